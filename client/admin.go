@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// AdminService calls the admin routes.
+type AdminService struct {
+	c *Client
+}
+
+// Healthz checks that the server is up.
+func (s *AdminService) Healthz(ctx context.Context) error {
+	resp, err := s.c.send(ctx, &request{method: http.MethodGet, path: "/admin/healthz", noAuth: true})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Reset deletes every user, and everything they own. It's only allowed on a dev server.
+func (s *AdminService) Reset(ctx context.Context) error {
+	resp, err := s.c.send(ctx, &request{method: http.MethodPost, path: "/admin/reset", noAuth: true})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// AuditsService calls the audit routes.
+type AuditsService struct {
+	c *Client
+}
+
+// AuditParams choose what to audit: a whole location, a case or a shelf. Only one is set.
+type AuditParams struct {
+	LocationID uuid.NullUUID `json:"location_id"`
+	CaseID     uuid.NullUUID `json:"case_id"`
+	ShelfID    uuid.NullUUID `json:"shelf_id"`
+}
+
+func (s *AuditsService) Create(ctx context.Context, params AuditParams, opts ...RequestOption) (Audit, error) {
+	var audit Audit
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: "/api/audits", body: params, opts: opts}, &audit)
+	return audit, err
+}
+
+func (s *AuditsService) Get(ctx context.Context, auditID uuid.UUID) (Audit, error) {
+	var audit Audit
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/audits/%s", auditID)}, &audit)
+	return audit, err
+}
+
+func (s *AuditsService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]Audit, error) {
+	var audits []Audit
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/audits", locationID)}, &audits)
+	return audits, err
+}
+
+// Scan records barcodes scanned on a shelf. The shelf can be left out when auditing a shelf.
+func (s *AuditsService) Scan(ctx context.Context, auditID uuid.UUID, shelfID uuid.NullUUID, barcodes []string) ([]AuditScanResult, error) {
+	var results []AuditScanResult
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/audits/%s/scans", auditID),
+		body:   map[string]any{"shelf_id": shelfID, "barcodes": barcodes},
+	}, &results)
+	return results, err
+}
+
+func (s *AuditsService) Report(ctx context.Context, auditID uuid.UUID) (AuditReport, error) {
+	var report AuditReport
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/audits/%s/report", auditID)}, &report)
+	return report, err
+}
+
+// Relocate moves misplaced items to the shelf they were found on: those given, or all of them
+// if items is empty.
+func (s *AuditsService) Relocate(ctx context.Context, auditID uuid.UUID, items []ItemRef) ([]AuditItem, error) {
+	var moved []AuditItem
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/audits/%s/relocate", auditID), body: map[string]any{"items": items}}, &moved)
+	return moved, err
+}
+
+// MarkMissing marks items that weren't found as missing: those given, or all of them if items
+// is empty.
+func (s *AuditsService) MarkMissing(ctx context.Context, auditID uuid.UUID, items []ItemRef) ([]AuditItem, error) {
+	var marked []AuditItem
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/audits/%s/mark_missing", auditID), body: map[string]any{"items": items}}, &marked)
+	return marked, err
+}
+
+func (s *AuditsService) Complete(ctx context.Context, auditID uuid.UUID) (Audit, error) {
+	var audit Audit
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/audits/%s/complete", auditID)}, &audit)
+	return audit, err
+}
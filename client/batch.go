@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// BatchService creates and moves items of any media type together.
+type BatchService struct {
+	c *Client
+}
+
+// BatchItem is an item to create. Item is what would be sent to its media type's Create, such
+// as a MovieParams.
+type BatchItem struct {
+	ItemType string `json:"item_type"`
+	Item     any    `json:"item"`
+}
+
+// Create creates every item, or none of them. When the batch fails, the response says which
+// item failed and why, alongside the *Error.
+func (s *BatchService) Create(ctx context.Context, items []BatchItem, opts ...RequestOption) (BatchResponse, error) {
+	return s.send(ctx, "/api/items:batch", map[string]any{"items": items}, opts)
+}
+
+// Move moves every item to the end of a shelf, or none of them.
+func (s *BatchService) Move(ctx context.Context, shelfID uuid.UUID, items []ItemRef, opts ...RequestOption) (BatchResponse, error) {
+	return s.send(ctx, "/api/items:move", map[string]any{"shelf_id": shelfID, "items": items}, opts)
+}
+
+func (s *BatchService) send(ctx context.Context, path string, body any, opts []RequestOption) (BatchResponse, error) {
+	var response BatchResponse
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: path, body: body, opts: opts}, &response)
+
+	// A failed batch still reports how each item went.
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		json.Unmarshal(apiErr.Body, &response)
+	}
+	return response, err
+}
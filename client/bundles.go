@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// BundlesService calls the bundle routes.
+type BundlesService struct {
+	c *Client
+}
+
+// BundleParams are the fields of a new bundle.
+type BundleParams struct {
+	Name    string    `json:"name"`
+	Barcode string    `json:"barcode"`
+	ShelfID uuid.UUID `json:"shelf_id"`
+}
+
+func (s *BundlesService) Create(ctx context.Context, params BundleParams, opts ...RequestOption) (Bundle, error) {
+	var bundle Bundle
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: "/api/bundles", body: params, opts: opts}, &bundle)
+	return bundle, err
+}
+
+// Get returns a bundle along with the items in it.
+func (s *BundlesService) Get(ctx context.Context, bundleID uuid.UUID) (BundleContents, error) {
+	var bundle BundleContents
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/bundles/%s", bundleID)}, &bundle)
+	return bundle, err
+}
+
+func (s *BundlesService) ListByShelf(ctx context.Context, shelfID uuid.UUID) ([]Bundle, error) {
+	var bundles []Bundle
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/shelves/%s/bundles", shelfID)}, &bundles)
+	return bundles, err
+}
+
+func (s *BundlesService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]Bundle, error) {
+	var bundles []Bundle
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/bundles", locationID)}, &bundles)
+	return bundles, err
+}
+
+// ListByBarcode lists the bundles with a barcode, at any of the logged in user's locations.
+func (s *BundlesService) ListByBarcode(ctx context.Context, barcode string) ([]Bundle, error) {
+	var bundles []Bundle
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/search/bundle_barcodes/%s", barcode)}, &bundles)
+	return bundles, err
+}
+
+// Move moves a bundle, and every item in it, to a shelf.
+func (s *BundlesService) Move(ctx context.Context, bundleID, shelfID uuid.UUID) (BundleContents, error) {
+	var bundle BundleContents
+	err := s.c.do(ctx, &request{
+		method: http.MethodPut,
+		path:   pathf("/api/bundles/%s", bundleID),
+		body:   map[string]string{"shelf_id": shelfID.String()},
+	}, &bundle)
+	return bundle, err
+}
+
+func (s *BundlesService) AddItem(ctx context.Context, bundleID uuid.UUID, item ItemRef) (BundleContents, error) {
+	var bundle BundleContents
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/bundles/%s/items", bundleID), body: item}, &bundle)
+	return bundle, err
+}
+
+func (s *BundlesService) RemoveItem(ctx context.Context, bundleID uuid.UUID, item ItemRef) error {
+	return s.c.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   pathf("/api/bundles/%s/items/%s/%s", bundleID, item.ItemType, item.ItemID),
+	}, nil)
+}
+
+// Delete deletes a bundle. The items in it are kept.
+func (s *BundlesService) Delete(ctx context.Context, bundleID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/bundles/%s", bundleID)}, nil)
+}
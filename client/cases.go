@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// CasesService calls the case routes.
+type CasesService struct {
+	c *Client
+}
+
+// CaseParams are the fields of a case that can be updated.
+type CaseParams struct {
+	Name    string `json:"name"`
+	Barcode string `json:"barcode"`
+}
+
+// Params returns the case's fields, to be changed and sent to Update.
+func (c Case) Params() CaseParams {
+	return CaseParams{Name: c.Name, Barcode: c.Barcode}
+}
+
+// Create adds a case to a location. Its barcode is generated.
+func (s *CasesService) Create(ctx context.Context, name string, locationID uuid.UUID, opts ...RequestOption) (Case, error) {
+	var c Case
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/cases",
+		body:   map[string]any{"name": name, "location_id": locationID},
+		opts:   opts,
+	}, &c)
+	return c, err
+}
+
+func (s *CasesService) List(ctx context.Context) ([]Case, error) {
+	var cases []Case
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: "/api/cases"}, &cases)
+	return cases, err
+}
+
+func (s *CasesService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]Case, error) {
+	var cases []Case
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/cases", locationID)}, &cases)
+	return cases, err
+}
+
+func (s *CasesService) Get(ctx context.Context, caseID uuid.UUID, opts ...RequestOption) (Case, error) {
+	var c Case
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/cases/%s", caseID), opts: opts}, &c)
+	return c, err
+}
+
+func (s *CasesService) Update(ctx context.Context, caseID uuid.UUID, params CaseParams, opts ...RequestOption) (Case, error) {
+	var c Case
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/cases/%s", caseID), body: params, opts: opts}, &c)
+	return c, err
+}
@@ -0,0 +1,394 @@
+// Package client is a Go client for the Digital Shelf API. It has a typed method for each API
+// route, grouped by the entities they work on, e.g. Client.Locations and Client.Movies.
+//
+// A client logs in with Login, or is given tokens with WithTokens. Its access token is
+// refreshed with the refresh token whenever it's about to expire, or is rejected as invalid.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshMargin is how long before its access token expires that a client refreshes it.
+const refreshMargin = time.Minute
+
+// Client calls the API of one server. It's safe to use from several goroutines.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	onRefresh  func(accessToken string)
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	// refreshing serializes refreshes, so that a burst of requests with an expired token
+	// only refreshes it once.
+	refreshing sync.Mutex
+
+	Users        *UsersService
+	Locations    *LocationsService
+	Cases        *CasesService
+	Shelves      *ShelvesService
+	Movies       *Items[Movie, MovieParams]
+	Shows        *Items[Show, ShowParams]
+	Books        *Items[Book, BookParams]
+	Music        *Items[Music, MusicParams]
+	Games        *Items[Game, GameParams]
+	Batch        *BatchService
+	Bundles      *BundlesService
+	Series       *SeriesService
+	CustomFields *CustomFieldsService
+	Audits       *AuditsService
+	ScanSessions *ScanSessionsService
+	Labels       *LabelsService
+	Search       *SearchService
+	SmartShelves *SmartShelvesService
+	Webhooks     *WebhooksService
+	Sync         *SyncService
+	Admin        *AdminService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with, instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokens sets the access and refresh tokens from an earlier login. The access token can be
+// empty, in which case one is fetched with the refresh token before the first request.
+func WithTokens(accessToken, refreshToken string) Option {
+	return func(c *Client) {
+		c.accessToken = accessToken
+		c.refreshToken = refreshToken
+	}
+}
+
+// WithTokenRefreshed calls fn with each new access token, e.g. so that it can be saved.
+func WithTokenRefreshed(fn func(accessToken string)) Option {
+	return func(c *Client) {
+		c.onRefresh = fn
+	}
+}
+
+// New returns a client for the server at baseURL, e.g. "https://shelf.example.com".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Users = &UsersService{c}
+	c.Locations = &LocationsService{c}
+	c.Cases = &CasesService{c}
+	c.Shelves = &ShelvesService{c}
+	c.Movies = &Items[Movie, MovieParams]{c: c, name: "movie", plural: "movies"}
+	c.Shows = &Items[Show, ShowParams]{c: c, name: "show", plural: "shows"}
+	c.Books = &Items[Book, BookParams]{c: c, name: "book", plural: "books"}
+	c.Music = &Items[Music, MusicParams]{c: c, name: "music", plural: "music"}
+	c.Games = &Items[Game, GameParams]{c: c, name: "game", plural: "games"}
+	c.Batch = &BatchService{c}
+	c.Bundles = &BundlesService{c}
+	c.Series = &SeriesService{c}
+	c.CustomFields = &CustomFieldsService{c}
+	c.Audits = &AuditsService{c}
+	c.ScanSessions = &ScanSessionsService{c}
+	c.Labels = &LabelsService{c}
+	c.Search = &SearchService{c}
+	c.SmartShelves = &SmartShelvesService{c}
+	c.Webhooks = &WebhooksService{c}
+	c.Sync = &SyncService{c}
+	c.Admin = &AdminService{c}
+	return c
+}
+
+// Tokens returns the client's current access and refresh tokens.
+func (c *Client) Tokens() (accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken, c.refreshToken
+}
+
+// SetTokens replaces the client's access and refresh tokens.
+func (c *Client) SetTokens(accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = accessToken
+	c.refreshToken = refreshToken
+}
+
+// Login logs in with an email and password. The client uses the tokens it gets for the
+// requests that follow.
+func (c *Client) Login(ctx context.Context, email, password string) (LoginResponse, error) {
+	var response LoginResponse
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/login",
+		body:   map[string]string{"email": email, "password": password},
+		noAuth: true,
+	}, &response)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+
+	c.SetTokens(response.Token, response.RefreshToken)
+	return response, nil
+}
+
+// Refresh gets a new access token with the client's refresh token. Requests do this on their
+// own when it's needed.
+func (c *Client) Refresh(ctx context.Context) error {
+	_, refreshToken := c.Tokens()
+	if refreshToken == "" {
+		return errors.New("client has no refresh token")
+	}
+
+	var response struct {
+		Token string `json:"token"`
+	}
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/refresh",
+		token:  refreshToken,
+	}, &response)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.accessToken = response.Token
+	c.mu.Unlock()
+	if c.onRefresh != nil {
+		c.onRefresh(response.Token)
+	}
+	return nil
+}
+
+// Revoke revokes the client's refresh token, logging it out, and forgets its tokens.
+func (c *Client) Revoke(ctx context.Context) error {
+	_, refreshToken := c.Tokens()
+	err := c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/revoke",
+		token:  refreshToken,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	c.SetTokens("", "")
+	return nil
+}
+
+// RevokeAll revokes every refresh token of the logged in user, logging out all of their
+// sessions.
+func (c *Client) RevokeAll(ctx context.Context) error {
+	return c.do(ctx, &request{method: http.MethodPost, path: "/api/revoke-all"}, nil)
+}
+
+// request is an API request to send.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	// body is encoded as JSON, unless it's nil.
+	body any
+	opts []RequestOption
+	// noAuth leaves out the Authorization header.
+	noAuth bool
+	// token is sent instead of the client's access token, without refreshing it.
+	token string
+}
+
+// do sends a request and decodes its JSON response into out, unless out is nil.
+func (c *Client) do(ctx context.Context, req *request, out any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response to %s %s: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// send sends a request, returning the response if it succeeded or an error if it didn't. The
+// response body must be closed.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request to %s %s: %w", req.method, req.path, err)
+		}
+	}
+
+	useToken := !req.noAuth && req.token == ""
+	if useToken {
+		if err := c.refreshIfExpiring(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.sendOnce(ctx, req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The access token may have expired while the request was sent, or been signed with a
+	// rotated secret, so it's refreshed and the request tried once more. Other 401s, such as
+	// the user not being a member of a location, aren't fixed by refreshing.
+	if resp.StatusCode == http.StatusUnauthorized && useToken && c.canRefresh() && (c.tokenExpiring() || tokenRejected(resp)) {
+		resp.Body.Close()
+		if err := c.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		resp, err = c.sendOnce(ctx, req, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+func (c *Client) sendOnce(ctx context.Context, req *request, body []byte) (*http.Response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case req.noAuth:
+	case req.token != "":
+		httpReq.Header.Set("Authorization", "Bearer "+req.token)
+	default:
+		if accessToken, _ := c.Tokens(); accessToken != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	}
+
+	var after []func(*http.Response)
+	for _, opt := range req.opts {
+		if fn := opt(httpReq); fn != nil {
+			after = append(after, fn)
+		}
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range after {
+		fn(resp)
+	}
+	return resp, nil
+}
+
+// tokenRejected reports whether the server rejected a request because its access token is
+// invalid or expired.
+func tokenRejected(resp *http.Response) bool {
+	return strings.Contains(resp.Header.Get("WWW-Authenticate"), `error="invalid_token"`)
+}
+
+func (c *Client) canRefresh() bool {
+	_, refreshToken := c.Tokens()
+	return refreshToken != ""
+}
+
+// refreshIfExpiring refreshes the access token if there isn't one, or it's about to expire.
+func (c *Client) refreshIfExpiring(ctx context.Context) error {
+	if !c.canRefresh() || !c.tokenExpiring() {
+		return nil
+	}
+
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+	// Another request may have refreshed it while this one waited.
+	if !c.tokenExpiring() {
+		return nil
+	}
+	return c.Refresh(ctx)
+}
+
+func (c *Client) tokenExpiring() bool {
+	accessToken, _ := c.Tokens()
+	if accessToken == "" {
+		return true
+	}
+	expiresAt, ok := tokenExpiry(accessToken)
+	return ok && time.Until(expiresAt) < refreshMargin
+}
+
+// tokenExpiry reads when a JWT expires, without checking its signature; the server does that.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.ExpiresAt, 0), true
+}
+
+// pathf builds a request path, escaping each argument as a path segment.
+func pathf(format string, args ...any) string {
+	escaped := make([]any, len(args))
+	for i, arg := range args {
+		escaped[i] = url.PathEscape(fmt.Sprint(arg))
+	}
+	return fmt.Sprintf(format, escaped...)
+}
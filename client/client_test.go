@@ -0,0 +1,429 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/auth"
+	"github.com/google/uuid"
+)
+
+// tokenServer is a stub API that accepts access tokens it has handed out by refreshing, and
+// records the Authorization headers it gets. If denied is set, it rejects requests with a
+// valid token as if the user isn't a member of the location.
+type tokenServer struct {
+	mu        sync.Mutex
+	valid     map[string]bool
+	denied    bool
+	refreshes int
+	headers   []string
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	header := r.Header.Get("Authorization")
+	s.headers = append(s.headers, header)
+
+	if r.URL.Path == "/api/refresh" {
+		if header != "Bearer refresh" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":"Invalid refresh token"}`)
+			return
+		}
+		s.refreshes++
+		token, _ := auth.MakeJWT(uuid.New(), "secret", time.Hour)
+		s.valid[token] = true
+		fmt.Fprintf(w, `{"token":%q}`, token)
+		return
+	}
+
+	if !s.valid[strings.TrimPrefix(header, "Bearer ")] {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"error":"Invalid token"}`)
+		return
+	}
+	if s.denied {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"error":"User is not a member of this location"}`)
+		return
+	}
+	io.WriteString(w, `[]`)
+}
+
+func TestTokenRefresh(t *testing.T) {
+	expired, _ := auth.MakeJWT(uuid.New(), "secret", -time.Minute)
+	expiring, _ := auth.MakeJWT(uuid.New(), "secret", 10*time.Second)
+	rejected, _ := auth.MakeJWT(uuid.New(), "secret", time.Hour)
+
+	tests := []struct {
+		name          string
+		accessToken   string
+		refreshToken  string
+		denied        bool
+		wantRefreshes int
+		wantRequests  int
+		wantStatus    int
+	}{
+		{
+			name:          "No access token",
+			refreshToken:  "refresh",
+			wantRefreshes: 1,
+			wantRequests:  2,
+		},
+		{
+			name:          "Expired access token",
+			accessToken:   expired,
+			refreshToken:  "refresh",
+			wantRefreshes: 1,
+			wantRequests:  2,
+		},
+		{
+			name:          "Access token about to expire",
+			accessToken:   expiring,
+			refreshToken:  "refresh",
+			wantRefreshes: 1,
+			wantRequests:  2,
+		},
+		{
+			name:          "Rejected access token",
+			accessToken:   rejected,
+			refreshToken:  "refresh",
+			wantRefreshes: 1,
+			wantRequests:  3,
+		},
+		{
+			name:          "Not a member",
+			refreshToken:  "refresh",
+			denied:        true,
+			wantRefreshes: 1,
+			wantRequests:  2,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:         "Rejected access token without refresh token",
+			accessToken:  rejected,
+			wantRequests: 1,
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "Invalid refresh token",
+			accessToken:  rejected,
+			refreshToken: "revoked",
+			wantRequests: 2,
+			wantStatus:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &tokenServer{valid: map[string]bool{}, denied: tt.denied}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			var refreshed string
+			c := New(server.URL, WithTokens(tt.accessToken, tt.refreshToken), WithTokenRefreshed(func(token string) {
+				refreshed = token
+			}))
+			_, err := c.Locations.List(context.Background())
+			if StatusCode(err) != tt.wantStatus || (tt.wantStatus == 0 && err != nil) {
+				t.Fatalf("List() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if stub.refreshes != tt.wantRefreshes {
+				t.Errorf("refreshes = %d, want %d", stub.refreshes, tt.wantRefreshes)
+			}
+			if len(stub.headers) != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", len(stub.headers), tt.wantRequests)
+			}
+
+			if tt.wantRefreshes > 0 {
+				accessToken, refreshToken := c.Tokens()
+				if accessToken != refreshed || !stub.valid[accessToken] {
+					t.Errorf("access token = %q, want the refreshed token %q", accessToken, refreshed)
+				}
+				if refreshToken != tt.refreshToken {
+					t.Errorf("refresh token = %q, want %q", refreshToken, tt.refreshToken)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrentRefresh(t *testing.T) {
+	stub := &tokenServer{valid: map[string]bool{}}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	c := New(server.URL, WithTokens("", "refresh"))
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Locations.List(context.Background()); err != nil {
+				t.Errorf("List() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stub.refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", stub.refreshes)
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	token, _ := auth.MakeJWT(uuid.New(), "secret", time.Hour)
+
+	tests := []struct {
+		name string
+		opts []Option
+		call func(*Client) error
+		want string
+	}{
+		{
+			name: "Access token",
+			opts: []Option{WithTokens(token, "refresh")},
+			call: func(c *Client) error {
+				_, err := c.Locations.List(context.Background())
+				return err
+			},
+			want: "Bearer " + token,
+		},
+		{
+			name: "Login",
+			opts: []Option{WithTokens(token, "refresh")},
+			call: func(c *Client) error {
+				_, err := c.Login(context.Background(), "user@example.com", "password")
+				return err
+			},
+			want: "",
+		},
+		{
+			name: "Revoke",
+			opts: []Option{WithTokens(token, "refresh")},
+			call: func(c *Client) error {
+				return c.Revoke(context.Background())
+			},
+			want: "Bearer refresh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				if r.Header.Get("Accept") != "application/json" {
+					t.Errorf("Accept = %q, want application/json", r.Header.Get("Accept"))
+				}
+				switch r.URL.Path {
+				case "/api/revoke":
+					w.WriteHeader(http.StatusNoContent)
+				case "/api/login":
+					io.WriteString(w, `{}`)
+				default:
+					io.WriteString(w, `[]`)
+				}
+			}))
+			defer server.Close()
+
+			if err := tt.call(New(server.URL, tt.opts...)); err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		etag        string
+		wantMessage string
+		check       func(error) bool
+	}{
+		{
+			name:        "JSON error",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error":"Location not found"}`,
+			wantMessage: "Location not found",
+			check:       IsNotFound,
+		},
+		{
+			name:        "Text error",
+			status:      http.StatusMethodNotAllowed,
+			contentType: "text/plain",
+			body:        "Method Not Allowed\n",
+			wantMessage: "Method Not Allowed",
+		},
+		{
+			name:        "Changed entity",
+			status:      http.StatusPreconditionFailed,
+			contentType: "application/json",
+			body:        `{"error":"Location has changed"}`,
+			etag:        `"abc"`,
+			wantMessage: "Location has changed",
+			check:       IsPreconditionFailed,
+		},
+		{
+			name:        "Idempotency-Key in use",
+			status:      http.StatusConflict,
+			contentType: "application/json",
+			body:        `{"error":"A request with this Idempotency-Key is in progress"}`,
+			wantMessage: "A request with this Idempotency-Key is in progress",
+			check:       IsConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			_, err := New(server.URL).Locations.Get(context.Background(), uuid.New())
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Get() error = %v, want an *Error", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.ETag != tt.etag {
+				t.Errorf("ETag = %q, want %q", apiErr.ETag, tt.etag)
+			}
+			if tt.check != nil && !tt.check(err) {
+				t.Errorf("error %v isn't the expected kind", err)
+			}
+		})
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	locationID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("Idempotency-Key") == "retried" {
+			w.Header().Set("Idempotent-Replayed", "true")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprintf(w, `{"id":%q,"name":"Home"}`, locationID)
+	}))
+	defer server.Close()
+	c := New(server.URL)
+	ctx := context.Background()
+
+	var etag string
+	location, err := c.Locations.Get(ctx, locationID, ETag(&etag))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if location.ID != locationID || etag != `"v1"` {
+		t.Errorf("Get() = %v with ETag %q, want %v with ETag %q", location.ID, etag, locationID, `"v1"`)
+	}
+
+	if _, err := c.Locations.Get(ctx, locationID, IfNoneMatch(etag)); !errors.Is(err, ErrNotModified) {
+		t.Errorf("Get() with IfNoneMatch error = %v, want ErrNotModified", err)
+	}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "first", want: false},
+		{key: "retried", want: true},
+	}
+	for _, tt := range tests {
+		var replayed bool
+		if _, err := c.Locations.Create(ctx, "Home", uuid.New(), IdempotencyKey(tt.key), Replayed(&replayed)); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if replayed != tt.want {
+			t.Errorf("Create() with key %q replayed = %v, want %v", tt.key, replayed, tt.want)
+		}
+	}
+}
+
+func TestBatchFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"The movie's shelf was not found","results":[{"index":0,"item_type":"movie","status":"rolled_back"},{"index":1,"item_type":"movie","status":"failed","error":"The movie's shelf was not found"}]}`)
+	}))
+	defer server.Close()
+
+	response, err := New(server.URL).Batch.Create(context.Background(), []BatchItem{
+		{ItemType: "movie", Item: MovieParams{Title: "Alien"}},
+		{ItemType: "movie", Item: MovieParams{Title: "Aliens"}},
+	})
+	if StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("Create() error = %v, want status 400", err)
+	}
+	if len(response.Results) != 2 || response.Results[0].Status != "rolled_back" || response.Results[1].Status != "failed" {
+		t.Errorf("Create() results = %+v, want the first rolled back and the second failed", response.Results)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	locationID := uuid.New()
+	eventIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, ": connected\n\n")
+		fmt.Fprintf(w, "id: 1\nevent: item.created\ndata: {\"id\":%q,\"type\":\"item.created\",\"location_id\":%q}\n\n", eventIDs[0], locationID)
+		io.WriteString(w, ": heartbeat\n\n")
+		fmt.Fprintf(w, "id: 2\nevent: item.deleted\ndata: {\"id\":%q,\"type\":\"item.deleted\",\"location_id\":%q}\n\n", eventIDs[1], locationID)
+	}))
+	defer server.Close()
+
+	stream, err := New(server.URL).Sync.Events(context.Background(), locationID)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	defer stream.Close()
+
+	for i, want := range []string{"item.created", "item.deleted"} {
+		event, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if event.ID != eventIDs[i] || event.Type != want || event.LocationID != locationID {
+			t.Errorf("Next() = %+v, want %s event %v in %v", event, want, eventIDs[i], locationID)
+		}
+	}
+	if _, err := stream.Next(); err != io.EOF {
+		t.Errorf("Next() at the end error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldsService calls the custom field routes.
+type CustomFieldsService struct {
+	c *Client
+}
+
+func (s *CustomFieldsService) Create(ctx context.Context, locationID uuid.UUID, params CustomFieldParams, opts ...RequestOption) (CustomField, error) {
+	var field CustomField
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/locations/%s/custom_fields", locationID), body: params, opts: opts}, &field)
+	return field, err
+}
+
+// List lists a location's custom fields, only those of itemType unless it's empty.
+func (s *CustomFieldsService) List(ctx context.Context, locationID uuid.UUID, itemType string) ([]CustomField, error) {
+	query := url.Values{}
+	if itemType != "" {
+		query.Set("item_type", itemType)
+	}
+
+	var fields []CustomField
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/custom_fields", locationID), query: query}, &fields)
+	return fields, err
+}
+
+func (s *CustomFieldsService) Delete(ctx context.Context, locationID, fieldID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/locations/%s/custom_fields/%s", locationID, fieldID)}, nil)
+}
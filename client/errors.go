@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotModified is returned by a request sent with IfNoneMatch when the entity hasn't changed.
+var ErrNotModified = errors.New("not modified")
+
+// maxErrorBody is how much of an error response is read.
+const maxErrorBody = 1 << 20
+
+// Error is an error response from the API.
+type Error struct {
+	StatusCode int
+	// Message is the response's error message, or its body if it wasn't JSON.
+	Message string
+	// ETag is the entity's current ETag, when a request sent with IfMatch failed because the
+	// entity has changed.
+	ETag string
+	// Body is the whole response body. Some errors, such as a failed batch, have more details
+	// in it than the message.
+	Body []byte
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// StatusCode returns the status code of an *Error in err's chain, or 0 if there isn't one.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether err is a 409 Conflict response, such as an Idempotency-Key
+// that's still in use.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsPreconditionFailed reports whether err is a 412 Precondition Failed response, because the
+// entity has changed since it was fetched.
+func IsPreconditionFailed(err error) bool {
+	return StatusCode(err) == http.StatusPreconditionFailed
+}
+
+func decodeError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return fmt.Errorf("reading %d response: %w", resp.StatusCode, err)
+	}
+
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		ETag:       resp.Header.Get("ETag"),
+		Body:       body,
+	}
+
+	var errorResponse struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+		apiErr.Message = errorResponse.Error
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Items calls the routes of one media type. T is the item, and P the fields that can be set.
+type Items[T, P any] struct {
+	c      *Client
+	name   string
+	plural string
+}
+
+// ListOptions narrow a list of items.
+type ListOptions struct {
+	// Decade only lists items from the decade starting that year, e.g. 1990. Only lists by
+	// location are filtered by decade.
+	Decade int
+	// SortByDate orders a list by location by release or publication date, instead of by title.
+	SortByDate bool
+	// CustomFields only lists items whose custom fields have these values.
+	CustomFields map[string]string
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Decade != 0 {
+		query.Set("decade", strconv.Itoa(o.Decade))
+	}
+	if o.SortByDate {
+		query.Set("sort", "release_date")
+	}
+	for name, value := range o.CustomFields {
+		query.Set("cf."+name, value)
+	}
+	return query
+}
+
+func (s *Items[T, P]) Create(ctx context.Context, params P, opts ...RequestOption) (T, error) {
+	var item T
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: "/api/" + s.plural, body: params, opts: opts}, &item)
+	return item, err
+}
+
+// CreateFromDraft creates an item from a scan session's draft, replacing the draft.
+func (s *Items[T, P]) CreateFromDraft(ctx context.Context, draftID uuid.UUID, params P, opts ...RequestOption) (T, error) {
+	var item T
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/" + s.plural,
+		query:  url.Values{"draft_id": {draftID.String()}},
+		body:   params,
+		opts:   opts,
+	}, &item)
+	return item, err
+}
+
+// List gets the items at every location the user is a member of.
+func (s *Items[T, P]) List(ctx context.Context) ([]T, error) {
+	var items []T
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: "/api/" + s.plural}, &items)
+	return items, err
+}
+
+func (s *Items[T, P]) Get(ctx context.Context, id uuid.UUID, opts ...RequestOption) (T, error) {
+	var item T
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/"+s.plural+"/%s", id), opts: opts}, &item)
+	return item, err
+}
+
+// Update sets every field of an item to params. Use the item's Params to change some of its
+// fields, or Patch.
+func (s *Items[T, P]) Update(ctx context.Context, id uuid.UUID, params P, opts ...RequestOption) (T, error) {
+	var item T
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/"+s.plural+"/%s", id), body: params, opts: opts}, &item)
+	return item, err
+}
+
+// Patch changes only the fields of an item that are given, by their JSON names. A nil
+// release_date clears it.
+func (s *Items[T, P]) Patch(ctx context.Context, id uuid.UUID, fields map[string]any, opts ...RequestOption) (T, error) {
+	var item T
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/"+s.plural+"/%s", id), body: fields, opts: opts}, &item)
+	return item, err
+}
+
+func (s *Items[T, P]) Delete(ctx context.Context, id uuid.UUID, opts ...RequestOption) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/"+s.plural+"/%s", id), opts: opts}, nil)
+}
+
+func (s *Items[T, P]) ListByShelf(ctx context.Context, shelfID uuid.UUID, opts ListOptions) ([]T, error) {
+	var items []T
+	err := s.c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   pathf("/api/shelves/%s/"+s.plural, shelfID),
+		query:  opts.query(),
+	}, &items)
+	return items, err
+}
+
+func (s *Items[T, P]) ListByLocation(ctx context.Context, locationID uuid.UUID, opts ListOptions) ([]T, error) {
+	var items []T
+	err := s.c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   pathf("/api/locations/%s/"+s.plural, locationID),
+		query:  opts.query(),
+	}, &items)
+	return items, err
+}
+
+// ListByBarcode lists the items with a barcode, at any of the logged in user's locations.
+func (s *Items[T, P]) ListByBarcode(ctx context.Context, barcode string) ([]T, error) {
+	var items []T
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/search/"+s.name+"_barcodes/%s", barcode)}, &items)
+	return items, err
+}
+
+// Search searches the items of a location, like SearchService.Location with a type filter.
+func (s *Items[T, P]) Search(ctx context.Context, locationID uuid.UUID, query string) (SearchResponse, error) {
+	var response SearchResponse
+	err := s.c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/api/search/" + s.plural,
+		body:   map[string]string{"location_id": locationID.String(), "query": query},
+	}, &response)
+	return response, err
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// LabelsService calls the label routes. Labels are returned as they're drawn: SVG for a single
+// label, and an HTML page for a sheet of them.
+type LabelsService struct {
+	c *Client
+}
+
+// LabelOptions choose how labels are printed. Layout is the name of a label sheet layout, or
+// empty for the server's default. The other options only apply to sheets: Skip leaves that
+// many labels at the start of the sheet empty, and CaseIDs and ShelfIDs choose which labels
+// are printed, otherwise every case and shelf is.
+type LabelOptions struct {
+	Layout   string
+	Skip     int
+	CaseIDs  []uuid.UUID
+	ShelfIDs []uuid.UUID
+}
+
+func (o LabelOptions) query() url.Values {
+	query := url.Values{}
+	if o.Layout != "" {
+		query.Set("layout", o.Layout)
+	}
+	if o.Skip != 0 {
+		query.Set("skip", strconv.Itoa(o.Skip))
+	}
+	for _, id := range o.CaseIDs {
+		query.Add("case_id", id.String())
+	}
+	for _, id := range o.ShelfIDs {
+		query.Add("shelf_id", id.String())
+	}
+	return query
+}
+
+// Shelf returns a shelf's label as SVG.
+func (s *LabelsService) Shelf(ctx context.Context, shelfID uuid.UUID, opts LabelOptions) ([]byte, error) {
+	return s.get(ctx, pathf("/api/shelves/%s/label", shelfID), opts)
+}
+
+// Case returns a case's label as SVG.
+func (s *LabelsService) Case(ctx context.Context, caseID uuid.UUID, opts LabelOptions) ([]byte, error) {
+	return s.get(ctx, pathf("/api/cases/%s/label", caseID), opts)
+}
+
+// Location returns a location's labels as an HTML page, laid out for printing.
+func (s *LabelsService) Location(ctx context.Context, locationID uuid.UUID, opts LabelOptions) ([]byte, error) {
+	return s.get(ctx, pathf("/api/locations/%s/labels", locationID), opts)
+}
+
+func (s *LabelsService) get(ctx context.Context, path string, opts LabelOptions) ([]byte, error) {
+	resp, err := s.c.send(ctx, &request{method: http.MethodGet, path: path, query: opts.query()})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
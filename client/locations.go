@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// LocationsService calls the location, member and invite routes.
+type LocationsService struct {
+	c *Client
+}
+
+func (s *LocationsService) Create(ctx context.Context, name string, ownerID uuid.UUID, opts ...RequestOption) (Location, error) {
+	var location Location
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/locations",
+		body:   map[string]any{"name": name, "owner_id": ownerID},
+		opts:   opts,
+	}, &location)
+	return location, err
+}
+
+func (s *LocationsService) List(ctx context.Context) ([]Location, error) {
+	var locations []Location
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: "/api/locations"}, &locations)
+	return locations, err
+}
+
+func (s *LocationsService) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]Location, error) {
+	var locations []Location
+	err := s.c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/api/search/locations/",
+		query:  url.Values{"owner_id": {ownerID.String()}},
+	}, &locations)
+	return locations, err
+}
+
+func (s *LocationsService) Get(ctx context.Context, locationID uuid.UUID, opts ...RequestOption) (Location, error) {
+	var location Location
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s", locationID), opts: opts}, &location)
+	return location, err
+}
+
+func (s *LocationsService) Stats(ctx context.Context, locationID uuid.UUID) (LocationStats, error) {
+	var stats LocationStats
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/stats", locationID)}, &stats)
+	return stats, err
+}
+
+func (s *LocationsService) Members(ctx context.Context, locationID uuid.UUID) ([]LocationMember, error) {
+	var members []LocationMember
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/members", locationID)}, &members)
+	return members, err
+}
+
+// AddMember adds a user to a location. The location's owner can add anyone, and an invited
+// user can add themselves, accepting the invite.
+func (s *LocationsService) AddMember(ctx context.Context, locationID, userID uuid.UUID) (NewLocationUser, error) {
+	var member NewLocationUser
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/locations/%s/members", locationID),
+		body:   map[string]any{"user_id": userID},
+	}, &member)
+	return member, err
+}
+
+func (s *LocationsService) RemoveMember(ctx context.Context, locationID, userID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/locations/%s/members/%s", locationID, userID)}, nil)
+}
+
+func (s *LocationsService) Invites(ctx context.Context, locationID uuid.UUID) ([]LocationInvite, error) {
+	var invites []LocationInvite
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/invites", locationID)}, &invites)
+	return invites, err
+}
+
+func (s *LocationsService) Invite(ctx context.Context, locationID, userID uuid.UUID, opts ...RequestOption) (NewLocationInvite, error) {
+	var invite NewLocationInvite
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/locations/%s/invites", locationID),
+		body:   map[string]any{"user_id": userID},
+		opts:   opts,
+	}, &invite)
+	return invite, err
+}
+
+// RemoveInvite withdraws an invite, or declines it when userID is the invited user.
+func (s *LocationsService) RemoveInvite(ctx context.Context, locationID, userID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/locations/%s/invites/%s", locationID, userID)}, nil)
+}
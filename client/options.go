@@ -0,0 +1,54 @@
+package client
+
+import "net/http"
+
+// RequestOption changes a single request. It can return a function to be called with the
+// response.
+type RequestOption func(*http.Request) func(*http.Response)
+
+// IfMatch only applies an update or delete if the entity's ETag is still etag, so that a
+// change someone else has made in the meantime isn't overwritten. Otherwise the request fails
+// with a 412 *Error holding the current ETag.
+func IfMatch(etag string) RequestOption {
+	return func(req *http.Request) func(*http.Response) {
+		req.Header.Set("If-Match", etag)
+		return nil
+	}
+}
+
+// IfNoneMatch only fetches an entity if its ETag is no longer etag. Otherwise the request
+// fails with ErrNotModified.
+func IfNoneMatch(etag string) RequestOption {
+	return func(req *http.Request) func(*http.Response) {
+		req.Header.Set("If-None-Match", etag)
+		return nil
+	}
+}
+
+// IdempotencyKey lets a create request be retried safely: a retry with the same key gets the
+// first response again, instead of creating another entity.
+func IdempotencyKey(key string) RequestOption {
+	return func(req *http.Request) func(*http.Response) {
+		req.Header.Set("Idempotency-Key", key)
+		return nil
+	}
+}
+
+// ETag stores the ETag of the response in dst, for use with IfMatch or IfNoneMatch.
+func ETag(dst *string) RequestOption {
+	return func(*http.Request) func(*http.Response) {
+		return func(resp *http.Response) {
+			*dst = resp.Header.Get("ETag")
+		}
+	}
+}
+
+// Replayed sets dst to whether the response was a replay of an earlier request with the same
+// IdempotencyKey.
+func Replayed(dst *bool) RequestOption {
+	return func(*http.Request) func(*http.Response) {
+		return func(resp *http.Response) {
+			*dst = resp.Header.Get("Idempotent-Replayed") == "true"
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ScanSessionsService calls the scan session routes.
+type ScanSessionsService struct {
+	c *Client
+}
+
+// Create starts a scan session at a location, optionally on a shelf.
+func (s *ScanSessionsService) Create(ctx context.Context, locationID uuid.UUID, shelfID uuid.NullUUID, opts ...RequestOption) (ScanSession, error) {
+	var session ScanSession
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/locations/%s/scan_sessions", locationID),
+		body:   map[string]any{"shelf_id": shelfID},
+		opts:   opts,
+	}, &session)
+	return session, err
+}
+
+func (s *ScanSessionsService) Get(ctx context.Context, sessionID uuid.UUID) (ScanSession, error) {
+	var session ScanSession
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/scan_sessions/%s", sessionID)}, &session)
+	return session, err
+}
+
+// Scan handles a scanned barcode. Shelf and case barcodes change the session's shelf. An item
+// at the location is moved to the shelf, and any other barcode is added as a draft.
+func (s *ScanSessionsService) Scan(ctx context.Context, sessionID uuid.UUID, barcode string) (ScanResult, error) {
+	var result ScanResult
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/scan_sessions/%s/scans", sessionID),
+		body:   map[string]string{"barcode": barcode},
+	}, &result)
+	return result, err
+}
+
+func (s *ScanSessionsService) Close(ctx context.Context, sessionID uuid.UUID) (ScanSession, error) {
+	var session ScanSession
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/scan_sessions/%s/close", sessionID)}, &session)
+	return session, err
+}
+
+func (s *ScanSessionsService) Drafts(ctx context.Context, sessionID uuid.UUID) ([]ScanDraft, error) {
+	var drafts []ScanDraft
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/scan_sessions/%s/drafts", sessionID)}, &drafts)
+	return drafts, err
+}
+
+func (s *ScanSessionsService) DeleteDraft(ctx context.Context, draftID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/scan_drafts/%s", draftID)}, nil)
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// SearchService calls the search routes that cover every media type.
+type SearchService struct {
+	c *Client
+}
+
+// Location searches every media type at a location. Filters narrow the results by facet:
+// "type", "genre", "format", "decade" or "shelf_id", each matching any of its values.
+func (s *SearchService) Location(ctx context.Context, locationID uuid.UUID, query string, filters map[string][]string) (SearchResponse, error) {
+	values := url.Values{"q": {query}}
+	for name, filterValues := range filters {
+		values[name] = filterValues
+	}
+
+	var response SearchResponse
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/search", locationID), query: values}, &response)
+	return response, err
+}
+
+// UserSearchOptions narrow a search of a user's locations to one case or shelf.
+type UserSearchOptions struct {
+	CaseID  uuid.UUID
+	ShelfID uuid.UUID
+}
+
+// User searches every media type at all of a user's locations, grouping the results by
+// location.
+func (s *SearchService) User(ctx context.Context, userID uuid.UUID, query string, opts UserSearchOptions) (UserSearchResponse, error) {
+	values := url.Values{"q": {query}}
+	if opts.CaseID != uuid.Nil {
+		values.Set("case_id", opts.CaseID.String())
+	}
+	if opts.ShelfID != uuid.Nil {
+		values.Set("shelf_id", opts.ShelfID.String())
+	}
+
+	var response UserSearchResponse
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/users/%s/search", userID), query: values}, &response)
+	return response, err
+}
+
+// Autocomplete suggests titles at a location for the text typed so far. A limit of 0 uses the
+// server's default.
+func (s *SearchService) Autocomplete(ctx context.Context, locationID uuid.UUID, query string, limit int) ([]Suggestion, error) {
+	values := url.Values{"q": {query}}
+	if limit != 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var suggestions []Suggestion
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/autocomplete", locationID), query: values}, &suggestions)
+	return suggestions, err
+}
+
+// Barcode finds the items of every media type with a barcode, and where each is kept. It
+// looks at all of the logged in user's locations, or only locationID unless it's uuid.Nil.
+func (s *SearchService) Barcode(ctx context.Context, barcode string, locationID uuid.UUID) ([]BarcodeMatch, error) {
+	values := url.Values{}
+	if locationID != uuid.Nil {
+		values.Set("location_id", locationID.String())
+	}
+
+	var matches []BarcodeMatch
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/search/barcodes/%s", barcode), query: values}, &matches)
+	return matches, err
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// SeriesService calls the series and episode routes.
+type SeriesService struct {
+	c *Client
+}
+
+func (s *SeriesService) Create(ctx context.Context, title string, locationID uuid.UUID, opts ...RequestOption) (Series, error) {
+	var series Series
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/series",
+		body:   map[string]any{"title": title, "location_id": locationID},
+		opts:   opts,
+	}, &series)
+	return series, err
+}
+
+func (s *SeriesService) Get(ctx context.Context, seriesID uuid.UUID) (Series, error) {
+	var series Series
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/series/%s", seriesID)}, &series)
+	return series, err
+}
+
+func (s *SeriesService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]Series, error) {
+	var series []Series
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/series", locationID)}, &series)
+	return series, err
+}
+
+func (s *SeriesService) CreateEpisode(ctx context.Context, seriesID uuid.UUID, params EpisodeParams, opts ...RequestOption) (Episode, error) {
+	var episode Episode
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/series/%s/episodes", seriesID), body: params, opts: opts}, &episode)
+	return episode, err
+}
+
+func (s *SeriesService) Episodes(ctx context.Context, seriesID uuid.UUID) ([]Episode, error) {
+	var episodes []Episode
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/series/%s/episodes", seriesID)}, &episodes)
+	return episodes, err
+}
+
+// ShowEpisodes lists the episodes on a show's discs.
+func (s *SeriesService) ShowEpisodes(ctx context.Context, showID uuid.UUID) ([]Episode, error) {
+	var episodes []Episode
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/shows/%s/episodes", showID)}, &episodes)
+	return episodes, err
+}
+
+func (s *SeriesService) MarkWatched(ctx context.Context, episodeID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/episodes/%s/watched", episodeID)}, nil)
+}
+
+func (s *SeriesService) UnmarkWatched(ctx context.Context, episodeID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/episodes/%s/watched", episodeID)}, nil)
+}
+
+// NextEpisodes lists the next episode to watch of each series the user has started.
+func (s *SeriesService) NextEpisodes(ctx context.Context, userID uuid.UUID) ([]NextEpisode, error) {
+	var episodes []NextEpisode
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/users/%s/next_episodes", userID)}, &episodes)
+	return episodes, err
+}
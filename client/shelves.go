@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ShelvesService calls the shelf routes.
+type ShelvesService struct {
+	c *Client
+}
+
+// ShelfParams are the fields of a shelf that can be set. Barcode is generated when a shelf is
+// created, and can only be changed by Update.
+type ShelfParams struct {
+	Name     string   `json:"name"`
+	Barcode  string   `json:"barcode,omitempty"`
+	Capacity *int32   `json:"capacity"`
+	WidthCM  *float64 `json:"width_cm"`
+}
+
+// Params returns the shelf's fields, to be changed and sent to Update.
+func (s Shelf) Params() ShelfParams {
+	return ShelfParams{Name: s.Name, Barcode: s.Barcode, Capacity: s.Capacity, WidthCM: s.WidthCM}
+}
+
+func (s *ShelvesService) Create(ctx context.Context, caseID uuid.UUID, params ShelfParams, opts ...RequestOption) (Shelf, error) {
+	var shelf Shelf
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/shelves",
+		body: map[string]any{
+			"name":     params.Name,
+			"case_id":  caseID,
+			"capacity": params.Capacity,
+			"width_cm": params.WidthCM,
+		},
+		opts: opts,
+	}, &shelf)
+	return shelf, err
+}
+
+func (s *ShelvesService) List(ctx context.Context) ([]Shelf, error) {
+	var shelves []Shelf
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: "/api/shelves"}, &shelves)
+	return shelves, err
+}
+
+// ListByCase lists a case's shelves, along with how full each is.
+func (s *ShelvesService) ListByCase(ctx context.Context, caseID uuid.UUID) ([]ShelfWithFullness, error) {
+	var shelves []ShelfWithFullness
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/cases/%s/shelves", caseID)}, &shelves)
+	return shelves, err
+}
+
+// ListByLocation lists every shelf at a location, alongside its smart shelves.
+func (s *ShelvesService) ListByLocation(ctx context.Context, locationID uuid.UUID) (LocationShelves, error) {
+	var shelves LocationShelves
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/shelves", locationID)}, &shelves)
+	return shelves, err
+}
+
+func (s *ShelvesService) Get(ctx context.Context, shelfID uuid.UUID, opts ...RequestOption) (Shelf, error) {
+	var shelf Shelf
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/shelves/%s", shelfID), opts: opts}, &shelf)
+	return shelf, err
+}
+
+func (s *ShelvesService) Update(ctx context.Context, shelfID uuid.UUID, params ShelfParams, opts ...RequestOption) (Shelf, error) {
+	var shelf Shelf
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/shelves/%s", shelfID), body: params, opts: opts}, &shelf)
+	return shelf, err
+}
+
+// Items lists the items of every media type on a shelf, in shelf order.
+func (s *ShelvesService) Items(ctx context.Context, shelfID uuid.UUID) ([]ShelfItem, error) {
+	var items []ShelfItem
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/shelves/%s/items", shelfID)}, &items)
+	return items, err
+}
+
+// Reorder moves the items given to the front of a shelf, in that order. The items that aren't
+// listed keep their order after them.
+func (s *ShelvesService) Reorder(ctx context.Context, shelfID uuid.UUID, items []ItemRef) ([]ShelfItem, error) {
+	var ordered []ShelfItem
+	err := s.c.do(ctx, &request{
+		method: http.MethodPut,
+		path:   pathf("/api/shelves/%s/order", shelfID),
+		body:   map[string]any{"items": items},
+	}, &ordered)
+	return ordered, err
+}
+
+// Sort orders a shelf's items by mode, "title" or "release_date".
+func (s *ShelvesService) Sort(ctx context.Context, shelfID uuid.UUID, mode string) ([]ShelfItem, error) {
+	var sorted []ShelfItem
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/shelves/%s/sort", shelfID),
+		body:   map[string]string{"mode": mode},
+	}, &sorted)
+	return sorted, err
+}
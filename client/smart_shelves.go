@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// SmartShelvesService calls the smart shelf routes.
+type SmartShelvesService struct {
+	c *Client
+}
+
+func (s *SmartShelvesService) Create(ctx context.Context, locationID uuid.UUID, params SmartShelfParams, opts ...RequestOption) (SmartShelf, error) {
+	var smartShelf SmartShelf
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/locations/%s/smart_shelves", locationID), body: params, opts: opts}, &smartShelf)
+	return smartShelf, err
+}
+
+func (s *SmartShelvesService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]SmartShelf, error) {
+	var smartShelves []SmartShelf
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/smart_shelves", locationID)}, &smartShelves)
+	return smartShelves, err
+}
+
+func (s *SmartShelvesService) Get(ctx context.Context, smartShelfID uuid.UUID, opts ...RequestOption) (SmartShelf, error) {
+	var smartShelf SmartShelf
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/smart_shelves/%s", smartShelfID), opts: opts}, &smartShelf)
+	return smartShelf, err
+}
+
+func (s *SmartShelvesService) Update(ctx context.Context, smartShelfID uuid.UUID, params SmartShelfParams, opts ...RequestOption) (SmartShelf, error) {
+	var smartShelf SmartShelf
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/smart_shelves/%s", smartShelfID), body: params, opts: opts}, &smartShelf)
+	return smartShelf, err
+}
+
+func (s *SmartShelvesService) Delete(ctx context.Context, smartShelfID uuid.UUID, opts ...RequestOption) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/smart_shelves/%s", smartShelfID), opts: opts}, nil)
+}
+
+// Items runs a smart shelf's search.
+func (s *SmartShelvesService) Items(ctx context.Context, smartShelfID uuid.UUID) (SearchResponse, error) {
+	var response SearchResponse
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/smart_shelves/%s/items", smartShelfID)}, &response)
+	return response, err
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// SyncService calls the routes that keep a copy of a location up to date: its changes, and its
+// live events.
+type SyncService struct {
+	c *Client
+}
+
+// Changes lists the latest change to each of a location's cases, shelves and items since a
+// cursor, oldest first. Pass 0 to fetch everything, then the Cursor of the previous page while
+// HasMore is set. A limit of 0 uses the server's default.
+func (s *SyncService) Changes(ctx context.Context, locationID uuid.UUID, since int64, limit int) (ChangesResponse, error) {
+	query := url.Values{"since": {strconv.FormatInt(since, 10)}}
+	if limit != 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var response ChangesResponse
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/changes", locationID), query: query}, &response)
+	return response, err
+}
+
+// Apply applies changes made while offline, reporting how each went.
+func (s *SyncService) Apply(ctx context.Context, locationID uuid.UUID, changes []OfflineChange) (OfflineChangesResponse, error) {
+	var response OfflineChangesResponse
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   pathf("/api/locations/%s/changes", locationID),
+		body:   map[string]any{"changes": changes},
+	}, &response)
+	return response, err
+}
+
+// Events opens a stream of a location's live events. The stream stays open until ctx is done,
+// it's closed, or the user is removed from the location, so the client's http.Client must not
+// have a Timeout.
+func (s *SyncService) Events(ctx context.Context, locationID uuid.UUID) (*EventStream, error) {
+	resp, err := s.c.send(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/events", locationID)})
+	if err != nil {
+		return nil, err
+	}
+	return &EventStream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// EventStream reads the events of a location as they happen.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Next waits for the next event. It returns io.EOF once the stream has ended.
+func (s *EventStream) Next() (Event, error) {
+	var data bytes.Buffer
+	for s.scanner.Scan() {
+		line := s.scanner.Bytes()
+		if len(line) == 0 {
+			if data.Len() == 0 {
+				// The end of a comment, such as a heartbeat.
+				continue
+			}
+
+			var event Event
+			if err := json.Unmarshal(data.Bytes(), &event); err != nil {
+				return Event{}, fmt.Errorf("decoding event: %w", err)
+			}
+			return event, nil
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		if string(field) == "data" {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return Event{}, err
+	}
+	return Event{}, io.EOF
+}
+
+// Close closes the stream.
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,899 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dates that may only be known to the year or month, such as release dates, are strings
+// formatted as YYYY, YYYY-MM or YYYY-MM-DD, and are empty when unknown.
+
+type User struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LoginResponse is the logged in user, along with their tokens.
+type LoginResponse struct {
+	User
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type Location struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NewLocationUser struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	JoinedAt   time.Time `json:"joined_at"`
+}
+
+type LocationMember struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"userID"`
+	UserName   string    `json:"user_name"`
+	UserEmail  string    `json:"user_email"`
+	JoinedAt   time.Time `json:"joined_at"`
+}
+
+type UserLocation struct {
+	UserID       uuid.UUID `json:"userID"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	JoinedAt     time.Time `json:"joined_at"`
+}
+
+type NewLocationInvite struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	InvitedAt  time.Time `json:"invited_at"`
+}
+
+type LocationInvite struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"userID"`
+	UserName   string    `json:"user_name"`
+	UserEmail  string    `json:"user_email"`
+	InvitedAt  time.Time `json:"invited_at"`
+}
+
+type UserInvite struct {
+	UserID       uuid.UUID `json:"userID"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	InvitedAt    time.Time `json:"invited_at"`
+}
+
+type Case struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	LocationID uuid.UUID `json:"location_id"`
+	Barcode    string    `json:"barcode"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Shelf struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CaseID    uuid.UUID `json:"case_id"`
+	Barcode   string    `json:"barcode"`
+	Capacity  *int32    `json:"capacity"`
+	WidthCM   *float64  `json:"width_cm"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ShelfWithFullness is a shelf along with how full it is.
+type ShelfWithFullness struct {
+	Shelf
+	Fullness ShelfFullness `json:"fullness"`
+}
+
+type ShelfFullness struct {
+	ItemCount       int64    `json:"item_count"`
+	UsedWidthCM     float64  `json:"used_width_cm"`
+	UnmeasuredItems int64    `json:"unmeasured_items"`
+	Percent         *float64 `json:"percent"`
+	Full            bool     `json:"full"`
+}
+
+// ShelfItem is an item of any media type on a shelf, in shelf order.
+type ShelfItem struct {
+	ItemType    string    `json:"item_type"`
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Position    int32     `json:"position"`
+	ThicknessCM float64   `json:"thickness_cm"`
+}
+
+// ItemRef names an item of any media type.
+type ItemRef struct {
+	ItemType string    `json:"item_type"`
+	ItemID   uuid.UUID `json:"item_id"`
+}
+
+type Movie struct {
+	ID                uuid.UUID      `json:"id"`
+	Title             string         `json:"title"`
+	Genre             string         `json:"genre"`
+	Actors            string         `json:"actors"`
+	Writer            string         `json:"writer"`
+	Director          string         `json:"director"`
+	Barcode           string         `json:"barcode"`
+	Format            string         `json:"format"`
+	ShelfID           uuid.UUID      `json:"shelf_id"`
+	ReleaseDate       string         `json:"release_date"`
+	RuntimeMinutes    int32          `json:"runtime_minutes"`
+	ContentRating     string         `json:"content_rating"`
+	DiscRegion        string         `json:"disc_region"`
+	AudioLanguages    string         `json:"audio_languages"`
+	SubtitleLanguages string         `json:"subtitle_languages"`
+	Position          int32          `json:"position"`
+	ThicknessCM       float64        `json:"thickness_cm"`
+	MissingSince      *time.Time     `json:"missing_since"`
+	CustomFields      map[string]any `json:"custom_fields"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// MovieParams are the fields of a movie that can be set.
+type MovieParams struct {
+	Title             string         `json:"title"`
+	Genre             string         `json:"genre"`
+	Actors            string         `json:"actors"`
+	Writer            string         `json:"writer"`
+	Director          string         `json:"director"`
+	Barcode           string         `json:"barcode"`
+	Format            string         `json:"format"`
+	ShelfID           uuid.UUID      `json:"shelf_id"`
+	ReleaseDate       string         `json:"release_date,omitempty"`
+	RuntimeMinutes    int32          `json:"runtime_minutes"`
+	ContentRating     string         `json:"content_rating"`
+	DiscRegion        string         `json:"disc_region"`
+	AudioLanguages    string         `json:"audio_languages"`
+	SubtitleLanguages string         `json:"subtitle_languages"`
+	ThicknessCM       float64        `json:"thickness_cm"`
+	CustomFields      map[string]any `json:"custom_fields,omitempty"`
+}
+
+// Params returns the movie's fields, to be changed and sent to Update.
+func (m Movie) Params() MovieParams {
+	return MovieParams{
+		Title:             m.Title,
+		Genre:             m.Genre,
+		Actors:            m.Actors,
+		Writer:            m.Writer,
+		Director:          m.Director,
+		Barcode:           m.Barcode,
+		Format:            m.Format,
+		ShelfID:           m.ShelfID,
+		ReleaseDate:       m.ReleaseDate,
+		RuntimeMinutes:    m.RuntimeMinutes,
+		ContentRating:     m.ContentRating,
+		DiscRegion:        m.DiscRegion,
+		AudioLanguages:    m.AudioLanguages,
+		SubtitleLanguages: m.SubtitleLanguages,
+		ThicknessCM:       m.ThicknessCM,
+		CustomFields:      m.CustomFields,
+	}
+}
+
+type Show struct {
+	ID                uuid.UUID      `json:"id"`
+	Title             string         `json:"title"`
+	Season            string         `json:"season"`
+	Genre             string         `json:"genre"`
+	Actors            string         `json:"actors"`
+	Writer            string         `json:"writer"`
+	Director          string         `json:"director"`
+	Barcode           string         `json:"barcode"`
+	Format            string         `json:"format"`
+	ShelfID           uuid.UUID      `json:"shelf_id"`
+	ReleaseDate       string         `json:"release_date"`
+	RuntimeMinutes    int32          `json:"runtime_minutes"`
+	ContentRating     string         `json:"content_rating"`
+	DiscRegion        string         `json:"disc_region"`
+	AudioLanguages    string         `json:"audio_languages"`
+	SubtitleLanguages string         `json:"subtitle_languages"`
+	Position          int32          `json:"position"`
+	ThicknessCM       float64        `json:"thickness_cm"`
+	MissingSince      *time.Time     `json:"missing_since"`
+	CustomFields      map[string]any `json:"custom_fields"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// ShowParams are the fields of a show that can be set.
+type ShowParams struct {
+	Title             string         `json:"title"`
+	Season            string         `json:"season"`
+	Genre             string         `json:"genre"`
+	Actors            string         `json:"actors"`
+	Writer            string         `json:"writer"`
+	Director          string         `json:"director"`
+	Barcode           string         `json:"barcode"`
+	Format            string         `json:"format"`
+	ShelfID           uuid.UUID      `json:"shelf_id"`
+	ReleaseDate       string         `json:"release_date,omitempty"`
+	RuntimeMinutes    int32          `json:"runtime_minutes"`
+	ContentRating     string         `json:"content_rating"`
+	DiscRegion        string         `json:"disc_region"`
+	AudioLanguages    string         `json:"audio_languages"`
+	SubtitleLanguages string         `json:"subtitle_languages"`
+	ThicknessCM       float64        `json:"thickness_cm"`
+	CustomFields      map[string]any `json:"custom_fields,omitempty"`
+}
+
+// Params returns the show's fields, to be changed and sent to Update.
+func (s Show) Params() ShowParams {
+	return ShowParams{
+		Title:             s.Title,
+		Season:            s.Season,
+		Genre:             s.Genre,
+		Actors:            s.Actors,
+		Writer:            s.Writer,
+		Director:          s.Director,
+		Barcode:           s.Barcode,
+		Format:            s.Format,
+		ShelfID:           s.ShelfID,
+		ReleaseDate:       s.ReleaseDate,
+		RuntimeMinutes:    s.RuntimeMinutes,
+		ContentRating:     s.ContentRating,
+		DiscRegion:        s.DiscRegion,
+		AudioLanguages:    s.AudioLanguages,
+		SubtitleLanguages: s.SubtitleLanguages,
+		ThicknessCM:       s.ThicknessCM,
+		CustomFields:      s.CustomFields,
+	}
+}
+
+type Book struct {
+	ID              uuid.UUID      `json:"id"`
+	Title           string         `json:"title"`
+	Author          string         `json:"author"`
+	Genre           string         `json:"genre"`
+	Barcode         string         `json:"barcode"`
+	ShelfID         uuid.UUID      `json:"shelf_id"`
+	PublicationDate string         `json:"publication_date"`
+	ISBN            string         `json:"isbn"`
+	Publisher       string         `json:"publisher"`
+	PageCount       int32          `json:"page_count"`
+	Edition         string         `json:"edition"`
+	Language        string         `json:"language"`
+	Series          string         `json:"series"`
+	SeriesNumber    string         `json:"series_number"`
+	Position        int32          `json:"position"`
+	ThicknessCM     float64        `json:"thickness_cm"`
+	MissingSince    *time.Time     `json:"missing_since"`
+	CustomFields    map[string]any `json:"custom_fields"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// BookParams are the fields of a book that can be set.
+type BookParams struct {
+	Title           string         `json:"title"`
+	Author          string         `json:"author"`
+	Genre           string         `json:"genre"`
+	Barcode         string         `json:"barcode"`
+	ShelfID         uuid.UUID      `json:"shelf_id"`
+	PublicationDate string         `json:"publication_date,omitempty"`
+	ISBN            string         `json:"isbn"`
+	Publisher       string         `json:"publisher"`
+	PageCount       int32          `json:"page_count"`
+	Edition         string         `json:"edition"`
+	Language        string         `json:"language"`
+	Series          string         `json:"series"`
+	SeriesNumber    string         `json:"series_number"`
+	ThicknessCM     float64        `json:"thickness_cm"`
+	CustomFields    map[string]any `json:"custom_fields,omitempty"`
+}
+
+// Params returns the book's fields, to be changed and sent to Update.
+func (b Book) Params() BookParams {
+	return BookParams{
+		Title:           b.Title,
+		Author:          b.Author,
+		Genre:           b.Genre,
+		Barcode:         b.Barcode,
+		ShelfID:         b.ShelfID,
+		PublicationDate: b.PublicationDate,
+		ISBN:            b.ISBN,
+		Publisher:       b.Publisher,
+		PageCount:       b.PageCount,
+		Edition:         b.Edition,
+		Language:        b.Language,
+		Series:          b.Series,
+		SeriesNumber:    b.SeriesNumber,
+		ThicknessCM:     b.ThicknessCM,
+		CustomFields:    b.CustomFields,
+	}
+}
+
+type Music struct {
+	ID            uuid.UUID      `json:"id"`
+	Title         string         `json:"title"`
+	Artist        string         `json:"artist"`
+	Genre         string         `json:"genre"`
+	Barcode       string         `json:"barcode"`
+	Format        string         `json:"format"`
+	ShelfID       uuid.UUID      `json:"shelf_id"`
+	ReleaseDate   string         `json:"release_date"`
+	Label         string         `json:"label"`
+	CatalogNumber string         `json:"catalog_number"`
+	DiscCount     int32          `json:"disc_count"`
+	Tracklist     []Track        `json:"tracklist"`
+	Position      int32          `json:"position"`
+	ThicknessCM   float64        `json:"thickness_cm"`
+	MissingSince  *time.Time     `json:"missing_since"`
+	CustomFields  map[string]any `json:"custom_fields"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+type Track struct {
+	Disc            int32  `json:"disc"`
+	Number          int32  `json:"number"`
+	Title           string `json:"title"`
+	DurationSeconds int32  `json:"duration_seconds"`
+}
+
+// MusicParams are the fields of music that can be set.
+type MusicParams struct {
+	Title         string         `json:"title"`
+	Artist        string         `json:"artist"`
+	Genre         string         `json:"genre"`
+	Barcode       string         `json:"barcode"`
+	Format        string         `json:"format"`
+	ShelfID       uuid.UUID      `json:"shelf_id"`
+	ReleaseDate   string         `json:"release_date,omitempty"`
+	Label         string         `json:"label"`
+	CatalogNumber string         `json:"catalog_number"`
+	DiscCount     int32          `json:"disc_count"`
+	Tracklist     []Track        `json:"tracklist,omitempty"`
+	ThicknessCM   float64        `json:"thickness_cm"`
+	CustomFields  map[string]any `json:"custom_fields,omitempty"`
+}
+
+// Params returns the music's fields, to be changed and sent to Update.
+func (m Music) Params() MusicParams {
+	return MusicParams{
+		Title:         m.Title,
+		Artist:        m.Artist,
+		Genre:         m.Genre,
+		Barcode:       m.Barcode,
+		Format:        m.Format,
+		ShelfID:       m.ShelfID,
+		ReleaseDate:   m.ReleaseDate,
+		Label:         m.Label,
+		CatalogNumber: m.CatalogNumber,
+		DiscCount:     m.DiscCount,
+		Tracklist:     m.Tracklist,
+		ThicknessCM:   m.ThicknessCM,
+		CustomFields:  m.CustomFields,
+	}
+}
+
+type Game struct {
+	ID              uuid.UUID      `json:"id"`
+	Title           string         `json:"title"`
+	GameType        string         `json:"game_type"`
+	Platform        string         `json:"platform"`
+	Publisher       string         `json:"publisher"`
+	Developer       string         `json:"developer"`
+	Genre           string         `json:"genre"`
+	MinPlayers      int32          `json:"min_players"`
+	MaxPlayers      int32          `json:"max_players"`
+	PlayTimeMinutes int32          `json:"play_time_minutes"`
+	Edition         string         `json:"edition"`
+	Barcode         string         `json:"barcode"`
+	ShelfID         uuid.UUID      `json:"shelf_id"`
+	ReleaseDate     string         `json:"release_date"`
+	Position        int32          `json:"position"`
+	ThicknessCM     float64        `json:"thickness_cm"`
+	MissingSince    *time.Time     `json:"missing_since"`
+	CustomFields    map[string]any `json:"custom_fields"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// GameParams are the fields of a game that can be set.
+type GameParams struct {
+	Title           string         `json:"title"`
+	GameType        string         `json:"game_type"`
+	Platform        string         `json:"platform"`
+	Publisher       string         `json:"publisher"`
+	Developer       string         `json:"developer"`
+	Genre           string         `json:"genre"`
+	MinPlayers      int32          `json:"min_players"`
+	MaxPlayers      int32          `json:"max_players"`
+	PlayTimeMinutes int32          `json:"play_time_minutes"`
+	Edition         string         `json:"edition"`
+	Barcode         string         `json:"barcode"`
+	ShelfID         uuid.UUID      `json:"shelf_id"`
+	ReleaseDate     string         `json:"release_date,omitempty"`
+	ThicknessCM     float64        `json:"thickness_cm"`
+	CustomFields    map[string]any `json:"custom_fields,omitempty"`
+}
+
+// Params returns the game's fields, to be changed and sent to Update.
+func (g Game) Params() GameParams {
+	return GameParams{
+		Title:           g.Title,
+		GameType:        g.GameType,
+		Platform:        g.Platform,
+		Publisher:       g.Publisher,
+		Developer:       g.Developer,
+		Genre:           g.Genre,
+		MinPlayers:      g.MinPlayers,
+		MaxPlayers:      g.MaxPlayers,
+		PlayTimeMinutes: g.PlayTimeMinutes,
+		Edition:         g.Edition,
+		Barcode:         g.Barcode,
+		ShelfID:         g.ShelfID,
+		ReleaseDate:     g.ReleaseDate,
+		ThicknessCM:     g.ThicknessCM,
+		CustomFields:    g.CustomFields,
+	}
+}
+
+// BatchItemResult is how one item of a batch went: "created", "moved", "failed",
+// "rolled_back" or "skipped".
+type BatchItemResult struct {
+	Index    int             `json:"index"`
+	ItemType string          `json:"item_type"`
+	ItemID   *uuid.UUID      `json:"item_id,omitempty"`
+	Status   string          `json:"status"`
+	Error    string          `json:"error,omitempty"`
+	Item     json.RawMessage `json:"item,omitempty"`
+}
+
+// BatchResponse reports how each item of a batch went, in the order they were sent.
+type BatchResponse struct {
+	Error   string            `json:"error,omitempty"`
+	Results []BatchItemResult `json:"results"`
+}
+
+type Bundle struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Barcode   string    `json:"barcode"`
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BundleContents is a bundle along with the items in it.
+type BundleContents struct {
+	Bundle
+	Movies []Movie `json:"movies"`
+	Shows  []Show  `json:"shows"`
+	Books  []Book  `json:"books"`
+	Music  []Music `json:"music"`
+	Games  []Game  `json:"games"`
+}
+
+type Series struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	LocationID uuid.UUID `json:"location_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Episode struct {
+	ID            uuid.UUID     `json:"id"`
+	SeriesID      uuid.UUID     `json:"series_id"`
+	SeasonNumber  int32         `json:"season_number"`
+	EpisodeNumber int32         `json:"episode_number"`
+	Title         string        `json:"title"`
+	ShowID        uuid.NullUUID `json:"show_id"`
+	DiscNumber    int32         `json:"disc_number"`
+	Watched       bool          `json:"watched"`
+	WatchedAt     *time.Time    `json:"watched_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// EpisodeParams are the fields of an episode that can be set.
+type EpisodeParams struct {
+	SeasonNumber  int32         `json:"season_number"`
+	EpisodeNumber int32         `json:"episode_number"`
+	Title         string        `json:"title"`
+	ShowID        uuid.NullUUID `json:"show_id"`
+	DiscNumber    int32         `json:"disc_number"`
+}
+
+// NextEpisode is the next unwatched episode of a series, and the shelf of the show it's on.
+type NextEpisode struct {
+	Episode
+	SeriesTitle string        `json:"series_title"`
+	ShelfID     uuid.NullUUID `json:"shelf_id"`
+	ShelfName   string        `json:"shelf_name"`
+}
+
+type CustomField struct {
+	ID         uuid.UUID `json:"id"`
+	LocationID uuid.UUID `json:"location_id"`
+	ItemType   string    `json:"item_type"`
+	Name       string    `json:"name"`
+	FieldType  string    `json:"field_type"`
+	Options    []string  `json:"options"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CustomFieldParams define a custom field. Options are the choices of an "enum" field.
+type CustomFieldParams struct {
+	ItemType  string   `json:"item_type"`
+	Name      string   `json:"name"`
+	FieldType string   `json:"field_type"`
+	Options   []string `json:"options,omitempty"`
+}
+
+type LocationStats struct {
+	LocationID    uuid.UUID        `json:"location_id"`
+	TotalItems    int64            `json:"total_items"`
+	ItemsByType   map[string]int64 `json:"items_by_type"`
+	ItemsByFormat []FormatCount    `json:"items_by_format"`
+	ItemsByGenre  []GenreCount     `json:"items_by_genre"`
+	ItemsByDecade []DecadeCount    `json:"items_by_decade"`
+	ItemsByCase   []CaseCount      `json:"items_by_case"`
+	ItemsByShelf  []ShelfCount     `json:"items_by_shelf"`
+	AddedByMonth  []MonthCount     `json:"added_by_month"`
+	TopCreators   []CreatorCount   `json:"top_creators"`
+}
+
+type FormatCount struct {
+	ItemType string `json:"item_type"`
+	Format   string `json:"format"`
+	Count    int64  `json:"count"`
+}
+
+type GenreCount struct {
+	ItemType string `json:"item_type"`
+	Genre    string `json:"genre"`
+	Count    int64  `json:"count"`
+}
+
+type DecadeCount struct {
+	Decade int32 `json:"decade"`
+	Count  int64 `json:"count"`
+}
+
+type CaseCount struct {
+	CaseID   uuid.UUID `json:"case_id"`
+	CaseName string    `json:"case_name"`
+	Count    int64     `json:"count"`
+}
+
+type ShelfCount struct {
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	ShelfName string    `json:"shelf_name"`
+	CaseID    uuid.UUID `json:"case_id"`
+	Count     int64     `json:"count"`
+}
+
+type MonthCount struct {
+	Month string `json:"month"` // YYYY-MM
+	Count int64  `json:"count"`
+}
+
+type CreatorCount struct {
+	ItemType string `json:"item_type"`
+	Creator  string `json:"creator"`
+	Count    int64  `json:"count"`
+}
+
+type Audit struct {
+	ID          uuid.UUID     `json:"id"`
+	LocationID  uuid.UUID     `json:"location_id"`
+	CaseID      uuid.NullUUID `json:"case_id"`
+	ShelfID     uuid.NullUUID `json:"shelf_id"`
+	StartedBy   uuid.UUID     `json:"started_by"`
+	CompletedAt *time.Time    `json:"completed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type AuditItem struct {
+	ItemType     string     `json:"item_type"`
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Barcode      string     `json:"barcode"`
+	ShelfID      uuid.UUID  `json:"shelf_id"`
+	MissingSince *time.Time `json:"missing_since"`
+}
+
+type MisplacedItem struct {
+	AuditItem
+	ShelfName      string    `json:"shelf_name"`
+	FoundShelfID   uuid.UUID `json:"found_shelf_id"`
+	FoundShelfName string    `json:"found_shelf_name"`
+}
+
+type UnknownBarcode struct {
+	Barcode   string    `json:"barcode"`
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	ShelfName string    `json:"shelf_name"`
+	Scans     int64     `json:"scans"`
+}
+
+type AuditReport struct {
+	Audit     Audit            `json:"audit"`
+	Scans     int64            `json:"scans"`
+	Found     int              `json:"found"`
+	Missing   []AuditItem      `json:"missing"`
+	Misplaced []MisplacedItem  `json:"misplaced"`
+	Unknown   []UnknownBarcode `json:"unknown"`
+}
+
+type AuditScanResult struct {
+	Barcode string      `json:"barcode"`
+	Status  string      `json:"status"`
+	Items   []AuditItem `json:"items"`
+}
+
+type ScanSession struct {
+	ID         uuid.UUID     `json:"id"`
+	LocationID uuid.UUID     `json:"location_id"`
+	StartedBy  uuid.UUID     `json:"started_by"`
+	ShelfID    uuid.NullUUID `json:"shelf_id"`
+	ClosedAt   *time.Time    `json:"closed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type ScanDraft struct {
+	ID          uuid.UUID `json:"id"`
+	SessionID   uuid.UUID `json:"session_id"`
+	ShelfID     uuid.UUID `json:"shelf_id"`
+	Barcode     string    `json:"barcode"`
+	ItemType    string    `json:"item_type"`
+	Title       string    `json:"title"`
+	Creator     string    `json:"creator"`
+	Genre       string    `json:"genre"`
+	ReleaseDate string    `json:"release_date"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ScannedItem struct {
+	ItemType    string    `json:"item_type"`
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Barcode     string    `json:"barcode"`
+	FromShelfID uuid.UUID `json:"from_shelf_id"`
+	ShelfID     uuid.UUID `json:"shelf_id"`
+}
+
+// ScanResult is what a scan did. Action says which of the other fields are set.
+type ScanResult struct {
+	Action  string          `json:"action"`
+	Session ScanSession     `json:"session"`
+	Shelf   *Shelf          `json:"shelf,omitempty"`
+	Case    *Case           `json:"case,omitempty"`
+	Shelves []Shelf         `json:"shelves,omitempty"`
+	Item    *ScannedItem    `json:"item,omitempty"`
+	Bundle  *BundleContents `json:"bundle,omitempty"`
+	Draft   *ScanDraft      `json:"draft,omitempty"`
+}
+
+type Suggestion struct {
+	ItemType string    `json:"item_type"`
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Creator  string    `json:"creator"`
+}
+
+type SearchResult struct {
+	ItemType    string            `json:"item_type"`
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Creator     string            `json:"creator"`
+	Genre       string            `json:"genre"`
+	Format      string            `json:"format"`
+	ReleaseDate string            `json:"release_date"`
+	ShelfID     uuid.UUID         `json:"shelf_id"`
+	CaseID      uuid.UUID         `json:"case_id"`
+	Rank        float32           `json:"rank"`
+	Highlights  map[string]string `json:"highlights"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+type SearchResponse struct {
+	Query   string                  `json:"query"`
+	Total   int                     `json:"total"`
+	Results []SearchResult          `json:"results"`
+	Facets  map[string][]FacetCount `json:"facets"`
+}
+
+type LocationSearchResults struct {
+	LocationID   uuid.UUID      `json:"location_id"`
+	LocationName string         `json:"location_name"`
+	Results      []SearchResult `json:"results"`
+}
+
+type UserSearchResponse struct {
+	Query     string                  `json:"query"`
+	Total     int                     `json:"total"`
+	Locations []LocationSearchResults `json:"locations"`
+}
+
+type ShelfPath struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	CaseID       uuid.UUID `json:"case_id"`
+	CaseName     string    `json:"case_name"`
+	ShelfID      uuid.UUID `json:"shelf_id"`
+	ShelfName    string    `json:"shelf_name"`
+	Path         string    `json:"path"`
+}
+
+type BarcodeMatch struct {
+	ItemType     string     `json:"item_type"`
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Creator      string     `json:"creator"`
+	Format       string     `json:"format"`
+	Barcode      string     `json:"barcode"`
+	MissingSince *time.Time `json:"missing_since"`
+	Shelf        ShelfPath  `json:"shelf"`
+}
+
+type SmartShelf struct {
+	ID         uuid.UUID           `json:"id"`
+	LocationID uuid.UUID           `json:"location_id"`
+	Name       string              `json:"name"`
+	Query      string              `json:"query"`
+	Filters    map[string][]string `json:"filters"`
+	Pinned     bool                `json:"pinned"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// SmartShelfParams are the fields of a smart shelf that can be set. Filters are search facets,
+// the same as SearchOptions.Filters.
+type SmartShelfParams struct {
+	Name    string              `json:"name"`
+	Query   string              `json:"query"`
+	Filters map[string][]string `json:"filters"`
+	Pinned  bool                `json:"pinned"`
+}
+
+// Params returns the smart shelf's fields, to be changed and sent to Update.
+func (s SmartShelf) Params() SmartShelfParams {
+	return SmartShelfParams{
+		Name:    s.Name,
+		Query:   s.Query,
+		Filters: s.Filters,
+		Pinned:  s.Pinned,
+	}
+}
+
+// LocationShelves are all of a location's shelves, alongside its smart shelves.
+type LocationShelves struct {
+	Shelves      []Shelf      `json:"shelves"`
+	SmartShelves []SmartShelf `json:"smart_shelves"`
+}
+
+type Webhook struct {
+	ID         uuid.UUID `json:"id"`
+	LocationID uuid.UUID `json:"location_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookParams are the fields of a webhook that can be set. An empty Secret is generated by
+// the server when creating a webhook, and left as it is when updating one.
+type WebhookParams struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	ResponseStatus *int32          `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// Change is the latest change to a case, shelf or item of a location. Entity is the entity as
+// it is now, unless it was deleted.
+type Change struct {
+	Cursor     int64           `json:"cursor"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	ChangedAt  time.Time       `json:"changed_at"`
+	Entity     json.RawMessage `json:"entity,omitempty"`
+}
+
+type ChangesResponse struct {
+	Cursor  int64    `json:"cursor"`
+	HasMore bool     `json:"has_more"`
+	Changes []Change `json:"changes"`
+}
+
+// OfflineChange is a change made while offline, to be applied with SyncService.Apply.
+type OfflineChange struct {
+	Ref           string          `json:"ref"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      uuid.UUID       `json:"entity_id"`
+	Operation     string          `json:"operation"`
+	BaseUpdatedAt *time.Time      `json:"base_updated_at"`
+	Entity        json.RawMessage `json:"entity"`
+}
+
+type OfflineChangeResult struct {
+	Ref        string          `json:"ref,omitempty"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Entity     json.RawMessage `json:"entity"`
+}
+
+type OfflineChangesResponse struct {
+	Applied   int                   `json:"applied"`
+	Conflicts int                   `json:"conflicts"`
+	Rejected  int                   `json:"rejected"`
+	Failed    int                   `json:"failed"`
+	Results   []OfflineChangeResult `json:"results"`
+}
+
+// Event is an event of a location, as sent to webhooks and event streams. Data depends on the
+// type, e.g. an ItemEvent for "item.created".
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	LocationID uuid.UUID       `json:"location_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// ItemEvent is the data of an item event. FromShelfID is set when an item is moved.
+type ItemEvent struct {
+	ItemType    string          `json:"item_type"`
+	Item        json.RawMessage `json:"item"`
+	FromShelfID *uuid.UUID      `json:"from_shelf_id,omitempty"`
+}
+
+// MemberEvent is the data of a member event.
+type MemberEvent struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"user_id"`
+}
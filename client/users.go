@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// UsersService calls the user routes.
+type UsersService struct {
+	c *Client
+}
+
+// Create registers a new user. It doesn't log in as them.
+func (s *UsersService) Create(ctx context.Context, name, email, password string) (User, error) {
+	var user User
+	err := s.c.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/api/users",
+		body:   map[string]string{"name": name, "email": email, "password": password},
+		noAuth: true,
+	}, &user)
+	return user, err
+}
+
+// Update changes the logged in user's email and password.
+func (s *UsersService) Update(ctx context.Context, email, password string) (User, error) {
+	var user User
+	err := s.c.do(ctx, &request{
+		method: http.MethodPut,
+		path:   "/api/users",
+		body:   map[string]string{"email": email, "password": password},
+	}, &user)
+	return user, err
+}
+
+func (s *UsersService) List(ctx context.Context) ([]User, error) {
+	var users []User
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: "/api/users"}, &users)
+	return users, err
+}
+
+func (s *UsersService) Get(ctx context.Context, userID uuid.UUID, opts ...RequestOption) (User, error) {
+	var user User
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/users/%s", userID), opts: opts}, &user)
+	return user, err
+}
+
+func (s *UsersService) GetByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	err := s.c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/api/search/users",
+		query:  url.Values{"email": {email}},
+	}, &user)
+	return user, err
+}
+
+// Locations lists the locations the user is a member of.
+func (s *UsersService) Locations(ctx context.Context, userID uuid.UUID) ([]UserLocation, error) {
+	var locations []UserLocation
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/users/%s/locations", userID)}, &locations)
+	return locations, err
+}
+
+// Invites lists the locations the user has been invited to.
+func (s *UsersService) Invites(ctx context.Context, userID uuid.UUID) ([]UserInvite, error) {
+	var invites []UserInvite
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/users/%s/invites", userID)}, &invites)
+	return invites, err
+}
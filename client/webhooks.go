@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// WebhooksService calls the webhook routes.
+type WebhooksService struct {
+	c *Client
+}
+
+// Create adds a webhook to a location. Its secret is only included in the webhook returned
+// here, and by an Update that changes it.
+func (s *WebhooksService) Create(ctx context.Context, locationID uuid.UUID, params WebhookParams, opts ...RequestOption) (Webhook, error) {
+	var webhook Webhook
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/locations/%s/webhooks", locationID), body: params, opts: opts}, &webhook)
+	return webhook, err
+}
+
+func (s *WebhooksService) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]Webhook, error) {
+	var webhooks []Webhook
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/locations/%s/webhooks", locationID)}, &webhooks)
+	return webhooks, err
+}
+
+// Update sets every field of a webhook to params, except that an empty Secret keeps the
+// webhook's secret.
+func (s *WebhooksService) Update(ctx context.Context, webhookID uuid.UUID, params WebhookParams) (Webhook, error) {
+	var webhook Webhook
+	err := s.c.do(ctx, &request{method: http.MethodPut, path: pathf("/api/webhooks/%s", webhookID), body: params}, &webhook)
+	return webhook, err
+}
+
+func (s *WebhooksService) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	return s.c.do(ctx, &request{method: http.MethodDelete, path: pathf("/api/webhooks/%s", webhookID)}, nil)
+}
+
+// Deliveries lists a webhook's latest deliveries, newest first. A limit of 0 uses the server's
+// default.
+func (s *WebhooksService) Deliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]WebhookDelivery, error) {
+	query := url.Values{}
+	if limit != 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var deliveries []WebhookDelivery
+	err := s.c.do(ctx, &request{method: http.MethodGet, path: pathf("/api/webhooks/%s/deliveries", webhookID), query: query}, &deliveries)
+	return deliveries, err
+}
+
+// Test sends a ping to a webhook, returning how the delivery went.
+func (s *WebhooksService) Test(ctx context.Context, webhookID uuid.UUID) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := s.c.do(ctx, &request{method: http.MethodPost, path: pathf("/api/webhooks/%s/test", webhookID)}, &delivery)
+	return delivery, err
+}
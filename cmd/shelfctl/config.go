@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+// config is the saved session. It holds a refresh token, so it's only readable by its owner.
+type config struct {
+	Server       string    `json:"server"`
+	UserID       uuid.UUID `json:"user_id"`
+	Email        string    `json:"email"`
+	AccessToken  string    `json:"access_token,omitempty"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+// configFile returns where the config is kept.
+func (a *app) configFile() (string, error) {
+	if a.configPath != "" {
+		return a.configPath, nil
+	}
+	if path := os.Getenv("SHELFCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding the config dir: %w", err)
+	}
+	return filepath.Join(dir, "shelfctl", "config.json"), nil
+}
+
+// loadConfig reads the config. A missing config is the same as an empty one.
+func (a *app) loadConfig() error {
+	path, err := a.configFile()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		a.config = config{}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	if err := json.Unmarshal(data, &a.config); err != nil {
+		return fmt.Errorf("reading config %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveConfig writes the config, replacing the old one all at once.
+func (a *app) saveConfig() error {
+	path, err := a.configFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	data, err := json.MarshalIndent(a.config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.json")
+	if err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	return nil
+}
+
+// client returns a client logged in with the saved session. New access tokens are saved as
+// they're refreshed, so that the next command doesn't need to refresh too.
+func (a *app) client() (*client.Client, error) {
+	if a.api != nil {
+		return a.api, nil
+	}
+	if err := a.loadConfig(); err != nil {
+		return nil, err
+	}
+	if a.config.RefreshToken == "" {
+		return nil, errors.New("not logged in, run shelfctl login first")
+	}
+
+	a.api = client.New(a.config.Server,
+		client.WithTokens(a.config.AccessToken, a.config.RefreshToken),
+		client.WithTokenRefreshed(func(accessToken string) {
+			a.config.AccessToken = accessToken
+			if err := a.saveConfig(); err != nil {
+				fmt.Fprintf(a.stderr, "shelfctl: %s\n", err)
+			}
+		}),
+	)
+	return a.api, nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+// CSV files have a row for each item and a column for each field, named as it is in the API's
+// JSON, along with item_type and id columns. Custom fields are in columns named cf.NAME.
+// Empty cells are left unset on import.
+
+// maxBatch is how many items are added in each batch.
+const maxBatch = 500
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "location ID")
+	typeName := fs.String("type", "", "only export items of this type")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if locationID.UUID == uuid.Nil {
+		return usagef("-location is required")
+	}
+	if fs.NArg() > 1 {
+		return usagef("unexpected argument %q", fs.Arg(1))
+	}
+
+	types := itemTypes
+	if *typeName != "" {
+		t, err := findItemType(*typeName)
+		if err != nil {
+			return usagef("%s", err)
+		}
+		types = []itemType{t}
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var records []record
+	for _, t := range types {
+		typeRecords, err := t.list(ctx, api, locationID.UUID)
+		if err != nil {
+			return fmt.Errorf("listing %s items: %w", t.name, err)
+		}
+		records = append(records, typeRecords...)
+	}
+
+	if fs.NArg() == 0 {
+		return writeCSV(a.stdout, types, records)
+	}
+	f, err := os.Create(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := writeCSV(f, types, records); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeCSV writes records as CSV, with a column for every field of types.
+func writeCSV(out io.Writer, types []itemType, records []record) error {
+	header := []string{"item_type", "id"}
+	for _, t := range types {
+		for _, name := range paramNames(t.params()) {
+			if !slices.Contains(header, name) {
+				header = append(header, name)
+			}
+		}
+	}
+	var customNames []string
+	for _, r := range records {
+		for name := range customFields(r.params) {
+			if !slices.Contains(customNames, "cf."+name) {
+				customNames = append(customNames, "cf."+name)
+			}
+		}
+	}
+	slices.Sort(customNames)
+	header = append(header, customNames...)
+
+	w := csv.NewWriter(out)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := make([]string, len(header))
+		row[0] = r.itemType
+		row[1] = r.id.String()
+		for i, name := range header[2:] {
+			if customName, ok := strings.CutPrefix(name, "cf."); ok {
+				row[i+2] = formatCustomField(customFields(r.params)[customName])
+			} else {
+				row[i+2] = formatParam(r.params, name)
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func runImport(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var shelfID idFlag
+	fs.Var(&shelfID, "shelf", "shelf for rows without a shelf_id")
+	typeName := fs.String("type", "", "item type for rows without an item_type")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usagef("unexpected argument %q", fs.Arg(1))
+	}
+	if *typeName != "" {
+		if _, err := findItemType(*typeName); err != nil {
+			return usagef("%s", err)
+		}
+	}
+
+	in := a.stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	items, lines, err := readCSV(ctx, in, *typeName, shelfID.UUID, newCustomFieldTypes(api))
+	if err != nil {
+		return err
+	}
+
+	// Each batch is added all at once or not at all, so a failed import can be fixed and
+	// carried on from the first line of the batch that failed.
+	var results []client.BatchItemResult
+	var rows [][]string
+	for start := 0; start < len(items); start += maxBatch {
+		end := min(start+maxBatch, len(items))
+		response, err := api.Batch.Create(ctx, items[start:end])
+		for _, result := range response.Results {
+			result.Index += start
+			results = append(results, result)
+			rows = append(rows, append([]string{strconv.Itoa(lines[result.Index])}, batchRows([]client.BatchItemResult{result})[0]...))
+		}
+		if err != nil {
+			if printErr := a.print(results, append([]string{"LINE"}, batchHeader...), rows); printErr != nil {
+				return printErr
+			}
+			if start > 0 {
+				return fmt.Errorf("adding the items from line %d on: %w", lines[start], err)
+			}
+			return err
+		}
+	}
+	return a.print(results, append([]string{"LINE"}, batchHeader...), rows)
+}
+
+// readCSV reads the items in a CSV file, along with the line each starts on.
+func readCSV(ctx context.Context, in io.Reader, defaultType string, defaultShelf uuid.UUID, types *customFieldTypes) ([]client.BatchItem, []int, error) {
+	r := csv.NewReader(in)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	// Spreadsheets often start the files they save with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	var items []client.BatchItem
+	var lines []int
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := r.FieldPos(0)
+
+		cells := map[string]string{}
+		for i, name := range header {
+			if value := strings.TrimSpace(row[i]); value != "" {
+				cells[name] = value
+			}
+		}
+
+		item, err := readItem(ctx, cells, defaultType, defaultShelf, types)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+		lines = append(lines, line)
+	}
+	if len(items) == 0 {
+		return nil, nil, errors.New("the CSV file has no items")
+	}
+	return items, lines, nil
+}
+
+// readItem reads the item in a row's non-empty cells.
+func readItem(ctx context.Context, cells map[string]string, defaultType string, defaultShelf uuid.UUID, types *customFieldTypes) (client.BatchItem, error) {
+	typeName := cells["item_type"]
+	if typeName == "" {
+		typeName = defaultType
+	}
+	if typeName == "" {
+		return client.BatchItem{}, errors.New("item_type is empty, and no -type was given")
+	}
+	t, err := findItemType(typeName)
+	if err != nil {
+		return client.BatchItem{}, err
+	}
+
+	params := t.params()
+	shelfID := defaultShelf
+	if s, ok := cells["shelf_id"]; ok {
+		if shelfID, err = uuid.Parse(s); err != nil {
+			return client.BatchItem{}, fmt.Errorf("shelf_id %q isn't a valid ID", s)
+		}
+	}
+	if shelfID == uuid.Nil {
+		return client.BatchItem{}, errors.New("shelf_id is empty, and no -shelf was given")
+	}
+	setParam(params, "shelf_id", shelfID.String())
+
+	for _, name := range slices.Sorted(maps.Keys(cells)) {
+		value := cells[name]
+		switch name {
+		case "item_type", "id", "shelf_id":
+			continue
+		}
+
+		if customName, ok := strings.CutPrefix(name, "cf."); ok {
+			v, err := types.value(ctx, shelfID, t.name, customName, value)
+			if err != nil {
+				return client.BatchItem{}, err
+			}
+			setCustomField(params, customName, v)
+			continue
+		}
+		if err := setParam(params, name, value); err != nil {
+			return client.BatchItem{}, fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+	return client.BatchItem{ItemType: t.name, Item: params}, nil
+}
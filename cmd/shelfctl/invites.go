@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+func runInvites(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usagef("a subcommand is required: list, send, withdraw, accept or decline")
+	}
+
+	switch args[0] {
+	case "list":
+		return runInvitesList(ctx, a, args[1:])
+	case "send":
+		return runInvitesSend(ctx, a, args[1:])
+	case "withdraw":
+		return runInvitesWithdraw(ctx, a, args[1:])
+	case "accept", "decline":
+		return runInvitesAnswer(ctx, a, args[0], args[1:])
+	}
+	return usagef("unknown subcommand %q, use list, send, withdraw, accept or decline", args[0])
+}
+
+// runInvitesList lists the invites sent to the logged in user, or those sent from a
+// location.
+func runInvitesList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "list the invites sent from this location, instead of those sent to you")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if locationID.UUID != uuid.Nil {
+		invites, err := api.Locations.Invites(ctx, locationID.UUID)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, 0, len(invites))
+		for _, invite := range invites {
+			rows = append(rows, []string{invite.UserID.String(), invite.UserName, invite.UserEmail, formatTime(invite.InvitedAt)})
+		}
+		return a.print(invites, []string{"USER", "NAME", "EMAIL", "INVITED"}, rows)
+	}
+
+	invites, err := api.Users.Invites(ctx, a.config.UserID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(invites))
+	for _, invite := range invites {
+		rows = append(rows, []string{invite.LocationID.String(), invite.LocationName, formatTime(invite.InvitedAt)})
+	}
+	return a.print(invites, []string{"LOCATION", "NAME", "INVITED"}, rows)
+}
+
+// runInvitesSend invites users to a location by their email.
+func runInvitesSend(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "location to invite the users to")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if locationID.UUID == uuid.Nil {
+		return usagef("-location is required")
+	}
+	if fs.NArg() == 0 {
+		return usagef("the email of at least one user to invite is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	var invites []client.NewLocationInvite
+	var rows [][]string
+	for _, email := range fs.Args() {
+		user, err := findUser(ctx, api, email)
+		if err != nil {
+			return err
+		}
+		invite, err := api.Locations.Invite(ctx, locationID.UUID, user.ID)
+		if err != nil {
+			return fmt.Errorf("inviting %s: %w", email, err)
+		}
+		invites = append(invites, invite)
+		rows = append(rows, []string{user.ID.String(), user.Name, user.Email, formatTime(invite.InvitedAt)})
+	}
+	return a.print(invites, []string{"USER", "NAME", "EMAIL", "INVITED"}, rows)
+}
+
+// runInvitesWithdraw withdraws the invites of users to a location.
+func runInvitesWithdraw(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "location the users were invited to")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if locationID.UUID == uuid.Nil {
+		return usagef("-location is required")
+	}
+	if fs.NArg() == 0 {
+		return usagef("the email of at least one invited user is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+	for _, email := range fs.Args() {
+		user, err := findUser(ctx, api, email)
+		if err != nil {
+			return err
+		}
+		if err := api.Locations.RemoveInvite(ctx, locationID.UUID, user.ID); err != nil {
+			return fmt.Errorf("withdrawing the invite of %s: %w", email, err)
+		}
+	}
+	return nil
+}
+
+// runInvitesAnswer accepts or declines the logged in user's invite to a location.
+func runInvitesAnswer(ctx context.Context, a *app, answer string, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("invites %s needs the ID of the location you were invited to", answer)
+	}
+	locationID, err := uuid.Parse(fs.Arg(0))
+	if err != nil {
+		return usagef("%q is not a valid location ID", fs.Arg(0))
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if answer == "decline" {
+		return api.Locations.RemoveInvite(ctx, locationID, a.config.UserID)
+	}
+	member, err := api.Locations.AddMember(ctx, locationID, a.config.UserID)
+	if err != nil {
+		return err
+	}
+	return a.print(member, []string{"LOCATION", "JOINED"}, [][]string{{member.LocationID.String(), formatTime(member.JoinedAt)}})
+}
+
+// findUser finds a user by their email.
+func findUser(ctx context.Context, api *client.Client, email string) (client.User, error) {
+	user, err := api.Users.GetByEmail(ctx, email)
+	if client.IsNotFound(err) {
+		return client.User{}, fmt.Errorf("no user has the email %s", email)
+	}
+	if err != nil {
+		return client.User{}, fmt.Errorf("finding %s: %w", email, err)
+	}
+	return user, nil
+}
@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+// itemType is a media type, and how to work with its items without knowing their Go type.
+type itemType struct {
+	name string
+	// params returns a pointer to empty params of the type, such as a *client.MovieParams.
+	params func() any
+	// list lists the items of the type at a location.
+	list func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error)
+}
+
+var itemTypes = []itemType{
+	{
+		name:   "movie",
+		params: func() any { return &client.MovieParams{} },
+		list: func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error) {
+			return listRecords(ctx, api.Movies, "movie", locationID)
+		},
+	},
+	{
+		name:   "show",
+		params: func() any { return &client.ShowParams{} },
+		list: func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error) {
+			return listRecords(ctx, api.Shows, "show", locationID)
+		},
+	},
+	{
+		name:   "book",
+		params: func() any { return &client.BookParams{} },
+		list: func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error) {
+			return listRecords(ctx, api.Books, "book", locationID)
+		},
+	},
+	{
+		name:   "music",
+		params: func() any { return &client.MusicParams{} },
+		list: func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error) {
+			return listRecords(ctx, api.Music, "music", locationID)
+		},
+	},
+	{
+		name:   "game",
+		params: func() any { return &client.GameParams{} },
+		list: func(ctx context.Context, api *client.Client, locationID uuid.UUID) ([]record, error) {
+			return listRecords(ctx, api.Games, "game", locationID)
+		},
+	},
+}
+
+func findItemType(name string) (itemType, error) {
+	for _, t := range itemTypes {
+		if t.name == name {
+			return t, nil
+		}
+	}
+
+	names := make([]string, len(itemTypes))
+	for i, t := range itemTypes {
+		names[i] = t.name
+	}
+	return itemType{}, fmt.Errorf("unknown item type %q, use one of %s", name, strings.Join(names, ", "))
+}
+
+// record is an item of any media type, with its fields as params.
+type record struct {
+	itemType string
+	id       uuid.UUID
+	params   any
+}
+
+func listRecords[T interface{ Params() P }, P any](ctx context.Context, items *client.Items[T, P], name string, locationID uuid.UUID) ([]record, error) {
+	list, err := items.ListByLocation(ctx, locationID, client.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(list))
+	for _, item := range list {
+		params := item.Params()
+		id := reflect.ValueOf(item).FieldByName("ID").Interface().(uuid.UUID)
+		records = append(records, record{itemType: name, id: id, params: &params})
+	}
+	return records, nil
+}
+
+// customFieldsName is the JSON name of the custom fields of params. Each custom field is set
+// on its own instead, as "cf.NAME".
+const customFieldsName = "custom_fields"
+
+// paramNames lists the JSON names of the fields of params, in order.
+func paramNames(params any) []string {
+	t := reflect.TypeOf(params).Elem()
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if name := jsonName(t.Field(i)); name != customFieldsName {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// paramField returns the field of params with a JSON name, if it has one.
+func paramField(params any, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(params).Elem()
+	for i := range v.NumField() {
+		if jsonName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// setParam sets the field of params with a JSON name from its text form, as formatParam
+// writes it.
+func setParam(params any, name, value string) error {
+	field, ok := paramField(params, name)
+	if !ok || name == customFieldsName {
+		return fmt.Errorf("no field named %q", name)
+	}
+
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number", name)
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s must be a number", name)
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", name)
+		}
+		field.SetBool(b)
+	default:
+		// Lists, such as a tracklist, are written as JSON.
+		if err := json.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+			return fmt.Errorf("%s must be JSON: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// formatParam returns the text form of the field of params with a JSON name.
+func formatParam(params any, name string) string {
+	field, ok := paramField(params, name)
+	if !ok {
+		return ""
+	}
+
+	switch v := field.Interface().(type) {
+	case uuid.UUID:
+		return formatID(v)
+	case string:
+		return v
+	case float64:
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int32:
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatInt(int64(v), 10)
+	}
+
+	if field.Kind() == reflect.Slice && field.Len() == 0 {
+		return ""
+	}
+	data, err := json.Marshal(field.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// customFields returns the custom fields of params.
+func customFields(params any) map[string]any {
+	field, ok := paramField(params, customFieldsName)
+	if !ok {
+		return nil
+	}
+	return field.Interface().(map[string]any)
+}
+
+// setCustomField sets a custom field of params.
+func setCustomField(params any, name string, value any) {
+	field, _ := paramField(params, customFieldsName)
+	if field.IsNil() {
+		field.Set(reflect.ValueOf(map[string]any{}))
+	}
+	field.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(value))
+}
+
+// formatCustomField returns the text form of a custom field's value.
+func formatCustomField(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// customFieldTypes finds the custom fields of the location each shelf is at, so that values
+// given as text can be sent as the type each field needs.
+type customFieldTypes struct {
+	api            *client.Client
+	shelfLocations map[uuid.UUID]uuid.UUID
+	fields         map[uuid.UUID][]client.CustomField
+}
+
+func newCustomFieldTypes(api *client.Client) *customFieldTypes {
+	return &customFieldTypes{
+		api:            api,
+		shelfLocations: map[uuid.UUID]uuid.UUID{},
+		fields:         map[uuid.UUID][]client.CustomField{},
+	}
+}
+
+// value converts the text value of a custom field of an item on a shelf.
+func (c *customFieldTypes) value(ctx context.Context, shelfID uuid.UUID, itemType, name, s string) (any, error) {
+	locationID, ok := c.shelfLocations[shelfID]
+	if !ok {
+		shelf, err := c.api.Shelves.Get(ctx, shelfID)
+		if err != nil {
+			return nil, fmt.Errorf("getting shelf %s: %w", shelfID, err)
+		}
+		shelfCase, err := c.api.Cases.Get(ctx, shelf.CaseID)
+		if err != nil {
+			return nil, fmt.Errorf("getting case %s: %w", shelf.CaseID, err)
+		}
+		locationID = shelfCase.LocationID
+		c.shelfLocations[shelfID] = locationID
+	}
+
+	fields, ok := c.fields[locationID]
+	if !ok {
+		var err error
+		fields, err = c.api.CustomFields.List(ctx, locationID, "")
+		if err != nil {
+			return nil, fmt.Errorf("listing custom fields: %w", err)
+		}
+		c.fields[locationID] = fields
+	}
+
+	for _, field := range fields {
+		if field.ItemType != itemType || field.Name != name {
+			continue
+		}
+		switch field.FieldType {
+		case "number":
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("custom field %s must be a number", name)
+			}
+			return n, nil
+		case "bool":
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("custom field %s must be true or false", name)
+			}
+			return b, nil
+		default:
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("no custom field named %q for %s items at the shelf's location", name, itemType)
+}
+
+// itemRef formats an item as TYPE/ID.
+func itemRef(ref client.ItemRef) string {
+	return ref.ItemType + "/" + ref.ItemID.String()
+}
+
+// parseItemRef parses an item given as TYPE/ID.
+func parseItemRef(s string) (client.ItemRef, bool) {
+	name, id, ok := strings.Cut(s, "/")
+	if !ok {
+		return client.ItemRef{}, false
+	}
+	if _, err := findItemType(name); err != nil {
+		return client.ItemRef{}, false
+	}
+	itemID, err := uuid.Parse(id)
+	if err != nil {
+		return client.ItemRef{}, false
+	}
+	return client.ItemRef{ItemType: name, ItemID: itemID}, true
+}
+
+// batchRows returns the table rows of a batch's results.
+func batchRows(results []client.BatchItemResult) [][]string {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		ref := ""
+		if result.ItemID != nil {
+			ref = itemRef(client.ItemRef{ItemType: result.ItemType, ItemID: *result.ItemID})
+		}
+		var item struct {
+			Title string `json:"title"`
+		}
+		json.Unmarshal(result.Item, &item)
+		rows = append(rows, []string{ref, item.Title, result.Status, result.Error})
+	}
+	return rows
+}
+
+var batchHeader = []string{"ITEM", "TITLE", "STATUS", "ERROR"}
+
+func runAdd(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var shelfID idFlag
+	var sets listFlag
+	fs.Var(&shelfID, "shelf", "ID of the shelf to add the item to")
+	title := fs.String("title", "", "title")
+	barcode := fs.String("barcode", "", "barcode")
+	fs.Var(&sets, "set", "set another field, as FIELD=VALUE, or a custom field as cf.NAME=VALUE; can be repeated")
+
+	// The type comes first, so that the flags after it are parsed.
+	typeName := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		typeName, args = args[0], args[1:]
+	}
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if typeName == "" {
+		return usagef("an item type is required")
+	}
+	t, err := findItemType(typeName)
+	if err != nil {
+		return usagef("%s", err)
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if shelfID.UUID == uuid.Nil {
+		return usagef("-shelf is required")
+	}
+	if *title == "" {
+		return usagef("-title is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	params := t.params()
+	setParam(params, "shelf_id", shelfID.String())
+	setParam(params, "title", *title)
+	setParam(params, "barcode", *barcode)
+	types := newCustomFieldTypes(api)
+	for _, set := range sets {
+		name, value, ok := strings.Cut(set, "=")
+		if !ok {
+			return usagef("-set %q must be FIELD=VALUE", set)
+		}
+		if customName, ok := strings.CutPrefix(name, "cf."); ok {
+			v, err := types.value(ctx, shelfID.UUID, t.name, customName, value)
+			if err != nil {
+				return err
+			}
+			setCustomField(params, customName, v)
+			continue
+		}
+		if err := setParam(params, name, value); err != nil {
+			return usagef("-set: %s", err)
+		}
+	}
+
+	response, err := api.Batch.Create(ctx, []client.BatchItem{{ItemType: t.name, Item: params}})
+	if err != nil {
+		return err
+	}
+	if a.output == "json" {
+		return a.print(response.Results[0].Item, nil, nil)
+	}
+	return a.print(nil, batchHeader, batchRows(response.Results))
+}
+
+func runMove(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var shelfID idFlag
+	fs.Var(&shelfID, "shelf", "ID of the shelf to move the items to")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if shelfID.UUID == uuid.Nil {
+		return usagef("-shelf is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	// Items given as arguments are all moved or none are. Items read from standard input are
+	// moved as each is read.
+	if fs.NArg() > 0 {
+		refs := make([]client.ItemRef, 0, fs.NArg())
+		for _, arg := range fs.Args() {
+			ref, err := resolveItem(ctx, api, arg)
+			if err != nil {
+				return err
+			}
+			refs = append(refs, ref)
+		}
+
+		response, err := api.Batch.Move(ctx, shelfID.UUID, refs)
+		if err != nil {
+			if len(response.Results) > 0 {
+				a.print(response, batchHeader, batchRows(response.Results))
+			}
+			return err
+		}
+		return a.print(response, batchHeader, batchRows(response.Results))
+	}
+
+	out := a.stream(batchHeader)
+	return a.eachLine(func(line string) error {
+		ref, err := resolveItem(ctx, api, line)
+		if err != nil {
+			return err
+		}
+		response, err := api.Batch.Move(ctx, shelfID.UUID, []client.ItemRef{ref})
+		if err != nil {
+			return fmt.Errorf("moving %s: %w", line, err)
+		}
+		return out.row(response.Results[0], batchRows(response.Results)[0])
+	})
+}
+
+// resolveItem finds an item given as TYPE/ID, or by its barcode.
+func resolveItem(ctx context.Context, api *client.Client, s string) (client.ItemRef, error) {
+	if ref, ok := parseItemRef(s); ok {
+		return ref, nil
+	}
+
+	matches, err := api.Search.Barcode(ctx, s, uuid.Nil)
+	if err != nil {
+		return client.ItemRef{}, fmt.Errorf("looking up barcode %s: %w", s, err)
+	}
+	switch len(matches) {
+	case 0:
+		return client.ItemRef{}, fmt.Errorf("no item has barcode %s", s)
+	case 1:
+		return client.ItemRef{ItemType: matches[0].ItemType, ItemID: matches[0].ID}, nil
+	}
+
+	refs := make([]string, len(matches))
+	for i, match := range matches {
+		refs[i] = itemRef(client.ItemRef{ItemType: match.ItemType, ItemID: match.ID})
+	}
+	return client.ItemRef{}, fmt.Errorf("barcode %s matches more than one item, give one of them as TYPE/ID: %s", s, strings.Join(refs, ", "))
+}
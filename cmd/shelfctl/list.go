@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+func runLocations(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+	locations, err := api.Users.Locations(ctx, a.config.UserID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(locations))
+	for _, location := range locations {
+		owner := ""
+		if location.OwnerID == a.config.UserID {
+			owner = "yes"
+		}
+		rows = append(rows, []string{location.LocationID.String(), location.LocationName, owner, formatTime(location.JoinedAt)})
+	}
+	return a.print(locations, []string{"ID", "NAME", "OWNER", "JOINED"}, rows)
+}
+
+func runCases(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "location ID")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if locationID.UUID == uuid.Nil {
+		return usagef("-location is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+	cases, err := api.Cases.ListByLocation(ctx, locationID.UUID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(cases))
+	for _, c := range cases {
+		rows = append(rows, []string{c.ID.String(), c.Name, c.Barcode})
+	}
+	return a.print(cases, []string{"ID", "NAME", "BARCODE"}, rows)
+}
+
+func runShelves(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID, caseID idFlag
+	fs.Var(&locationID, "location", "location ID")
+	fs.Var(&caseID, "case", "case ID")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if (locationID.UUID == uuid.Nil) == (caseID.UUID == uuid.Nil) {
+		return usagef("one of -location or -case is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	// A case's shelves come with how full they are, and a location's with their smart shelves.
+	if caseID.UUID != uuid.Nil {
+		shelves, err := api.Shelves.ListByCase(ctx, caseID.UUID)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, 0, len(shelves))
+		for _, shelf := range shelves {
+			full := ""
+			if shelf.Fullness.Percent != nil {
+				full = strconv.FormatFloat(*shelf.Fullness.Percent, 'f', 0, 64) + "%"
+			}
+			rows = append(rows, []string{shelf.ID.String(), shelf.Name, shelf.Barcode, strconv.FormatInt(shelf.Fullness.ItemCount, 10), full})
+		}
+		return a.print(shelves, []string{"ID", "NAME", "BARCODE", "ITEMS", "FULL"}, rows)
+	}
+
+	shelves, err := api.Shelves.ListByLocation(ctx, locationID.UUID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(shelves.Shelves)+len(shelves.SmartShelves))
+	for _, shelf := range shelves.Shelves {
+		rows = append(rows, []string{shelf.ID.String(), shelf.Name, shelf.CaseID.String(), shelf.Barcode, ""})
+	}
+	for _, smartShelf := range shelves.SmartShelves {
+		rows = append(rows, []string{smartShelf.ID.String(), smartShelf.Name, "", "", "yes"})
+	}
+	return a.print(shelves, []string{"ID", "NAME", "CASE", "BARCODE", "SMART"}, rows)
+}
+
+func runItems(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var shelfID idFlag
+	fs.Var(&shelfID, "shelf", "shelf ID")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if shelfID.UUID == uuid.Nil {
+		return usagef("-shelf is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+	items, err := api.Shelves.Items(ctx, shelfID.UUID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{fmt.Sprint(item.Position), itemRef(client.ItemRef{ItemType: item.ItemType, ItemID: item.ID}), item.Title})
+	}
+	return a.print(items, []string{"POSITION", "ITEM", "TITLE"}, rows)
+}
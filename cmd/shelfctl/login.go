@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+)
+
+const defaultServer = "http://localhost:8080"
+
+func runLogin(ctx context.Context, a *app, args []string) error {
+	if err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	fs := a.flags()
+	server := fs.String("server", a.config.Server, "server URL (default "+defaultServer+")")
+	email := fs.String("email", "", "email to log in with, prompted for if empty")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from standard input, for scripts")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	if *server == "" {
+		*server = defaultServer
+	}
+
+	in := bufio.NewReader(a.stdin)
+	if *email == "" {
+		if *passwordStdin {
+			return usagef("-email is required with -password-stdin")
+		}
+		fmt.Fprint(a.stderr, "Email: ")
+		line, err := readLine(in)
+		if err != nil {
+			return err
+		}
+		*email = line
+	}
+
+	var password string
+	var err error
+	if *passwordStdin {
+		password, err = readLine(in)
+	} else {
+		fmt.Fprint(a.stderr, "Password: ")
+		password, err = a.readPassword(in)
+	}
+	if err != nil {
+		return err
+	}
+
+	api := client.New(*server)
+	user, err := api.Login(ctx, *email, password)
+	if err != nil {
+		return err
+	}
+
+	a.config = config{
+		Server:       *server,
+		UserID:       user.ID,
+		Email:        user.Email,
+		AccessToken:  user.Token,
+		RefreshToken: user.RefreshToken,
+	}
+	if err := a.saveConfig(); err != nil {
+		return err
+	}
+
+	return a.print(user.User, []string{"ID", "NAME", "EMAIL"}, [][]string{{user.ID.String(), user.Name, user.Email}})
+}
+
+func runLogout(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+	// The session is forgotten even if it couldn't be revoked, so that logging out always
+	// logs out here.
+	revokeErr := api.Revoke(ctx)
+	a.config.AccessToken = ""
+	a.config.RefreshToken = ""
+	if err := a.saveConfig(); err != nil {
+		return err
+	}
+	if revokeErr != nil {
+		return fmt.Errorf("revoking session: %w", revokeErr)
+	}
+	return nil
+}
+
+// readLine reads a line, without its line ending.
+func readLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", errors.New("unexpected end of input")
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readPassword reads a password, turning off the terminal's echo while it's typed.
+func (a *app) readPassword(in *bufio.Reader) (string, error) {
+	f, ok := a.stdin.(*os.File)
+	if !ok || !isTerminal(f) {
+		return readLine(in)
+	}
+
+	if stty(f, "-echo") == nil {
+		defer func() {
+			stty(f, "echo")
+			fmt.Fprintln(a.stderr)
+		}()
+	}
+	return readLine(in)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func stty(f *os.File, arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = f
+	return cmd.Run()
+}
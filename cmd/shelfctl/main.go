@@ -0,0 +1,199 @@
+// Command shelfctl manages a Digital Shelf from the command line: its locations, cases,
+// shelves and items, and the invites to share them.
+//
+// Every command prints a table, or JSON with -o json. Commands that read barcodes, such as
+// barcode and move, read one per line from standard input when none are given, so a scanner
+// can be piped into them.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+// command is a shelfctl subcommand.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{"login", "[-server URL] [-email EMAIL] [-password-stdin]", "Log in, saving the session to the config file", runLogin},
+	{"logout", "", "Log out, revoking the saved session", runLogout},
+	{"locations", "", "List your locations", runLocations},
+	{"cases", "-location ID", "List a location's cases", runCases},
+	{"shelves", "-location ID | -case ID", "List a location's or case's shelves", runShelves},
+	{"items", "-shelf ID", "List the items on a shelf", runItems},
+	{"add", "TYPE -shelf ID -title TITLE [-barcode BARCODE] [-set FIELD=VALUE]...", "Add an item", runAdd},
+	{"move", "-shelf ID [ITEM]...", "Move items, given as TYPE/ID or a barcode, to a shelf", runMove},
+	{"search", "[-location ID] [-type TYPE] [-genre GENRE] [-format FORMAT] QUERY", "Search your items", runSearch},
+	{"barcode", "[-location ID] [BARCODE]...", "Look up where the items with a barcode are", runBarcode},
+	{"import", "[-shelf ID] [FILE]", "Add the items in a CSV file", runImport},
+	{"export", "-location ID [-type TYPE] [FILE]", "Write a location's items to a CSV file", runExport},
+	{"invites", "list|send|withdraw|accept|decline ...", "Manage invites to locations", runInvites},
+}
+
+// usageError is an error in how a command was called.
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+// app is the state shared by the commands.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// output is the output format, "table" or "json".
+	output     string
+	configPath string
+	config     config
+	api        *client.Client
+	// cmd is the command being run.
+	cmd *command
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs shelfctl with args, returning its exit code: 0 if it succeeded, 2 if it was called
+// wrong, and 1 if it failed otherwise.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr, output: "table"}
+
+	fs := flag.NewFlagSet("shelfctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&a.output, "o", a.output, "output format: table or json")
+	fs.StringVar(&a.configPath, "config", "", "config file (default $SHELFCTL_CONFIG, or shelfctl/config.json in the user config dir)")
+	fs.Usage = func() { a.usage() }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		a.usage()
+		return 2
+	}
+
+	name := fs.Arg(0)
+	for i, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		a.cmd = &commands[i]
+		err := cmd.run(ctx, a, fs.Args()[1:])
+		var usageErr *usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(stderr, "shelfctl %s: %s\nusage: shelfctl %s %s\n", cmd.name, err, cmd.name, cmd.args)
+			return 2
+		case errors.Is(err, errFlags):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "shelfctl %s: %s\n", cmd.name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "shelfctl: unknown command %q\n", name)
+	a.usage()
+	return 2
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "usage: shelfctl [-o table|json] [-config FILE] COMMAND [ARGS]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(a.stderr, "\nRun shelfctl COMMAND -h for a command's flags.")
+}
+
+// errFlags is returned when a command's flags couldn't be parsed. The flag package has
+// already said why.
+var errFlags = errors.New("invalid flags")
+
+// flags returns a flag set for the command being run. It has -o too, so that the output
+// format can come after the command.
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("shelfctl "+a.cmd.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.output, "o", a.output, "output format: table or json")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "usage: shelfctl %s %s\n\n%s.\n\n", a.cmd.name, a.cmd.args, a.cmd.summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses a command's flags, and checks the output format.
+func (a *app) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlags
+	}
+	if a.output != "table" && a.output != "json" {
+		return usagef("unknown output format %q, use table or json", a.output)
+	}
+	return nil
+}
+
+// idFlag is a flag holding a UUID.
+type idFlag struct {
+	uuid.UUID
+}
+
+func (f *idFlag) Set(s string) error {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return errors.New("not a valid ID")
+	}
+	f.UUID = id
+	return nil
+}
+
+func (f *idFlag) String() string {
+	if f.UUID == uuid.Nil {
+		return ""
+	}
+	return f.UUID.String()
+}
+
+// listFlag is a flag that can be given more than once.
+type listFlag []string
+
+func (f *listFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/Rodabaugh/digitalshelf/internal/auth"
+	"github.com/google/uuid"
+)
+
+func TestLogin(t *testing.T) {
+	userID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/login" || body.Email != "user@example.com" || body.Password != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"Incorrect email or password"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%q,"name":"User","email":"user@example.com","token":"access","refresh_token":"refresh"}`, userID)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		password string
+		wantCode int
+	}{
+		{
+			name:     "Correct password",
+			password: "hunter2\n",
+			wantCode: 0,
+		},
+		{
+			name:     "Incorrect password",
+			password: "wrong\n",
+			wantCode: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "shelfctl", "config.json")
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), []string{"-config", path, "login", "-server", server.URL, "-email", "user@example.com", "-password-stdin"}, strings.NewReader(tt.password), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantCode != 0 {
+				if _, err := os.Stat(path); err == nil {
+					t.Errorf("config was saved after a failed login")
+				}
+				return
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("config wasn't saved: %v", err)
+			}
+			if info.Mode().Perm() != 0o600 {
+				t.Errorf("config permissions = %v, want 0600", info.Mode().Perm())
+			}
+
+			a := &app{configPath: path}
+			if err := a.loadConfig(); err != nil {
+				t.Fatalf("loadConfig() error = %v", err)
+			}
+			want := config{Server: server.URL, UserID: userID, Email: "user@example.com", AccessToken: "access", RefreshToken: "refresh"}
+			if a.config != want {
+				t.Errorf("config = %+v, want %+v", a.config, want)
+			}
+		})
+	}
+}
+
+func TestOutput(t *testing.T) {
+	userID := uuid.New()
+	locationID := uuid.New()
+	token, _ := auth.MakeJWT(userID, "secret", time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/users/"+userID.String()+"/locations" || r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `[{"userID":%q,"location_id":%q,"location_name":"Living Room","owner_id":%q}]`, userID, locationID, userID)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	data, _ := json.Marshal(config{Server: server.URL, UserID: userID, AccessToken: token, RefreshToken: "refresh"})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		check    func(t *testing.T, stdout string)
+	}{
+		{
+			name: "Table",
+			args: []string{"-config", path, "locations"},
+			check: func(t *testing.T, stdout string) {
+				lines := strings.Split(strings.TrimSpace(stdout), "\n")
+				if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Living Room") {
+					t.Errorf("stdout = %q, want a header and a row for the location", stdout)
+				}
+			},
+		},
+		{
+			name: "JSON",
+			args: []string{"-config", path, "-o", "json", "locations"},
+			check: func(t *testing.T, stdout string) {
+				var locations []client.UserLocation
+				if err := json.Unmarshal([]byte(stdout), &locations); err != nil {
+					t.Fatalf("stdout isn't JSON: %v", err)
+				}
+				if len(locations) != 1 || locations[0].LocationID != locationID {
+					t.Errorf("locations = %+v, want %v", locations, locationID)
+				}
+			},
+		},
+		{
+			name: "JSON after the command",
+			args: []string{"-config", path, "locations", "-o", "json"},
+			check: func(t *testing.T, stdout string) {
+				if !json.Valid([]byte(stdout)) {
+					t.Errorf("stdout = %q, want JSON", stdout)
+				}
+			},
+		},
+		{
+			name:     "Unknown format",
+			args:     []string{"-config", path, "-o", "yaml", "locations"},
+			wantCode: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, strings.NewReader(""), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.check != nil {
+				tt.check(t, stdout.String())
+			}
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	shelfID := uuid.New()
+	records := []record{
+		{
+			itemType: "movie",
+			id:       uuid.New(),
+			params: &client.MovieParams{
+				Title:          "Alien, the \"Director's Cut\"",
+				Director:       "Ridley Scott",
+				Barcode:        "012345678905",
+				Format:         "Blu-ray",
+				ShelfID:        shelfID,
+				ReleaseDate:    "1979-05",
+				RuntimeMinutes: 117,
+				ThicknessCM:    1.2,
+			},
+		},
+		{
+			itemType: "music",
+			id:       uuid.New(),
+			params: &client.MusicParams{
+				Title:     "Rumours",
+				Artist:    "Fleetwood Mac",
+				ShelfID:   shelfID,
+				DiscCount: 1,
+				Tracklist: []client.Track{{Disc: 1, Number: 1, Title: "Second Hand News", DurationSeconds: 163}},
+			},
+		},
+	}
+	types := []itemType{}
+	for _, name := range []string{"movie", "music"} {
+		typ, _ := findItemType(name)
+		types = append(types, typ)
+	}
+
+	var out bytes.Buffer
+	if err := writeCSV(&out, types, records); err != nil {
+		t.Fatalf("writeCSV() error = %v", err)
+	}
+	items, lines, err := readCSV(context.Background(), &out, "", uuid.Nil, nil)
+	if err != nil {
+		t.Fatalf("readCSV() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(lines, []int{2, 3}) {
+		t.Errorf("lines = %v, want [2 3]", lines)
+	}
+	if len(items) != len(records) {
+		t.Fatalf("read %d items, want %d", len(items), len(records))
+	}
+	for i, item := range items {
+		if item.ItemType != records[i].itemType || !reflect.DeepEqual(item.Item, records[i].params) {
+			t.Errorf("item %d = %s %+v, want %s %+v", i, item.ItemType, item.Item, records[i].itemType, records[i].params)
+		}
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	shelfID := uuid.New()
+
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr string
+	}{
+		{
+			name:    "Empty file",
+			csv:     "",
+			wantErr: "empty",
+		},
+		{
+			name:    "No item type",
+			csv:     "title,shelf_id\nAlien," + shelfID.String() + "\n",
+			wantErr: "line 2: item_type is empty",
+		},
+		{
+			name:    "No shelf",
+			csv:     "item_type,title\nmovie,Alien\n",
+			wantErr: "line 2: shelf_id is empty",
+		},
+		{
+			name:    "Field of another type",
+			csv:     "item_type,title,author,shelf_id\nmovie,Alien,Alan Dean Foster," + shelfID.String() + "\n",
+			wantErr: `line 2: movie: no field named "author"`,
+		},
+		{
+			name:    "Not a number",
+			csv:     "item_type,title,runtime_minutes,shelf_id\nmovie,Alien,long," + shelfID.String() + "\n",
+			wantErr: "line 2: movie: runtime_minutes must be a whole number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := readCSV(context.Background(), strings.NewReader(tt.csv), "", uuid.Nil, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readCSV() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseItemRef(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name   string
+		s      string
+		want   client.ItemRef
+		wantOK bool
+	}{
+		{
+			name:   "Item",
+			s:      "book/" + id.String(),
+			want:   client.ItemRef{ItemType: "book", ItemID: id},
+			wantOK: true,
+		},
+		{
+			name: "Barcode",
+			s:    "9780261103573",
+		},
+		{
+			name: "Unknown type",
+			s:    "vinyl/" + id.String(),
+		},
+		{
+			name: "Invalid ID",
+			s:    "book/123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseItemRef(tt.s)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseItemRef(%q) = %v, %v, want %v, %v", tt.s, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// print prints v as JSON, or as a table of header and rows.
+func (a *app) print(v any, header []string, rows [][]string) error {
+	if a.output == "json" {
+		// An empty list is printed as [], not null.
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+			v = []any{}
+		}
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// stream prints rows one at a time, as each is ready: as tab-separated lines after a
+// header, or as one JSON value per line.
+type stream struct {
+	a       *app
+	header  []string
+	started bool
+}
+
+func (a *app) stream(header []string) *stream {
+	return &stream{a: a, header: header}
+}
+
+func (s *stream) row(v any, row []string) error {
+	if s.a.output == "json" {
+		return json.NewEncoder(s.a.stdout).Encode(v)
+	}
+
+	if !s.started {
+		s.started = true
+		if _, err := fmt.Fprintln(s.a.stdout, strings.Join(s.header, "\t")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(s.a.stdout, strings.Join(row, "\t"))
+	return err
+}
+
+// eachLine calls fn with each non-empty line of standard input. A line that fails is reported
+// and the rest are still handled, but eachLine fails once they're done.
+func (a *app) eachLine(fn func(line string) error) error {
+	scanner := bufio.NewScanner(a.stdin)
+	failed := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			fmt.Fprintf(a.stderr, "shelfctl: %s\n", err)
+			failed++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading standard input: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of the lines failed", failed)
+	}
+	return nil
+}
+
+// formatTime formats a time for a table.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// formatID formats an ID for a table, leaving it empty if there isn't one.
+func formatID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/google/uuid"
+)
+
+var searchHeader = []string{"ITEM", "TITLE", "CREATOR", "FORMAT", "RELEASED", "SHELF"}
+
+func searchRow(result client.SearchResult) []string {
+	return []string{
+		itemRef(client.ItemRef{ItemType: result.ItemType, ItemID: result.ID}),
+		result.Title,
+		result.Creator,
+		result.Format,
+		result.ReleaseDate,
+		formatID(result.ShelfID),
+	}
+}
+
+func runSearch(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	var types, genres, formats listFlag
+	fs.Var(&locationID, "location", "only search this location, otherwise all of yours are")
+	fs.Var(&types, "type", "only find items of this type; can be repeated, and needs -location")
+	fs.Var(&genres, "genre", "only find items of this genre; can be repeated, and needs -location")
+	fs.Var(&formats, "format", "only find items of this format; can be repeated, and needs -location")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		return usagef("a query is required")
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	if locationID.UUID == uuid.Nil {
+		if len(types)+len(genres)+len(formats) > 0 {
+			return usagef("-type, -genre and -format need -location")
+		}
+
+		response, err := api.Search.User(ctx, a.config.UserID, query, client.UserSearchOptions{})
+		if err != nil {
+			return err
+		}
+
+		var rows [][]string
+		for _, location := range response.Locations {
+			for _, result := range location.Results {
+				rows = append(rows, append(searchRow(result), location.LocationName))
+			}
+		}
+		return a.print(response, append(searchHeader, "LOCATION"), rows)
+	}
+
+	filters := map[string][]string{}
+	for name, values := range map[string]listFlag{"type": types, "genre": genres, "format": formats} {
+		if len(values) > 0 {
+			filters[name] = values
+		}
+	}
+	response, err := api.Search.Location(ctx, locationID.UUID, query, filters)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		rows = append(rows, searchRow(result))
+	}
+	return a.print(response, searchHeader, rows)
+}
+
+var barcodeHeader = []string{"BARCODE", "ITEM", "TITLE", "CREATOR", "FORMAT", "SHELF", "MISSING"}
+
+func barcodeRow(match client.BarcodeMatch) []string {
+	missing := ""
+	if match.MissingSince != nil {
+		missing = formatTime(*match.MissingSince)
+	}
+	return []string{
+		match.Barcode,
+		itemRef(client.ItemRef{ItemType: match.ItemType, ItemID: match.ID}),
+		match.Title,
+		match.Creator,
+		match.Format,
+		match.Shelf.Path,
+		missing,
+	}
+}
+
+func runBarcode(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var locationID idFlag
+	fs.Var(&locationID, "location", "only look in this location, otherwise all of yours are")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	api, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	lookup := func(barcode string) ([]client.BarcodeMatch, error) {
+		matches, err := api.Search.Barcode(ctx, barcode, locationID.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("looking up %s: %w", barcode, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no item has barcode %s", barcode)
+		}
+		return matches, nil
+	}
+
+	// Barcodes read from standard input are looked up as each is read.
+	if fs.NArg() == 0 {
+		out := a.stream(barcodeHeader)
+		return a.eachLine(func(barcode string) error {
+			matches, err := lookup(barcode)
+			if err != nil {
+				return err
+			}
+			for _, match := range matches {
+				if err := out.row(match, barcodeRow(match)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	var all []client.BarcodeMatch
+	var rows [][]string
+	var notFound error
+	for _, barcode := range fs.Args() {
+		matches, err := lookup(barcode)
+		if err != nil {
+			if client.StatusCode(err) != 0 {
+				return err
+			}
+			fmt.Fprintf(a.stderr, "shelfctl barcode: %s\n", err)
+			notFound = fmt.Errorf("not every barcode was found")
+			continue
+		}
+		for _, match := range matches {
+			all = append(all, match)
+			rows = append(rows, barcodeRow(match))
+		}
+	}
+	if err := a.print(all, barcodeHeader, rows); err != nil {
+		return err
+	}
+	return notFound
+}
@@ -11,19 +11,178 @@ import (
 )
 
 type AppState struct {
-	userID	string
+	userID             string
+	pinnedSmartShelves []SmartShelf
+	view               string
 }
 
 func (cfg *apiConfig) webApp(w http.ResponseWriter, r *http.Request) {
+	cfg.renderMainPage(w, r, "")
+}
+
+// renderMainPage renders the whole app. view is the page loaded into it, if any, so that a
+// link to a page of the app can be opened directly, such as from a scanned label.
+func (cfg *apiConfig) renderMainPage(w http.ResponseWriter, r *http.Request, view string) {
 	cookieUserID := cfg.getRequestUserID(r)
 
 	appState := AppState{
 		userID: cookieUserID.String(),
+		view:   view,
+	}
+
+	if cookieUserID != uuid.Nil {
+		dbSmartShelves, err := cfg.db.GetPinnedSmartShelvesForUser(r.Context(), cookieUserID)
+		if err != nil {
+			fmt.Printf("Unable to get pinned smart shelves: %v\n", err)
+		} else if appState.pinnedSmartShelves, err = smartShelvesFromDB(dbSmartShelves); err != nil {
+			fmt.Printf("Unable to read pinned smart shelves: %v\n", err)
+		}
 	}
 
 	MainPage(&appState).Render(r.Context(), w)
 }
 
+// appIsMember reports whether the user of the app is a member of the location.
+func (cfg *apiConfig) appIsMember(r *http.Request, locationID uuid.UUID) (bool, error) {
+	dbUserLocations, err := cfg.db.GetUserLocations(r.Context(), cfg.getRequestUserID(r))
+	if err != nil {
+		return false, err
+	}
+	for _, userLocation := range dbUserLocations {
+		if userLocation.ID == locationID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// appGetShelf shows the items on a shelf. Shelf labels link here, so a request that isn't
+// from htmx gets the whole app with the shelf loaded into it.
+func (cfg *apiConfig) appGetShelf(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("HX-Request") != "true" {
+		cfg.renderMainPage(w, r, r.URL.Path)
+		return
+	}
+
+	shelfID, err := uuid.Parse(r.PathValue("shelf_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid shelf ID", err)
+		return
+	}
+
+	dbShelf, err := cfg.db.GetShelfByID(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return
+	}
+
+	location, err := cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return
+	}
+
+	member, err := cfg.appIsMember(r, location.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get locations for user", err)
+		return
+	}
+	if !member {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to view this shelf", nil)
+		return
+	}
+
+	dbItems, err := cfg.db.GetShelfItems(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf items", err)
+		return
+	}
+
+	ShelfView(shelfFromDB(dbShelf), location.ID, shelfItemsFromDB(dbItems)).Render(r.Context(), w)
+}
+
+// appGetCase shows the shelves of a case. Case labels link here, so a request that isn't
+// from htmx gets the whole app with the case loaded into it.
+func (cfg *apiConfig) appGetCase(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("HX-Request") != "true" {
+		cfg.renderMainPage(w, r, r.URL.Path)
+		return
+	}
+
+	caseID, err := uuid.Parse(r.PathValue("case_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid case ID", err)
+		return
+	}
+
+	dbCase, err := cfg.db.GetCaseByID(r.Context(), caseID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Case not found", err)
+		return
+	}
+
+	member, err := cfg.appIsMember(r, dbCase.LocationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get locations for user", err)
+		return
+	}
+	if !member {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to view this case", nil)
+		return
+	}
+
+	dbShelves, err := cfg.db.GetShelvesByCase(r.Context(), caseID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelves", err)
+		return
+	}
+
+	shelves := []Shelf{}
+	for _, dbShelf := range dbShelves {
+		shelves = append(shelves, shelfFromDB(dbShelf))
+	}
+
+	CaseView(caseFromDB(dbCase), shelves).Render(r.Context(), w)
+}
+
+// appGetSmartShelf shows the items of a smart shelf, for the smart shelves pinned to the menu.
+func (cfg *apiConfig) appGetSmartShelf(w http.ResponseWriter, r *http.Request) {
+	smartShelfID, err := uuid.Parse(r.PathValue("smart_shelf_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid smart shelf ID", err)
+		return
+	}
+
+	dbSmartShelf, err := cfg.db.GetSmartShelfByID(r.Context(), smartShelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Smart shelf not found", err)
+		return
+	}
+
+	member, err := cfg.appIsMember(r, dbSmartShelf.LocationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get locations for user", err)
+		return
+	}
+	if !member {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to view this smart shelf", nil)
+		return
+	}
+
+	smartShelf, err := smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	results, err := cfg.searchLocation(r.Context(), smartShelf.LocationID, smartShelf.Query, smartShelf.Filters)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search items", err)
+		return
+	}
+
+	SmartShelfItems(smartShelf, results.Results).Render(r.Context(), w)
+}
 
 func (cfg *apiConfig) appGetUserLocations(w http.ResponseWriter, r *http.Request) {
 	userIDString := r.PathValue("user_id")
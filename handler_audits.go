@@ -0,0 +1,648 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+// An Audit compares the items recorded at a location, case or shelf with the barcodes
+// scanned on its shelves. At most one of CaseID and ShelfID is set.
+type Audit struct {
+	ID          uuid.UUID     `json:"id"`
+	LocationID  uuid.UUID     `json:"location_id"`
+	CaseID      uuid.NullUUID `json:"case_id"`
+	ShelfID     uuid.NullUUID `json:"shelf_id"`
+	StartedBy   uuid.UUID     `json:"started_by"`
+	CompletedAt *time.Time    `json:"completed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type AuditItem struct {
+	ItemType     string     `json:"item_type"`
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Barcode      string     `json:"barcode"`
+	ShelfID      uuid.UUID  `json:"shelf_id"`
+	MissingSince *time.Time `json:"missing_since"`
+}
+
+// MisplacedItem is an item that was scanned on a different shelf from the one it's recorded on.
+type MisplacedItem struct {
+	AuditItem
+	ShelfName      string    `json:"shelf_name"`
+	FoundShelfID   uuid.UUID `json:"found_shelf_id"`
+	FoundShelfName string    `json:"found_shelf_name"`
+}
+
+type UnknownBarcode struct {
+	Barcode   string    `json:"barcode"`
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	ShelfName string    `json:"shelf_name"`
+	Scans     int64     `json:"scans"`
+}
+
+type AuditReport struct {
+	Audit     Audit            `json:"audit"`
+	Scans     int64            `json:"scans"`
+	Found     int              `json:"found"`
+	Missing   []AuditItem      `json:"missing"`
+	Misplaced []MisplacedItem  `json:"misplaced"`
+	Unknown   []UnknownBarcode `json:"unknown"`
+}
+
+// AuditScanResult is the outcome of one scanned barcode: found, misplaced or unknown.
+type AuditScanResult struct {
+	Barcode string      `json:"barcode"`
+	Status  string      `json:"status"`
+	Items   []AuditItem `json:"items"`
+}
+
+// auditItemRef identifies an item in the body of the relocate and mark missing actions.
+type auditItemRef struct {
+	ItemType string    `json:"item_type"`
+	ItemID   uuid.UUID `json:"item_id"`
+}
+
+func auditFromDB(dbAudit database.Audit) Audit {
+	return Audit{
+		ID:          dbAudit.ID,
+		LocationID:  dbAudit.LocationID,
+		CaseID:      dbAudit.CaseID,
+		ShelfID:     dbAudit.ShelfID,
+		StartedBy:   dbAudit.StartedBy,
+		CompletedAt: nullTimeToPointer(dbAudit.CompletedAt),
+		CreatedAt:   dbAudit.CreatedAt,
+		UpdatedAt:   dbAudit.UpdatedAt,
+	}
+}
+
+func auditItemFromDB(dbItem database.LocationItem) AuditItem {
+	return AuditItem{
+		ItemType:     dbItem.ItemType,
+		ID:           dbItem.ID,
+		Title:        dbItem.Title,
+		Barcode:      dbItem.Barcode,
+		ShelfID:      dbItem.ShelfID,
+		MissingSince: nullTimeToPointer(dbItem.MissingSince),
+	}
+}
+
+func auditFoundItemFromDB(row database.GetAuditFoundItemsRow) AuditItem {
+	return AuditItem{
+		ItemType:     row.ItemType,
+		ID:           row.ID,
+		Title:        row.Title,
+		Barcode:      row.Barcode,
+		ShelfID:      row.ShelfID,
+		MissingSince: nullTimeToPointer(row.MissingSince),
+	}
+}
+
+// getAudit reads the audit ID from the path, loads the audit and checks that the requester
+// is a member of its location, responding with an error if not.
+func (cfg *apiConfig) getAudit(w http.ResponseWriter, r *http.Request) (database.Audit, bool) {
+	auditIDString := r.PathValue("audit_id")
+	if auditIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No audit id was provided", fmt.Errorf("no audit id was provided"))
+		return database.Audit{}, false
+	}
+
+	auditID, err := uuid.Parse(auditIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid audit ID", err)
+		return database.Audit{}, false
+	}
+
+	dbAudit, err := cfg.db.GetAuditByID(r.Context(), auditID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Audit not found", err)
+		return database.Audit{}, false
+	}
+
+	err = cfg.authorizeMember(dbAudit.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to access audits at this location", err)
+		return database.Audit{}, false
+	}
+
+	return dbAudit, true
+}
+
+// getOpenAudit is getAudit for the actions that change an audit, which aren't allowed once it's completed.
+func (cfg *apiConfig) getOpenAudit(w http.ResponseWriter, r *http.Request) (database.Audit, bool) {
+	dbAudit, ok := cfg.getAudit(w, r)
+	if !ok {
+		return database.Audit{}, false
+	}
+
+	if dbAudit.CompletedAt.Valid {
+		respondWithError(w, http.StatusConflict, "Audit is already completed", fmt.Errorf("audit %s is completed", dbAudit.ID))
+		return database.Audit{}, false
+	}
+
+	return dbAudit, true
+}
+
+// handlerAuditCreate starts an audit of a whole location, a case or a shelf. Exactly one of
+// location_id, case_id and shelf_id is sent.
+func (cfg *apiConfig) handlerAuditCreate(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		LocationID uuid.NullUUID `json:"location_id"`
+		CaseID     uuid.NullUUID `json:"case_id"`
+		ShelfID    uuid.NullUUID `json:"shelf_id"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := parameters{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	var locationID uuid.UUID
+	switch {
+	case params.LocationID.Valid && !params.CaseID.Valid && !params.ShelfID.Valid:
+		locationID = params.LocationID.UUID
+	case params.CaseID.Valid && !params.LocationID.Valid && !params.ShelfID.Valid:
+		caseLocation, err := cfg.db.GetCaseLocation(r.Context(), params.CaseID.UUID)
+		if err != nil {
+			respondWithError(w, http.StatusNotFound, "Case not found", err)
+			return
+		}
+		locationID = caseLocation.ID
+	case params.ShelfID.Valid && !params.LocationID.Valid && !params.CaseID.Valid:
+		shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), params.ShelfID.UUID)
+		if err != nil {
+			respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+			return
+		}
+		locationID = shelfLocation.ID
+	default:
+		respondWithError(w, http.StatusBadRequest, "Exactly one of location_id, case_id and shelf_id is required", nil)
+		return
+	}
+
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to audit this location", err)
+		return
+	}
+
+	userID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	dbAudit, err := cfg.db.CreateAudit(r.Context(), database.CreateAuditParams{
+		LocationID: locationID,
+		CaseID:     params.CaseID,
+		ShelfID:    params.ShelfID,
+		StartedBy:  userID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create audit", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, auditFromDB(dbAudit))
+}
+
+func (cfg *apiConfig) handlerAuditGetByID(w http.ResponseWriter, r *http.Request) {
+	dbAudit, ok := cfg.getAudit(w, r)
+	if !ok {
+		return
+	}
+
+	respondWithEntity(w, r, dbAudit.UpdatedAt, auditFromDB(dbAudit))
+}
+
+func (cfg *apiConfig) handlerAuditsGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get audits for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get audits for this location", err)
+		return
+	}
+
+	dbAudits, err := cfg.db.GetAuditsByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get audits", err)
+		return
+	}
+
+	audits := []Audit{}
+	for _, dbAudit := range dbAudits {
+		audits = append(audits, auditFromDB(dbAudit))
+	}
+
+	respondWithJSON(w, http.StatusOK, audits)
+}
+
+// handlerAuditScan records barcodes scanned on a shelf. For a shelf audit, shelf_id defaults
+// to the audited shelf. Items marked missing are no longer missing once they are scanned.
+func (cfg *apiConfig) handlerAuditScan(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		ShelfID  uuid.NullUUID `json:"shelf_id"`
+		Barcodes []string      `json:"barcodes"`
+	}
+
+	dbAudit, ok := cfg.getOpenAudit(w, r)
+	if !ok {
+		return
+	}
+
+	params := parameters{}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if !params.ShelfID.Valid {
+		params.ShelfID = dbAudit.ShelfID
+	}
+	if !params.ShelfID.Valid {
+		respondWithError(w, http.StatusBadRequest, "shelf_id is required", nil)
+		return
+	}
+
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), params.ShelfID.UUID)
+	if err != nil || shelfLocation.ID != dbAudit.LocationID {
+		respondWithError(w, http.StatusBadRequest, "Shelf is not at the audited location", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	for _, barcode := range params.Barcodes {
+		if barcode == "" {
+			continue
+		}
+		_, err := qtx.CreateAuditScan(r.Context(), database.CreateAuditScanParams{
+			AuditID: dbAudit.ID,
+			Barcode: barcode,
+			ShelfID: params.ShelfID.UUID,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to record scan", err)
+			return
+		}
+	}
+
+	found, err := qtx.GetAuditFoundItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get scanned items", err)
+		return
+	}
+
+	byBarcode := map[string][]database.GetAuditFoundItemsRow{}
+	changes := []itemChange{}
+	for _, row := range found {
+		if row.MissingSince.Valid {
+			change, err := setItemMissingSince(r.Context(), qtx, dbAudit.LocationID, row.ItemType, row.ID, sql.NullTime{})
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, "Unable to clear missing item", err)
+				return
+			}
+			changes = append(changes, change)
+			row.MissingSince = sql.NullTime{}
+		}
+
+		byBarcode[row.Barcode] = append(byBarcode[row.Barcode], row)
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	results := []AuditScanResult{}
+	for _, barcode := range params.Barcodes {
+		if barcode == "" {
+			continue
+		}
+		result := AuditScanResult{Barcode: barcode, Status: "unknown", Items: []AuditItem{}}
+		for _, row := range byBarcode[barcode] {
+			if row.FoundShelfID != params.ShelfID.UUID {
+				continue
+			}
+			if result.Status != "misplaced" {
+				result.Status = "found"
+			}
+			if row.ShelfID != row.FoundShelfID {
+				result.Status = "misplaced"
+			}
+			result.Items = append(result.Items, auditFoundItemFromDB(row))
+		}
+		results = append(results, result)
+	}
+
+	respondWithJSON(w, http.StatusOK, results)
+}
+
+// handlerAuditReport lists the items in the audit's scope that haven't been scanned, the
+// items scanned on a different shelf from their recorded one, and the scanned barcodes that
+// don't match any item at the location.
+func (cfg *apiConfig) handlerAuditReport(w http.ResponseWriter, r *http.Request) {
+	dbAudit, ok := cfg.getAudit(w, r)
+	if !ok {
+		return
+	}
+
+	report := AuditReport{
+		Audit:     auditFromDB(dbAudit),
+		Missing:   []AuditItem{},
+		Misplaced: []MisplacedItem{},
+		Unknown:   []UnknownBarcode{},
+	}
+
+	scans, err := cfg.db.CountAuditScans(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to count scans", err)
+		return
+	}
+	report.Scans = scans
+
+	found, err := cfg.db.GetAuditFoundItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get scanned items", err)
+		return
+	}
+
+	items, err := cfg.db.GetAuditItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get missing items", err)
+		return
+	}
+	for _, dbItem := range auditMissingItems(items, found) {
+		report.Missing = append(report.Missing, auditItemFromDB(dbItem))
+	}
+	for _, row := range found {
+		if row.ShelfID == row.FoundShelfID {
+			report.Found++
+			continue
+		}
+		report.Misplaced = append(report.Misplaced, MisplacedItem{
+			AuditItem:      auditFoundItemFromDB(row),
+			ShelfName:      row.RecordedShelfName,
+			FoundShelfID:   row.FoundShelfID,
+			FoundShelfName: row.FoundShelfName,
+		})
+	}
+
+	unknown, err := cfg.db.GetAuditUnknownBarcodes(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get unknown barcodes", err)
+		return
+	}
+	for _, row := range unknown {
+		report.Unknown = append(report.Unknown, UnknownBarcode{
+			Barcode:   row.Barcode,
+			ShelfID:   row.ShelfID,
+			ShelfName: row.ShelfName,
+			Scans:     row.Scans,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// handlerAuditRelocate moves misplaced items to the shelf they were scanned on. If items are
+// listed only those are moved, otherwise every misplaced item is.
+func (cfg *apiConfig) handlerAuditRelocate(w http.ResponseWriter, r *http.Request) {
+	dbAudit, ok := cfg.getOpenAudit(w, r)
+	if !ok {
+		return
+	}
+
+	selected, ok := decodeAuditItemRefs(w, r)
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	found, err := qtx.GetAuditFoundItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get scanned items", err)
+		return
+	}
+
+	moved := []AuditItem{}
+	movedRows := []database.GetAuditFoundItemsRow{}
+	for _, row := range found {
+		if row.ShelfID == row.FoundShelfID {
+			continue
+		}
+		if selected != nil && !selected[auditItemRef{ItemType: row.ItemType, ItemID: row.ID}] {
+			continue
+		}
+
+		t, ok := lookupItemType(row.ItemType)
+		if !ok {
+			respondWithError(w, http.StatusInternalServerError, "Unknown item type", fmt.Errorf("unknown item type: %s", row.ItemType))
+			return
+		}
+
+		err = t.itemMove(r.Context(), qtx, row.ID, row.FoundShelfID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to move %s", row.ItemType), err)
+			return
+		}
+
+		item := auditFoundItemFromDB(row)
+		item.ShelfID = row.FoundShelfID
+		moved = append(moved, item)
+		movedRows = append(movedRows, row)
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for _, row := range movedRows {
+		t, _ := lookupItemType(row.ItemType)
+		cfg.publishItemMoved(r.Context(), t, dbAudit.LocationID, row.ID, row.ShelfID)
+	}
+
+	respondWithJSON(w, http.StatusOK, moved)
+}
+
+// handlerAuditMarkMissing marks the items that haven't been scanned as missing. If items are
+// listed only those are marked, otherwise every missing item is. Items without a barcode are
+// never missing, since there's nothing to scan; marking them would mark them on every audit.
+func (cfg *apiConfig) handlerAuditMarkMissing(w http.ResponseWriter, r *http.Request) {
+	dbAudit, ok := cfg.getOpenAudit(w, r)
+	if !ok {
+		return
+	}
+
+	selected, ok := decodeAuditItemRefs(w, r)
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	found, err := qtx.GetAuditFoundItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get scanned items", err)
+		return
+	}
+
+	items, err := qtx.GetAuditItems(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get missing items", err)
+		return
+	}
+	missing := auditMissingItems(items, found)
+
+	now := time.Now().UTC()
+	marked := []AuditItem{}
+	changes := []itemChange{}
+	for _, dbItem := range missing {
+		if selected != nil && !selected[auditItemRef{ItemType: dbItem.ItemType, ItemID: dbItem.ID}] {
+			continue
+		}
+		// Keep the date an item first went missing.
+		if !dbItem.MissingSince.Valid {
+			dbItem.MissingSince = sql.NullTime{Time: now, Valid: true}
+			change, err := setItemMissingSince(r.Context(), qtx, dbAudit.LocationID, dbItem.ItemType, dbItem.ID, dbItem.MissingSince)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, "Unable to mark item missing", err)
+				return
+			}
+			changes = append(changes, change)
+		}
+		marked = append(marked, auditItemFromDB(dbItem))
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	respondWithJSON(w, http.StatusOK, marked)
+}
+
+func (cfg *apiConfig) handlerAuditComplete(w http.ResponseWriter, r *http.Request) {
+	dbAudit, ok := cfg.getOpenAudit(w, r)
+	if !ok {
+		return
+	}
+
+	dbAudit, err := cfg.db.CompleteAudit(r.Context(), dbAudit.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to complete audit", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, auditFromDB(dbAudit))
+}
+
+// auditMissingItems returns the items that no scan was matched to.
+func auditMissingItems(items []database.LocationItem, found []database.GetAuditFoundItemsRow) []database.LocationItem {
+	matched := map[auditItemRef]bool{}
+	for _, row := range found {
+		matched[auditItemRef{ItemType: row.ItemType, ItemID: row.ID}] = true
+	}
+
+	missing := []database.LocationItem{}
+	for _, item := range items {
+		if !matched[auditItemRef{ItemType: item.ItemType, ItemID: item.ID}] {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+// decodeAuditItemRefs reads the optional list of items an audit action applies to. It returns
+// nil when no items are listed, meaning the action applies to every item.
+func decodeAuditItemRefs(w http.ResponseWriter, r *http.Request) (map[auditItemRef]bool, bool) {
+	var params struct {
+		Items []auditItemRef `json:"items"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return nil, false
+	}
+
+	if len(params.Items) == 0 {
+		return nil, true
+	}
+
+	selected := map[auditItemRef]bool{}
+	for _, ref := range params.Items {
+		selected[ref] = true
+	}
+	return selected, true
+}
+
+// setItemMissingSince sets or clears when an item went missing. It returns an item.updated
+// change to publish once the transaction is committed.
+func setItemMissingSince(ctx context.Context, db *database.Queries, locationID uuid.UUID, itemType string, id uuid.UUID, missingSince sql.NullTime) (itemChange, error) {
+	t, ok := lookupItemType(itemType)
+	if !ok {
+		return itemChange{}, fmt.Errorf("unknown item type: %s", itemType)
+	}
+
+	err := t.itemSetMissingSince(ctx, db, id, missingSince)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	return updatedItemChange(ctx, db, t, id, locationID)
+}
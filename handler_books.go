@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+type Book struct {
+	ID              uuid.UUID           `json:"id"`
+	Title           string              `json:"title"`
+	Author          string              `json:"author"`
+	Genre           string              `json:"genre"`
+	Barcode         string              `json:"barcode"`
+	ShelfID         uuid.UUID           `json:"shelf_id"`
+	PublicationDate partialdate.Date    `json:"publication_date"`
+	ISBN            string              `json:"isbn"`
+	Publisher       string              `json:"publisher"`
+	PageCount       int32               `json:"page_count"`
+	Edition         string              `json:"edition"`
+	Language        string              `json:"language"`
+	Series          string              `json:"series"`
+	SeriesNumber    string              `json:"series_number"`
+	Position        int32               `json:"position"`
+	ThicknessCM     float64             `json:"thickness_cm"`
+	MissingSince    *time.Time          `json:"missing_since"`
+	CustomFields    customfields.Values `json:"custom_fields"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+type bookParams struct {
+	Title           string              `json:"title"`
+	Author          string              `json:"author"`
+	Genre           string              `json:"genre"`
+	Barcode         string              `json:"barcode"`
+	ShelfID         uuid.UUID           `json:"shelf_id"`
+	PublicationDate partialdate.Date    `json:"publication_date"`
+	ISBN            string              `json:"isbn"`
+	Publisher       string              `json:"publisher"`
+	PageCount       int32               `json:"page_count"`
+	Edition         string              `json:"edition"`
+	Language        string              `json:"language"`
+	Series          string              `json:"series"`
+	SeriesNumber    string              `json:"series_number"`
+	ThicknessCM     float64             `json:"thickness_cm"`
+	CustomFields    customfields.Values `json:"custom_fields"`
+}
+
+var bookItems = &itemType[database.Book, Book, bookParams]{
+	name:   "book",
+	plural: "books",
+
+	toItem: func(dbBook database.Book) Book {
+		return Book{
+			ID:              dbBook.ID,
+			Title:           dbBook.Title,
+			Author:          dbBook.Author,
+			Genre:           dbBook.Genre,
+			Barcode:         dbBook.Barcode,
+			ShelfID:         dbBook.ShelfID,
+			PublicationDate: partialdate.FromNullTime(dbBook.PublicationDate, dbBook.PublicationDatePrecision),
+			ISBN:            dbBook.Isbn,
+			Publisher:       dbBook.Publisher,
+			PageCount:       dbBook.PageCount,
+			Edition:         dbBook.Edition,
+			Language:        dbBook.Language,
+			Series:          dbBook.Series,
+			SeriesNumber:    dbBook.SeriesNumber,
+			Position:        dbBook.Position,
+			ThicknessCM:     dbBook.ThicknessCm,
+			MissingSince:    nullTimeToPointer(dbBook.MissingSince),
+			CustomFields:    customFieldsFromDB(dbBook.CustomFields),
+			CreatedAt:       dbBook.CreatedAt,
+			UpdatedAt:       dbBook.UpdatedAt,
+		}
+	},
+	toParams: func(dbBook database.Book) bookParams {
+		return bookParams{
+			Title:           dbBook.Title,
+			Author:          dbBook.Author,
+			Genre:           dbBook.Genre,
+			Barcode:         dbBook.Barcode,
+			ShelfID:         dbBook.ShelfID,
+			PublicationDate: partialdate.FromNullTime(dbBook.PublicationDate, dbBook.PublicationDatePrecision),
+			ISBN:            dbBook.Isbn,
+			Publisher:       dbBook.Publisher,
+			PageCount:       dbBook.PageCount,
+			Edition:         dbBook.Edition,
+			Language:        dbBook.Language,
+			Series:          dbBook.Series,
+			SeriesNumber:    dbBook.SeriesNumber,
+			ThicknessCM:     dbBook.ThicknessCm,
+			CustomFields:    customFieldsFromDB(dbBook.CustomFields),
+		}
+	},
+	id:           func(book Book) uuid.UUID { return book.ID },
+	updatedAt:    func(book Book) time.Time { return book.UpdatedAt },
+	shelfID:      func(params bookParams) uuid.UUID { return params.ShelfID },
+	date:         func(book Book) partialdate.Date { return book.PublicationDate },
+	customFields: func(params *bookParams) *customfields.Values { return &params.CustomFields },
+
+	create: func(db *database.Queries, ctx context.Context, params bookParams) (database.Book, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Book{}, err
+		}
+
+		return db.CreateBook(ctx, database.CreateBookParams{
+			Title:                    params.Title,
+			Author:                   params.Author,
+			Genre:                    params.Genre,
+			Barcode:                  params.Barcode,
+			ShelfID:                  params.ShelfID,
+			PublicationDate:          params.PublicationDate.NullTime(),
+			PublicationDatePrecision: params.PublicationDate.PrecisionString(),
+			Isbn:                     params.ISBN,
+			Publisher:                params.Publisher,
+			PageCount:                params.PageCount,
+			Edition:                  params.Edition,
+			Language:                 params.Language,
+			Series:                   params.Series,
+			SeriesNumber:             params.SeriesNumber,
+			ThicknessCm:              params.ThicknessCM,
+			CustomFields:             customFields,
+		})
+	},
+	update: func(db *database.Queries, ctx context.Context, id uuid.UUID, params bookParams) (database.Book, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Book{}, err
+		}
+
+		return db.UpdateBook(ctx, database.UpdateBookParams{
+			ID:                       id,
+			Title:                    params.Title,
+			Author:                   params.Author,
+			Genre:                    params.Genre,
+			Barcode:                  params.Barcode,
+			ShelfID:                  params.ShelfID,
+			PublicationDate:          params.PublicationDate.NullTime(),
+			PublicationDatePrecision: params.PublicationDate.PrecisionString(),
+			Isbn:                     params.ISBN,
+			Publisher:                params.Publisher,
+			PageCount:                params.PageCount,
+			Edition:                  params.Edition,
+			Language:                 params.Language,
+			Series:                   params.Series,
+			SeriesNumber:             params.SeriesNumber,
+			ThicknessCm:              params.ThicknessCM,
+			CustomFields:             customFields,
+		})
+	},
+	delete: (*database.Queries).DeleteBook,
+	lock:   (*database.Queries).LockBook,
+	setPosition: func(db *database.Queries, ctx context.Context, id uuid.UUID, position int32) error {
+		return db.SetBookPosition(ctx, database.SetBookPositionParams{
+			ID:       id,
+			Position: position,
+		})
+	},
+	move: func(db *database.Queries, ctx context.Context, id, shelfID uuid.UUID) error {
+		return db.MoveBook(ctx, database.MoveBookParams{
+			ID:      id,
+			ShelfID: shelfID,
+		})
+	},
+	setMissingSince: func(db *database.Queries, ctx context.Context, id uuid.UUID, missingSince sql.NullTime) error {
+		return db.SetBookMissingSince(ctx, database.SetBookMissingSinceParams{
+			ID:           id,
+			MissingSince: missingSince,
+		})
+	},
+	location: func(db *database.Queries, ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		location, err := db.GetBookLocation(ctx, id)
+		return location.ID, err
+	},
+	addToBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.AddBookToBundle(ctx, database.AddBookToBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	removeFromBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.RemoveBookFromBundle(ctx, database.RemoveBookFromBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	getByBundle: func(db *database.Queries, ctx context.Context, bundleID uuid.UUID) ([]database.Book, error) {
+		return db.GetBooksByBundle(ctx, uuid.NullUUID{UUID: bundleID, Valid: true})
+	},
+	getForUser: (*database.Queries).GetBooksForUser,
+	getByID:    (*database.Queries).GetBookByID,
+	getByBarcode: func(db *database.Queries, ctx context.Context, barcode string, userID uuid.UUID) ([]database.Book, error) {
+		return db.GetBooksByBarcodeForUser(ctx, database.GetBooksByBarcodeForUserParams{
+			Barcode: barcode,
+			UserID:  userID,
+		})
+	},
+	getByShelf: func(db *database.Queries, ctx context.Context, shelfID uuid.UUID, customFields json.RawMessage) ([]database.Book, error) {
+		return db.GetBooksByShelf(ctx, database.GetBooksByShelfParams{
+			ShelfID:      shelfID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocation: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, customFields json.RawMessage) ([]database.Book, error) {
+		return db.GetBooksByLocation(ctx, database.GetBooksByLocationParams{
+			LocationID:   locationID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocationAndDecade: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, decade int32, customFields json.RawMessage) ([]database.Book, error) {
+		return db.GetBooksByLocationAndDecade(ctx, database.GetBooksByLocationAndDecadeParams{
+			LocationID:   locationID,
+			Decade:       decade,
+			CustomFields: customFields,
+		})
+	},
+}
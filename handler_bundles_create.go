@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+type Bundle struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Barcode   string    `json:"barcode"`
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BundleContents struct {
+	Bundle
+	Movies []Movie `json:"movies"`
+	Shows  []Show  `json:"shows"`
+	Books  []Book  `json:"books"`
+	Music  []Music `json:"music"`
+	Games  []Game  `json:"games"`
+}
+
+func (cfg *apiConfig) handlerBundleCreate(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Name    string    `json:"name"`
+		Barcode string    `json:"barcode"`
+		ShelfID uuid.UUID `json:"shelf_id"`
+	}
+
+	type response struct {
+		Bundle
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := parameters{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	if len(params.Name) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Bundle name is required", nil)
+		return
+	}
+
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), params.ShelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to create bundles in this location", err)
+		return
+	}
+
+	bundle, err := cfg.db.CreateBundle(r.Context(), database.CreateBundleParams{
+		Name:    params.Name,
+		Barcode: params.Barcode,
+		ShelfID: params.ShelfID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create bundle", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response{
+		Bundle: Bundle{
+			ID:        bundle.ID,
+			Name:      bundle.Name,
+			Barcode:   bundle.Barcode,
+			ShelfID:   bundle.ShelfID,
+			CreatedAt: bundle.CreatedAt,
+			UpdatedAt: bundle.UpdatedAt,
+		},
+	})
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/etag"
+	"github.com/google/uuid"
+)
+
+func (cfg *apiConfig) handlerBundleGetByID(w http.ResponseWriter, r *http.Request) {
+	bundleIDString := r.PathValue("bundle_id")
+	if bundleIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No bundle id was provided", fmt.Errorf("no bundle id was provided"))
+		return
+	}
+
+	bundleID, err := uuid.Parse(bundleIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid bundle ID", err)
+		return
+	}
+
+	// Validate user is authorized to get bundles at the location of requested bundle.
+	bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(bundleLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get bundles at this location", err)
+		return
+	}
+
+	dbBundle, err := cfg.db.GetBundleByID(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Bundle not found", err)
+		return
+	}
+
+	bundleContents, err := cfg.getBundleContents(r.Context(), cfg.db, dbBundle)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+		return
+	}
+
+	respondWithETag(w, r, bundleContents.entityTag(), bundleContents)
+}
+
+func (cfg *apiConfig) handlerBundlesGetByShelf(w http.ResponseWriter, r *http.Request) {
+	shelfIDString := r.PathValue("shelf_id")
+	if shelfIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No shelf id was provided", fmt.Errorf("no shelf_id was provided"))
+		return
+	}
+
+	shelfID, err := uuid.Parse(shelfIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid shelf ID", err)
+		return
+	}
+
+	// Validate user is authorized to get bundles at the location of shelf.
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get bundles at the location of that shelf", err)
+		return
+	}
+
+	dbBundles, err := cfg.db.GetBundlesByShelf(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "No bundles found for that shelf", err)
+		return
+	}
+
+	bundles := []Bundle{}
+
+	for _, dbBundle := range dbBundles {
+		bundles = append(bundles, Bundle{
+			ID:        dbBundle.ID,
+			Name:      dbBundle.Name,
+			Barcode:   dbBundle.Barcode,
+			ShelfID:   dbBundle.ShelfID,
+			CreatedAt: dbBundle.CreatedAt,
+			UpdatedAt: dbBundle.UpdatedAt,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, bundles)
+}
+
+func (cfg *apiConfig) handlerBundlesGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get bundles for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get bundles for this location", err)
+		return
+	}
+
+	dbBundles, err := cfg.db.GetBundlesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "No bundles found for that location", err)
+		return
+	}
+
+	bundles := []Bundle{}
+
+	for _, dbBundle := range dbBundles {
+		bundles = append(bundles, Bundle{
+			ID:        dbBundle.ID,
+			Name:      dbBundle.Name,
+			Barcode:   dbBundle.Barcode,
+			ShelfID:   dbBundle.ShelfID,
+			CreatedAt: dbBundle.CreatedAt,
+			UpdatedAt: dbBundle.UpdatedAt,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, bundles)
+}
+
+func (cfg *apiConfig) handlerGetBundlesByBarcode(w http.ResponseWriter, r *http.Request) {
+	barcode := r.PathValue("barcode")
+	if barcode == "" {
+		respondWithError(w, http.StatusBadRequest, "No barcode was provided", fmt.Errorf("no barcode was provided"))
+		return
+	}
+
+	// Only bundles at locations the requester is a member of are returned.
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	dbBundles, err := cfg.db.GetBundlesByBarcodeForUser(r.Context(), database.GetBundlesByBarcodeForUserParams{
+		Barcode: barcode,
+		UserID:  requesterID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search bundles", err)
+		return
+	}
+	if len(dbBundles) == 0 {
+		respondWithError(w, http.StatusNotFound, "Bundle not found", nil)
+		return
+	}
+
+	bundles := []BundleContents{}
+
+	for _, dbBundle := range dbBundles {
+		bundleContents, err := cfg.getBundleContents(r.Context(), cfg.db, dbBundle)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+			return
+		}
+		bundles = append(bundles, bundleContents)
+	}
+
+	respondWithJSON(w, http.StatusOK, bundles)
+}
+
+// getBundleContents loads every item of each media type that belongs to the bundle.
+func (cfg *apiConfig) getBundleContents(ctx context.Context, db *database.Queries, dbBundle database.Bundle) (BundleContents, error) {
+	bundleID := uuid.NullUUID{UUID: dbBundle.ID, Valid: true}
+
+	bundleContents := BundleContents{
+		Bundle: Bundle{
+			ID:        dbBundle.ID,
+			Name:      dbBundle.Name,
+			Barcode:   dbBundle.Barcode,
+			ShelfID:   dbBundle.ShelfID,
+			CreatedAt: dbBundle.CreatedAt,
+			UpdatedAt: dbBundle.UpdatedAt,
+		},
+	}
+
+	dbMovies, err := db.GetMoviesByBundle(ctx, bundleID)
+	if err != nil {
+		return BundleContents{}, fmt.Errorf("unable to get bundle movies: %w", err)
+	}
+	bundleContents.Movies = movieItems.toItems(dbMovies)
+
+	dbShows, err := db.GetShowsByBundle(ctx, bundleID)
+	if err != nil {
+		return BundleContents{}, fmt.Errorf("unable to get bundle shows: %w", err)
+	}
+	bundleContents.Shows = showItems.toItems(dbShows)
+
+	dbBooks, err := db.GetBooksByBundle(ctx, bundleID)
+	if err != nil {
+		return BundleContents{}, fmt.Errorf("unable to get bundle books: %w", err)
+	}
+	bundleContents.Books = bookItems.toItems(dbBooks)
+
+	dbMusic, err := db.GetMusicByBundle(ctx, bundleID)
+	if err != nil {
+		return BundleContents{}, fmt.Errorf("unable to get bundle music: %w", err)
+	}
+	bundleContents.Music = musicItems.toItems(dbMusic)
+
+	dbGames, err := db.GetGamesByBundle(ctx, bundleID)
+	if err != nil {
+		return BundleContents{}, fmt.Errorf("unable to get bundle games: %w", err)
+	}
+	bundleContents.Games = gameItems.toItems(dbGames)
+
+	return bundleContents, nil
+}
+
+// entityTag returns the bundle's ETag. It changes when the bundle or any item in it is
+// updated, and when items are added to or removed from it.
+func (b BundleContents) entityTag() string {
+	updatedAt := []time.Time{b.UpdatedAt}
+	for _, movie := range b.Movies {
+		updatedAt = append(updatedAt, movie.UpdatedAt)
+	}
+	for _, show := range b.Shows {
+		updatedAt = append(updatedAt, show.UpdatedAt)
+	}
+	for _, book := range b.Books {
+		updatedAt = append(updatedAt, book.UpdatedAt)
+	}
+	for _, music := range b.Music {
+		updatedAt = append(updatedAt, music.UpdatedAt)
+	}
+	for _, game := range b.Games {
+		updatedAt = append(updatedAt, game.UpdatedAt)
+	}
+	return etag.FromTimes(updatedAt...)
+}
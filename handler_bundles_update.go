@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+func (cfg *apiConfig) handlerBundleMove(w http.ResponseWriter, r *http.Request) {
+	bundleIDString := r.PathValue("bundle_id")
+	if bundleIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No bundle id was provided", nil)
+		return
+	}
+
+	bundleID, err := uuid.Parse(bundleIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid bundle id format", err)
+		return
+	}
+
+	var requestBody struct {
+		ShelfID string `json:"shelf_id"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	shelfID, err := uuid.Parse(requestBody.ShelfID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid shelf id format", err)
+		return
+	}
+
+	// Validate user is authorized to modify bundles at the location of requested bundle.
+	bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(bundleLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to modify bundles at this location", err)
+		return
+	}
+
+	// Validate user is authorized to modify bundles at the location of new shelf.
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to modify bundles at this location", err)
+		return
+	}
+
+	// The bundle and every item in it are moved together, or not at all.
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	if _, ok := cfg.lockBundle(w, r, qtx, bundleID); !ok {
+		return
+	}
+
+	bundle, err := qtx.UpdateBundleShelf(r.Context(), database.UpdateBundleShelfParams{
+		ID:      bundleID,
+		ShelfID: shelfID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Bundle not found", err)
+		return
+	}
+
+	changes, err := moveBundleItems(r.Context(), qtx, bundle.ID, shelfID, bundleLocation.ID, shelfLocation.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to move bundle items", err)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to move bundle", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	bundleContents, err := cfg.getBundleContents(r.Context(), cfg.db, bundle)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+		return
+	}
+
+	respondWithETag(w, r, bundleContents.entityTag(), bundleContents)
+}
+
+func (cfg *apiConfig) handlerBundleAddItem(w http.ResponseWriter, r *http.Request) {
+	bundleIDString := r.PathValue("bundle_id")
+	if bundleIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No bundle id was provided", nil)
+		return
+	}
+
+	bundleID, err := uuid.Parse(bundleIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid bundle id format", err)
+		return
+	}
+
+	var requestBody struct {
+		ItemType string    `json:"item_type"`
+		ItemID   uuid.UUID `json:"item_id"`
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(bundleLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to modify bundles at this location", err)
+		return
+	}
+
+	t, ok := lookupItemType(requestBody.ItemType)
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "Invalid item type", fmt.Errorf("invalid item type: %s", requestBody.ItemType))
+		return
+	}
+
+	// Items can only be bundled with other items at the same location.
+	itemLocationID, err := t.itemLocation(r.Context(), cfg.db, requestBody.ItemID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Unable to get item location", err)
+		return
+	}
+	if itemLocationID != bundleLocation.ID {
+		respondWithError(w, http.StatusBadRequest, "Item is not at the same location as the bundle", nil)
+		return
+	}
+
+	// Adding an item to a bundle also places it at the end of the bundle's shelf.
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	bundle, ok := cfg.lockBundle(w, r, qtx, bundleID)
+	if !ok {
+		return
+	}
+
+	// An item in another bundle has to be removed from it first, so that it isn't taken out
+	// of a bundle by mistake.
+	added, err := t.itemAddToBundle(r.Context(), qtx, requestBody.ItemID, bundle.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to add item to bundle", err)
+		return
+	}
+	if !added {
+		respondWithError(w, http.StatusConflict, "Item is already in another bundle", nil)
+		return
+	}
+
+	change, moved, err := moveBundleItem(r.Context(), qtx, t, requestBody.ItemID, bundle.ShelfID, bundleLocation.ID, bundleLocation.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to move item to the bundle's shelf", err)
+		return
+	}
+	if !moved {
+		change, err = updatedItemChange(r.Context(), qtx, t, requestBody.ItemID, bundleLocation.ID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to get item", err)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to add item to bundle", err)
+		return
+	}
+
+	cfg.publishItemChange(r.Context(), change)
+
+	bundleContents, err := cfg.getBundleContents(r.Context(), cfg.db, bundle)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+		return
+	}
+
+	respondWithETag(w, r, bundleContents.entityTag(), bundleContents)
+}
+
+func (cfg *apiConfig) handlerBundleRemoveItem(w http.ResponseWriter, r *http.Request) {
+	bundleID, err := uuid.Parse(r.PathValue("bundle_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid bundle ID", err)
+		return
+	}
+
+	t, ok := lookupItemType(r.PathValue("item_type"))
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "Invalid item type", fmt.Errorf("invalid item type: %s", r.PathValue("item_type")))
+		return
+	}
+
+	itemID, err := uuid.Parse(r.PathValue("item_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+
+	bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(bundleLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to modify bundles at this location", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	if _, ok := cfg.lockBundle(w, r, qtx, bundleID); !ok {
+		return
+	}
+
+	removed, err := t.itemRemoveFromBundle(r.Context(), qtx, itemID, bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to remove item from bundle", err)
+		return
+	}
+	if !removed {
+		respondWithError(w, http.StatusNotFound, "Item is not in the bundle", nil)
+		return
+	}
+
+	change, err := updatedItemChange(r.Context(), qtx, t, itemID, bundleLocation.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get item", err)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to remove item from bundle", err)
+		return
+	}
+
+	cfg.publishItemChange(r.Context(), change)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (cfg *apiConfig) handlerBundleDelete(w http.ResponseWriter, r *http.Request) {
+	bundleID, err := uuid.Parse(r.PathValue("bundle_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid bundle ID", err)
+		return
+	}
+
+	bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(bundleLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to delete bundles at this location", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	if _, ok := cfg.lockBundle(w, r, qtx, bundleID); !ok {
+		return
+	}
+
+	// Items in the bundle are kept, they just no longer belong to a bundle. They're taken out
+	// of it one at a time, so that each is marked as updated.
+	changes := []itemChange{}
+	for _, t := range itemTypes {
+		ids, err := t.itemBundleIDs(r.Context(), qtx, bundleID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to get bundle %s items", t.itemName()), err)
+			return
+		}
+
+		for _, id := range ids {
+			_, err := t.itemRemoveFromBundle(r.Context(), qtx, id, bundleID)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, "Unable to remove item from bundle", err)
+				return
+			}
+			change, err := updatedItemChange(r.Context(), qtx, t, id, bundleLocation.ID)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, "Unable to get item", err)
+				return
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	err = qtx.DeleteBundle(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete bundle", err)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete bundle", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockBundle locks a bundle until the transaction db belongs to ends, and checks that it hasn't
+// changed since the client fetched it, responding with an error if it has or it can't be found.
+func (cfg *apiConfig) lockBundle(w http.ResponseWriter, r *http.Request, db *database.Queries, bundleID uuid.UUID) (database.Bundle, bool) {
+	bundle, err := db.LockBundle(r.Context(), bundleID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Bundle not found", err)
+		return database.Bundle{}, false
+	}
+
+	bundleContents, err := cfg.getBundleContents(r.Context(), db, bundle)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+		return database.Bundle{}, false
+	}
+
+	if !checkIfMatchETag(w, r, bundleContents.entityTag(), "bundle") {
+		return database.Bundle{}, false
+	}
+
+	return bundle, true
+}
+
+// moveBundleItems moves every item of a bundle to shelfID, returning the changes to publish.
+// Each item is moved to the end of the shelf, so it doesn't share a position with the items
+// already there.
+func moveBundleItems(ctx context.Context, db *database.Queries, bundleID, shelfID, fromLocationID, locationID uuid.UUID) ([]itemChange, error) {
+	changes := []itemChange{}
+	for _, t := range itemTypes {
+		ids, err := t.itemBundleIDs(ctx, db, bundleID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get bundle %s items: %w", t.itemName(), err)
+		}
+
+		for _, id := range ids {
+			change, moved, err := moveBundleItem(ctx, db, t, id, shelfID, fromLocationID, locationID)
+			if err != nil {
+				return nil, fmt.Errorf("unable to move bundle %s items: %w", t.itemName(), err)
+			}
+			if moved {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// moveBundleItem moves an item of a bundle to the end of shelfID, returning the change to
+// publish. moved is false if the item was already on the shelf.
+func moveBundleItem(ctx context.Context, db *database.Queries, t registeredItemType, id, shelfID, fromLocationID, locationID uuid.UUID) (change itemChange, moved bool, err error) {
+	fromShelfID, err := t.itemShelf(ctx, db, id)
+	if err != nil {
+		return itemChange{}, false, err
+	}
+	if fromShelfID == shelfID {
+		return itemChange{}, false, nil
+	}
+
+	err = t.itemMove(ctx, db, id, shelfID)
+	if err != nil {
+		return itemChange{}, false, err
+	}
+
+	event, err := t.itemEvent(ctx, db, id)
+	if err != nil {
+		return itemChange{}, false, err
+	}
+	event.FromShelfID = &fromShelfID
+
+	return itemChange{
+		id:             id,
+		eventType:      webhooks.ItemMoved,
+		event:          event,
+		locationID:     locationID,
+		fromLocationID: fromLocationID,
+	}, true, nil
+}
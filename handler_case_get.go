@@ -17,13 +17,7 @@ func (cfg *apiConfig) handlerCaseGet(w http.ResponseWriter, r *http.Request) {
 	itemCases := []Case{}
 
 	for _, dbCase := range dbCases {
-		itemCases = append(itemCases, Case{
-			ID:         dbCase.ID,
-			Name:       dbCase.Name,
-			LocationID: dbCase.LocationID,
-			CreatedAt:  dbCase.CreatedAt,
-			UpdatedAt:  dbCase.UpdatedAt,
-		})
+		itemCases = append(itemCases, caseFromDB(dbCase))
 	}
 
 	respondWithJSON(w, http.StatusOK, itemCases)
@@ -56,13 +50,7 @@ func (cfg *apiConfig) handlerCasesGetByLocation(w http.ResponseWriter, r *http.R
 	itemCases := []Case{}
 
 	for _, dbCase := range dbCases {
-		itemCases = append(itemCases, Case{
-			ID:         dbCase.ID,
-			Name:       dbCase.Name,
-			LocationID: dbCase.LocationID,
-			CreatedAt:  dbCase.CreatedAt,
-			UpdatedAt:  dbCase.UpdatedAt,
-		})
+		itemCases = append(itemCases, caseFromDB(dbCase))
 	}
 
 	respondWithJSON(w, http.StatusOK, itemCases)
@@ -87,11 +75,5 @@ func (cfg *apiConfig) handlerCaseGetByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, Case{
-		ID:         dbCase.ID,
-		Name:       dbCase.Name,
-		LocationID: dbCase.LocationID,
-		CreatedAt:  dbCase.CreatedAt,
-		UpdatedAt:  dbCase.UpdatedAt,
-	})
+	respondWithEntity(w, r, dbCase.UpdatedAt, caseFromDB(dbCase))
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
 	"github.com/google/uuid"
 )
 
@@ -13,10 +14,22 @@ type Case struct {
 	ID         uuid.UUID `json:"id"`
 	Name       string    `json:"name"`
 	LocationID uuid.UUID `json:"location_id"`
+	Barcode    string    `json:"barcode"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+func caseFromDB(dbCase database.Case) Case {
+	return Case{
+		ID:         dbCase.ID,
+		Name:       dbCase.Name,
+		LocationID: dbCase.LocationID,
+		Barcode:    dbCase.Barcode,
+		CreatedAt:  dbCase.CreatedAt,
+		UpdatedAt:  dbCase.UpdatedAt,
+	}
+}
+
 func (cfg *apiConfig) handlerCasesCreate(w http.ResponseWriter, r *http.Request) {
 	type parameters struct {
 		Name       string    `json:"name"`
@@ -59,13 +72,9 @@ func (cfg *apiConfig) handlerCasesCreate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	cfg.publishEvent(r.Context(), item_case.LocationID, webhooks.CaseCreated, caseFromDB(item_case))
+
 	respondWithJSON(w, http.StatusCreated, response{
-		Case: Case{
-			ID:         item_case.ID,
-			Name:       item_case.Name,
-			LocationID: item_case.LocationID,
-			CreatedAt:  item_case.CreatedAt,
-			UpdatedAt:  item_case.UpdatedAt,
-		},
+		Case: caseFromDB(item_case),
 	})
 }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// isUniqueViolation reports whether an insert or update failed because of a unique index,
+// such as a barcode that's already assigned to another case or shelf.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// handlerCaseUpdate changes a case's name and barcode. Only the fields that are sent are changed.
+// If-Match is checked against the case's ETag, so that a client doesn't overwrite a change it
+// hasn't seen.
+func (cfg *apiConfig) handlerCaseUpdate(w http.ResponseWriter, r *http.Request) {
+	caseIDString := r.PathValue("case_id")
+	if caseIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No case id was provided", fmt.Errorf("no case id was provided"))
+		return
+	}
+
+	caseID, err := uuid.Parse(caseIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid case ID", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	updatedAt, err := qtx.LockCase(r.Context(), caseID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Case not found", err)
+		return
+	}
+
+	dbCase, err := qtx.GetCaseByID(r.Context(), caseID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Case not found", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(dbCase.LocationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to modify this case", err)
+		return
+	}
+
+	if !checkIfMatch(w, r, updatedAt, "case") {
+		return
+	}
+
+	params := struct {
+		Name    string `json:"name"`
+		Barcode string `json:"barcode"`
+	}{
+		Name:    dbCase.Name,
+		Barcode: dbCase.Barcode,
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Name) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Case name is required", nil)
+		return
+	}
+
+	if len(params.Barcode) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Case barcode can't be empty", nil)
+		return
+	}
+
+	dbCase, err = qtx.UpdateCase(r.Context(), database.UpdateCaseParams{
+		ID:      caseID,
+		Name:    params.Name,
+		Barcode: params.Barcode,
+	})
+	if isUniqueViolation(err) {
+		respondWithError(w, http.StatusConflict, "That barcode is already used by another case", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to update case", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	cfg.publishEvent(r.Context(), dbCase.LocationID, webhooks.CaseUpdated, caseFromDB(dbCase))
+
+	respondWithEntity(w, r, dbCase.UpdatedAt, caseFromDB(dbCase))
+}
+
+// updateCase applies an offline edit to a case, like handlerCaseUpdate. If the case has been
+// updated since baseUpdatedAt, nothing is changed and errChangeConflict is returned along with
+// the case as it is now.
+func updateCase(ctx context.Context, db *database.Queries, caseID uuid.UUID, baseUpdatedAt *time.Time, body json.RawMessage, allowLocation func(uuid.UUID) error) (Case, error) {
+	updatedAt, err := db.LockCase(ctx, caseID)
+	if err != nil {
+		return Case{}, err
+	}
+
+	dbCase, err := db.GetCaseByID(ctx, caseID)
+	if err != nil {
+		return Case{}, err
+	}
+	if err := allowLocation(dbCase.LocationID); err != nil {
+		return Case{}, err
+	}
+	if baseUpdatedAt != nil && !updatedAt.Equal(*baseUpdatedAt) {
+		return caseFromDB(dbCase), errChangeConflict
+	}
+
+	params := struct {
+		Name    string `json:"name"`
+		Barcode string `json:"barcode"`
+	}{
+		Name:    dbCase.Name,
+		Barcode: dbCase.Barcode,
+	}
+	if err := json.Unmarshal(body, &params); err != nil {
+		return Case{}, invalidChange("Invalid case: %v", err)
+	}
+
+	if len(params.Name) == 0 {
+		return Case{}, invalidChange("Case name is required")
+	}
+	if len(params.Barcode) == 0 {
+		return Case{}, invalidChange("Case barcode can't be empty")
+	}
+
+	dbCase, err = db.UpdateCase(ctx, database.UpdateCaseParams{
+		ID:      caseID,
+		Name:    params.Name,
+		Barcode: params.Barcode,
+	})
+	if isUniqueViolation(err) {
+		return Case{}, invalidChange("That barcode is already used by another case")
+	}
+	if err != nil {
+		return Case{}, err
+	}
+	return caseFromDB(dbCase), nil
+}
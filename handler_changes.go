@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultChangesLimit = 200
+	maxChangesLimit     = 1000
+	// maxOfflineChanges is how many offline changes can be sent at once.
+	maxOfflineChanges = 500
+)
+
+// Change is the latest change to an entity since a sync. Entity is the entity as it is now.
+// It's left out of "delete" changes, which are tombstones for entities that were deleted or
+// moved to another location.
+type Change struct {
+	Cursor     int64     `json:"cursor"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Operation  string    `json:"operation"`
+	ChangedAt  time.Time `json:"changed_at"`
+	Entity     any       `json:"entity,omitempty"`
+}
+
+// ChangesResponse is a page of changes. Cursor is the since value for the next page.
+type ChangesResponse struct {
+	Cursor  int64    `json:"cursor"`
+	HasMore bool     `json:"has_more"`
+	Changes []Change `json:"changes"`
+}
+
+// OfflineChange is an edit made while a client was offline. BaseUpdatedAt is the updated_at
+// of the entity the edit was made to, and is required to update or delete.
+type OfflineChange struct {
+	Ref           string          `json:"ref"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      uuid.UUID       `json:"entity_id"`
+	Operation     string          `json:"operation"`
+	BaseUpdatedAt *time.Time      `json:"base_updated_at"`
+	Entity        json.RawMessage `json:"entity"`
+}
+
+// OfflineChangeResult is how an offline change went. Status is applied, conflict, rejected or
+// failed. Entity is the entity as it is now, which is null once it's deleted.
+type OfflineChangeResult struct {
+	Ref        string    `json:"ref,omitempty"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	Operation  string    `json:"operation"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Entity     any       `json:"entity"`
+}
+
+// OfflineChangesResponse reports how each of the offline changes went, in the order they were sent.
+type OfflineChangesResponse struct {
+	Applied   int                   `json:"applied"`
+	Conflicts int                   `json:"conflicts"`
+	Rejected  int                   `json:"rejected"`
+	Failed    int                   `json:"failed"`
+	Results   []OfflineChangeResult `json:"results"`
+}
+
+// parseChangesLocation reads the location's ID from the path and checks the requester is a member.
+func (cfg *apiConfig) parseChangesLocation(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return uuid.Nil, false
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return uuid.Nil, false
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to sync this location", err)
+		return uuid.Nil, false
+	}
+
+	return locationID, true
+}
+
+// handlerLocationChanges lists the latest change to each of a location's cases, shelves and
+// items since a cursor, oldest first. A client syncs by passing the cursor of the last page it
+// fetched as since, or 0 to fetch everything.
+func (cfg *apiConfig) handlerLocationChanges(w http.ResponseWriter, r *http.Request) {
+	locationID, ok := cfg.parseChangesLocation(w, r)
+	if !ok {
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceString, 10, 64)
+		if err != nil || since < 0 {
+			respondWithError(w, http.StatusBadRequest, "since must be a cursor from an earlier sync", err)
+			return
+		}
+	}
+
+	limit := defaultChangesLimit
+	if limitString := r.URL.Query().Get("limit"); limitString != "" {
+		var err error
+		limit, err = strconv.Atoi(limitString)
+		if err != nil || limit < 1 || limit > maxChangesLimit {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxChangesLimit), err)
+			return
+		}
+	}
+
+	err := cfg.sequenceLocationChanges(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get changes", err)
+		return
+	}
+
+	// One extra change is fetched to tell whether there's another page.
+	dbChanges, err := cfg.db.GetLocationChanges(r.Context(), database.GetLocationChangesParams{
+		LocationID: locationID,
+		Since:      since,
+		RowLimit:   int32(limit + 1),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get changes", err)
+		return
+	}
+
+	response := ChangesResponse{
+		Cursor:  since,
+		HasMore: len(dbChanges) > limit,
+		Changes: []Change{},
+	}
+	if response.HasMore {
+		dbChanges = dbChanges[:limit]
+	}
+
+	for _, dbChange := range dbChanges {
+		change := Change{
+			Cursor:     dbChange.Seq.Int64,
+			EntityType: dbChange.EntityType,
+			EntityID:   dbChange.EntityID,
+			Operation:  dbChange.Operation,
+			ChangedAt:  dbChange.ChangedAt,
+		}
+
+		if change.Operation == "upsert" {
+			entity, found, err := cfg.syncEntity(r.Context(), locationID, change.EntityType, change.EntityID)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to get %s", change.EntityType), err)
+				return
+			}
+			// An entity that's been deleted or moved away since is sent as a tombstone. Its
+			// own change comes in a later page.
+			if found {
+				change.Entity = entity
+			} else {
+				change.Operation = "delete"
+			}
+		}
+
+		response.Changes = append(response.Changes, change)
+		response.Cursor = change.Cursor
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// sequenceLocationChanges gives cursors to the changes that are ready for syncing. It runs in
+// its own transaction, one at a time, so cursors become visible in the order they're given out.
+func (cfg *apiConfig) sequenceLocationChanges(ctx context.Context) error {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	err = qtx.LockLocationChangeSequencer(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = qtx.SequenceLocationChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// syncEntity gets an entity as it's returned by its own routes. found is false if it no longer
+// exists at the location.
+func (cfg *apiConfig) syncEntity(ctx context.Context, locationID uuid.UUID, entityType string, id uuid.UUID) (entity any, found bool, err error) {
+	switch entityType {
+	case "case":
+		dbCase, err := cfg.db.GetCaseByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return caseFromDB(dbCase), dbCase.LocationID == locationID, nil
+	case "shelf":
+		dbShelf, err := cfg.db.GetShelfByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return shelfFromDB(dbShelf), true, nil
+	}
+
+	t, ok := lookupItemType(entityType)
+	if !ok {
+		return nil, false, fmt.Errorf("unknown entity type: %s", entityType)
+	}
+
+	itemLocationID, err := t.itemLocation(ctx, cfg.db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if itemLocationID != locationID {
+		return nil, false, nil
+	}
+
+	event, err := t.itemEvent(ctx, cfg.db, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return event.Item, true, nil
+}
+
+// handlerLocationApplyChanges applies edits a client queued while it was offline. Each change
+// is applied on its own, in the order sent, and reported in the results. An update or delete
+// of an entity that's been changed since the client synced it isn't applied; it's reported as
+// a conflict along with the entity as it is now, for the client to resolve.
+func (cfg *apiConfig) handlerLocationApplyChanges(w http.ResponseWriter, r *http.Request) {
+	locationID, ok := cfg.parseChangesLocation(w, r)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		Changes []OfflineChange `json:"changes"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Changes) > maxOfflineChanges {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("No more than %d changes can be sent at once", maxOfflineChanges), nil)
+		return
+	}
+
+	response := OfflineChangesResponse{Results: []OfflineChangeResult{}}
+	for _, change := range params.Changes {
+		result := cfg.applyOfflineChange(r.Context(), locationID, change)
+		switch result.Status {
+		case "applied":
+			response.Applied++
+		case "conflict":
+			response.Conflicts++
+		case "rejected":
+			response.Rejected++
+		default:
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// applyOfflineChange applies one offline change in its own transaction.
+func (cfg *apiConfig) applyOfflineChange(ctx context.Context, locationID uuid.UUID, change OfflineChange) OfflineChangeResult {
+	result := OfflineChangeResult{
+		Ref:        change.Ref,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Operation:  change.Operation,
+	}
+
+	// Offline changes can only be made to the location being synced.
+	allowLocation := func(id uuid.UUID) error {
+		if id != locationID {
+			return invalidChange("Changes can only be made to entities at this location")
+		}
+		return nil
+	}
+
+	reject := func(message string) OfflineChangeResult {
+		result.Status = "rejected"
+		result.Error = message
+		return result
+	}
+
+	if change.Operation != "create" && change.Operation != "update" && change.Operation != "delete" {
+		return reject("operation must be create, update or delete")
+	}
+	if change.Operation != "create" && change.BaseUpdatedAt == nil {
+		return reject("base_updated_at is required to update or delete")
+	}
+
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Unable to start transaction for offline change: %v", err)
+		result.Status = "failed"
+		result.Error = "Unable to start transaction"
+		return result
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	// A created item's ID is only known once it's saved.
+	entityID := change.EntityID
+	var entity any
+	var publish func()
+	switch change.EntityType {
+	case "case", "shelf":
+		if change.Operation != "update" {
+			return reject(fmt.Sprintf("A %s can only be updated offline", change.EntityType))
+		}
+		var eventType string
+		if change.EntityType == "case" {
+			eventType = webhooks.CaseUpdated
+			entity, err = updateCase(ctx, qtx, change.EntityID, change.BaseUpdatedAt, change.Entity, allowLocation)
+		} else {
+			eventType = webhooks.ShelfUpdated
+			entity, err = updateShelf(ctx, qtx, change.EntityID, change.BaseUpdatedAt, change.Entity, allowLocation)
+		}
+		publish = func() {
+			cfg.publishEvent(ctx, locationID, eventType, entity)
+		}
+	default:
+		t, ok := lookupItemType(change.EntityType)
+		if !ok {
+			return reject(fmt.Sprintf("Unknown entity type: %s", change.EntityType))
+		}
+
+		var itemResult itemChange
+		switch change.Operation {
+		case "create":
+			itemResult, err = t.itemCreate(ctx, cfg, qtx, change.Entity, allowLocation)
+		case "update":
+			itemResult, err = t.itemUpdate(ctx, cfg, qtx, change.EntityID, change.BaseUpdatedAt, change.Entity, allowLocation)
+		case "delete":
+			itemResult, err = t.itemDelete(ctx, qtx, change.EntityID, change.BaseUpdatedAt, allowLocation)
+		}
+		if change.Operation == "create" {
+			entityID = itemResult.id
+		}
+		entity = itemResult.event.Item
+		if change.Operation == "delete" && err == nil {
+			entity = nil
+		}
+		publish = func() {
+			cfg.publishItemChange(ctx, itemResult)
+		}
+	}
+
+	var invalid *invalidChangeError
+	switch {
+	case errors.As(err, &invalid):
+		return reject(invalid.message)
+	case errors.Is(err, sql.ErrNoRows) && change.Operation == "delete":
+		// It's already gone, which is what the client wanted.
+		result.Status = "applied"
+		return result
+	case errors.Is(err, sql.ErrNoRows):
+		// It was deleted on the server, so there's nothing to update.
+		result.Status = "conflict"
+		result.Error = fmt.Sprintf("The %s has been deleted", change.EntityType)
+		return result
+	case errors.Is(err, errChangeConflict):
+		result.Status = "conflict"
+		result.Error = fmt.Sprintf("The %s has been changed since it was synced", change.EntityType)
+		result.Entity = entity
+		return result
+	case err != nil:
+		log.Printf("Unable to %s %s %s: %v", change.Operation, change.EntityType, change.EntityID, err)
+		result.Status = "failed"
+		result.Error = fmt.Sprintf("Unable to %s %s", change.Operation, change.EntityType)
+		return result
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Unable to commit offline change: %v", err)
+		result.Status = "failed"
+		result.Error = "Unable to commit transaction"
+		return result
+	}
+
+	publish()
+
+	result.Status = "applied"
+	result.EntityID = entityID
+	result.Entity = entity
+	return result
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+type CustomField struct {
+	ID         uuid.UUID `json:"id"`
+	LocationID uuid.UUID `json:"location_id"`
+	ItemType   string    `json:"item_type"`
+	Name       string    `json:"name"`
+	FieldType  string    `json:"field_type"`
+	Options    []string  `json:"options"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func customFieldFromDB(dbField database.CustomField) CustomField {
+	return CustomField{
+		ID:         dbField.ID,
+		LocationID: dbField.LocationID,
+		ItemType:   dbField.ItemType,
+		Name:       dbField.Name,
+		FieldType:  dbField.FieldType,
+		Options:    dbField.Options,
+		CreatedAt:  dbField.CreatedAt,
+		UpdatedAt:  dbField.UpdatedAt,
+	}
+}
+
+// getCustomFieldDefinitions returns the custom fields a location defines for an item type.
+func (cfg *apiConfig) getCustomFieldDefinitions(ctx context.Context, locationID uuid.UUID, itemType string) ([]customfields.Field, error) {
+	dbFields, err := cfg.db.GetCustomFieldsByLocationAndItemType(ctx, database.GetCustomFieldsByLocationAndItemTypeParams{
+		LocationID: locationID,
+		ItemType:   itemType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []customfields.Field{}
+	for _, dbField := range dbFields {
+		fields = append(fields, customfields.Field{
+			Name:    dbField.Name,
+			Type:    customfields.Type(dbField.FieldType),
+			Options: dbField.Options,
+		})
+	}
+	return fields, nil
+}
+
+// customFieldsFromDB decodes the custom field values stored on an item. Values are only
+// ever written after validation, so a value that fails to decode is treated as empty.
+func customFieldsFromDB(raw json.RawMessage) customfields.Values {
+	values := customfields.Values{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return customfields.Values{}
+	}
+	return values
+}
+
+func (cfg *apiConfig) handlerCustomFieldCreate(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		ItemType  string   `json:"item_type"`
+		Name      string   `json:"name"`
+		FieldType string   `json:"field_type"`
+		Options   []string `json:"options"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := parameters{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate that the user is permitted to define custom fields for this location.
+	err = cfg.authorizeOwner(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to add custom fields to this location", err)
+		return
+	}
+
+	if _, ok := lookupItemType(params.ItemType); !ok {
+		respondWithError(w, http.StatusBadRequest, "Invalid item type", fmt.Errorf("invalid item type: %s", params.ItemType))
+		return
+	}
+
+	if len(params.Name) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Custom field name is required", nil)
+		return
+	}
+
+	if !customfields.ValidType(customfields.Type(params.FieldType)) {
+		respondWithError(w, http.StatusBadRequest, "field_type must be text, number, bool, date or enum", fmt.Errorf("invalid field type: %s", params.FieldType))
+		return
+	}
+
+	if params.FieldType == string(customfields.TypeEnum) && len(params.Options) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Enum fields require options", nil)
+		return
+	}
+	if params.Options == nil || params.FieldType != string(customfields.TypeEnum) {
+		params.Options = []string{}
+	}
+
+	dbField, err := cfg.db.CreateCustomField(r.Context(), database.CreateCustomFieldParams{
+		LocationID: locationID,
+		ItemType:   params.ItemType,
+		Name:       params.Name,
+		FieldType:  params.FieldType,
+		Options:    params.Options,
+	})
+	if isUniqueViolation(err) {
+		respondWithError(w, http.StatusConflict, "There's already a custom field with that name for this item type", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create custom field", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, customFieldFromDB(dbField))
+}
+
+func (cfg *apiConfig) handlerCustomFieldsGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get custom fields for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get custom fields for this location", err)
+		return
+	}
+
+	var dbFields []database.CustomField
+	if itemType := r.URL.Query().Get("item_type"); itemType != "" {
+		dbFields, err = cfg.db.GetCustomFieldsByLocationAndItemType(r.Context(), database.GetCustomFieldsByLocationAndItemTypeParams{
+			LocationID: locationID,
+			ItemType:   itemType,
+		})
+	} else {
+		dbFields, err = cfg.db.GetCustomFieldsByLocation(r.Context(), locationID)
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get custom fields", err)
+		return
+	}
+
+	fields := []CustomField{}
+	for _, dbField := range dbFields {
+		fields = append(fields, customFieldFromDB(dbField))
+	}
+
+	respondWithJSON(w, http.StatusOK, fields)
+}
+
+// handlerCustomFieldDelete removes a field definition. Values already stored on items are
+// dropped the next time each item is updated.
+func (cfg *apiConfig) handlerCustomFieldDelete(w http.ResponseWriter, r *http.Request) {
+	locationID, err := uuid.Parse(r.PathValue("location_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	fieldID, err := uuid.Parse(r.PathValue("field_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid custom field ID", err)
+		return
+	}
+
+	err = cfg.authorizeOwner(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to remove custom fields from this location", err)
+		return
+	}
+
+	dbField, err := cfg.db.GetCustomFieldByID(r.Context(), fieldID)
+	if err != nil || dbField.LocationID != locationID {
+		respondWithError(w, http.StatusNotFound, "Custom field not found", err)
+		return
+	}
+
+	// Field definitions are never updated, so the field doesn't need to be locked.
+	if !checkIfMatch(w, r, dbField.UpdatedAt, "custom field") {
+		return
+	}
+
+	err = cfg.db.DeleteCustomField(r.Context(), fieldID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete custom field", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+func (cfg *apiConfig) handlerEpisodeMarkWatched(w http.ResponseWriter, r *http.Request) {
+	cfg.setEpisodeWatched(w, r, true)
+}
+
+func (cfg *apiConfig) handlerEpisodeUnmarkWatched(w http.ResponseWriter, r *http.Request) {
+	cfg.setEpisodeWatched(w, r, false)
+}
+
+// setEpisodeWatched records or clears the requester's watch of an episode. Watches are per user.
+func (cfg *apiConfig) setEpisodeWatched(w http.ResponseWriter, r *http.Request, watched bool) {
+	episodeID, err := uuid.Parse(r.PathValue("episode_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid episode ID", err)
+		return
+	}
+
+	episodeLocation, err := cfg.db.GetEpisodeLocation(r.Context(), episodeID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Episode not found", err)
+		return
+	}
+
+	err = cfg.authorizeMember(episodeLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to watch episodes at this location", err)
+		return
+	}
+
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	if watched {
+		err = cfg.db.MarkEpisodeWatched(r.Context(), database.MarkEpisodeWatchedParams{
+			UserID:    requesterID,
+			EpisodeID: episodeID,
+		})
+	} else {
+		err = cfg.db.UnmarkEpisodeWatched(r.Context(), database.UnmarkEpisodeWatchedParams{
+			UserID:    requesterID,
+			EpisodeID: episodeID,
+		})
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to update watched episode", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
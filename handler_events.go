@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/events"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+// eventHeartbeatInterval is how often an idle event stream sends a comment, so that proxies
+// don't close it.
+const eventHeartbeatInterval = 30 * time.Second
+
+// handlerLocationEvents streams a location's events to a member as Server-Sent Events. Each
+// event's name is its type and its data is the same JSON that webhooks are sent.
+func (cfg *apiConfig) handlerLocationEvents(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to watch this location", err)
+		return
+	}
+
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	cfg.streamEvents(w, r, locationID, requesterID, func(msg events.Message) (events.Message, error) {
+		return msg, nil
+	})
+}
+
+// appLocationEvents streams a location's events to the web app, as a line of HTML for the
+// location's activity list. Every event is sent as an "activity" event.
+func (cfg *apiConfig) appLocationEvents(w http.ResponseWriter, r *http.Request) {
+	locationID, err := uuid.Parse(r.PathValue("location_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	userID := cfg.getRequestUserID(r)
+	dbUserLocations, err := cfg.db.GetUserLocations(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get locations for user", err)
+		return
+	}
+	member := false
+	for _, userLocation := range dbUserLocations {
+		member = member || userLocation.ID == locationID
+	}
+	if !member {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to watch this location", nil)
+		return
+	}
+
+	cfg.streamEvents(w, r, locationID, userID, func(msg events.Message) (events.Message, error) {
+		summary, err := eventSummary(msg)
+		if err != nil {
+			return events.Message{}, err
+		}
+
+		var buf bytes.Buffer
+		err = LocationActivity(summary).Render(r.Context(), &buf)
+		if err != nil {
+			return events.Message{}, err
+		}
+
+		return events.Message{ID: msg.ID, Type: "activity", Data: buf.Bytes()}, nil
+	})
+}
+
+// streamEvents sends the location's events to the client until it disconnects, formatting
+// each one with format. The stream ends if the user is removed from the location.
+func (cfg *apiConfig) streamEvents(w http.ResponseWriter, r *http.Request, locationID, userID uuid.UUID, format func(events.Message) (events.Message, error)) {
+	rc := http.NewResponseController(w)
+
+	// Streams stay open for much longer than the server's write timeout.
+	err := rc.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start event stream", err)
+		return
+	}
+
+	messages, unsubscribe := cfg.broker.Subscribe(locationID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, ": connected\n\n")
+	if err := rc.Flush(); err != nil {
+		log.Printf("Unable to flush event stream: %v", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			out, formatErr := format(msg)
+			if formatErr != nil {
+				log.Printf("Unable to format %s event: %v", msg.Type, formatErr)
+				continue
+			}
+			err = events.WriteSSE(w, out)
+
+			if err == nil && removesMember(msg, userID) {
+				rc.Flush()
+				return
+			}
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// removesMember reports whether the event removes the user from its location.
+func removesMember(msg events.Message, userID uuid.UUID) bool {
+	if msg.Type != webhooks.MemberRemoved {
+		return false
+	}
+
+	var event struct {
+		Data MemberEvent `json:"data"`
+	}
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		return false
+	}
+	return event.Data.UserID == userID
+}
+
+// eventSummary describes an event in a few words, such as "Dune (book) was moved".
+func eventSummary(msg events.Message) (string, error) {
+	var event struct {
+		Data struct {
+			ItemType string `json:"item_type"`
+			Item     struct {
+				Title string `json:"title"`
+			} `json:"item"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		return "", err
+	}
+
+	kind, action, _ := strings.Cut(msg.Type, ".")
+	switch kind {
+	case "item":
+		if event.Data.Item.Title == "" {
+			return fmt.Sprintf("A %s was %s", event.Data.ItemType, action), nil
+		}
+		return fmt.Sprintf("%s (%s) was %s", event.Data.Item.Title, event.Data.ItemType, action), nil
+	case "case", "shelf":
+		if event.Data.Name == "" {
+			return fmt.Sprintf("A %s was %s", kind, action), nil
+		}
+		return fmt.Sprintf("%s %s was %s", strings.ToUpper(kind[:1])+kind[1:], event.Data.Name, action), nil
+	case "member":
+		return fmt.Sprintf("A member was %s", action), nil
+	default:
+		return msg.Type, nil
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+// Games are video games and board games. Board games usually leave platform empty.
+type Game struct {
+	ID              uuid.UUID           `json:"id"`
+	Title           string              `json:"title"`
+	GameType        string              `json:"game_type"`
+	Platform        string              `json:"platform"`
+	Publisher       string              `json:"publisher"`
+	Developer       string              `json:"developer"`
+	Genre           string              `json:"genre"`
+	MinPlayers      int32               `json:"min_players"`
+	MaxPlayers      int32               `json:"max_players"`
+	PlayTimeMinutes int32               `json:"play_time_minutes"`
+	Edition         string              `json:"edition"`
+	Barcode         string              `json:"barcode"`
+	ShelfID         uuid.UUID           `json:"shelf_id"`
+	ReleaseDate     partialdate.Date    `json:"release_date"`
+	Position        int32               `json:"position"`
+	ThicknessCM     float64             `json:"thickness_cm"`
+	MissingSince    *time.Time          `json:"missing_since"`
+	CustomFields    customfields.Values `json:"custom_fields"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+type gameParams struct {
+	Title           string              `json:"title"`
+	GameType        string              `json:"game_type"`
+	Platform        string              `json:"platform"`
+	Publisher       string              `json:"publisher"`
+	Developer       string              `json:"developer"`
+	Genre           string              `json:"genre"`
+	MinPlayers      int32               `json:"min_players"`
+	MaxPlayers      int32               `json:"max_players"`
+	PlayTimeMinutes int32               `json:"play_time_minutes"`
+	Edition         string              `json:"edition"`
+	Barcode         string              `json:"barcode"`
+	ShelfID         uuid.UUID           `json:"shelf_id"`
+	ReleaseDate     partialdate.Date    `json:"release_date"`
+	ThicknessCM     float64             `json:"thickness_cm"`
+	CustomFields    customfields.Values `json:"custom_fields"`
+}
+
+var gameItems = &itemType[database.Game, Game, gameParams]{
+	name:   "game",
+	plural: "games",
+
+	toItem: func(dbGame database.Game) Game {
+		return Game{
+			ID:              dbGame.ID,
+			Title:           dbGame.Title,
+			GameType:        dbGame.GameType,
+			Platform:        dbGame.Platform,
+			Publisher:       dbGame.Publisher,
+			Developer:       dbGame.Developer,
+			Genre:           dbGame.Genre,
+			MinPlayers:      dbGame.MinPlayers,
+			MaxPlayers:      dbGame.MaxPlayers,
+			PlayTimeMinutes: dbGame.PlayTimeMinutes,
+			Edition:         dbGame.Edition,
+			Barcode:         dbGame.Barcode,
+			ShelfID:         dbGame.ShelfID,
+			ReleaseDate:     partialdate.FromNullTime(dbGame.ReleaseDate, dbGame.ReleaseDatePrecision),
+			Position:        dbGame.Position,
+			ThicknessCM:     dbGame.ThicknessCm,
+			MissingSince:    nullTimeToPointer(dbGame.MissingSince),
+			CustomFields:    customFieldsFromDB(dbGame.CustomFields),
+			CreatedAt:       dbGame.CreatedAt,
+			UpdatedAt:       dbGame.UpdatedAt,
+		}
+	},
+	toParams: func(dbGame database.Game) gameParams {
+		return gameParams{
+			Title:           dbGame.Title,
+			GameType:        dbGame.GameType,
+			Platform:        dbGame.Platform,
+			Publisher:       dbGame.Publisher,
+			Developer:       dbGame.Developer,
+			Genre:           dbGame.Genre,
+			MinPlayers:      dbGame.MinPlayers,
+			MaxPlayers:      dbGame.MaxPlayers,
+			PlayTimeMinutes: dbGame.PlayTimeMinutes,
+			Edition:         dbGame.Edition,
+			Barcode:         dbGame.Barcode,
+			ShelfID:         dbGame.ShelfID,
+			ReleaseDate:     partialdate.FromNullTime(dbGame.ReleaseDate, dbGame.ReleaseDatePrecision),
+			ThicknessCM:     dbGame.ThicknessCm,
+			CustomFields:    customFieldsFromDB(dbGame.CustomFields),
+		}
+	},
+	id:           func(game Game) uuid.UUID { return game.ID },
+	updatedAt:    func(game Game) time.Time { return game.UpdatedAt },
+	shelfID:      func(params gameParams) uuid.UUID { return params.ShelfID },
+	date:         func(game Game) partialdate.Date { return game.ReleaseDate },
+	customFields: func(params *gameParams) *customfields.Values { return &params.CustomFields },
+	validate: func(params *gameParams) error {
+		switch params.GameType {
+		case "":
+			params.GameType = "video"
+		case "video", "board":
+		default:
+			return fmt.Errorf("game_type must be video or board")
+		}
+		if params.MinPlayers == 0 {
+			params.MinPlayers = 1
+		}
+		if params.MaxPlayers < params.MinPlayers {
+			params.MaxPlayers = params.MinPlayers
+		}
+		return nil
+	},
+
+	create: func(db *database.Queries, ctx context.Context, params gameParams) (database.Game, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Game{}, err
+		}
+
+		return db.CreateGame(ctx, database.CreateGameParams{
+			Title:                params.Title,
+			GameType:             params.GameType,
+			Platform:             params.Platform,
+			Publisher:            params.Publisher,
+			Developer:            params.Developer,
+			Genre:                params.Genre,
+			MinPlayers:           params.MinPlayers,
+			MaxPlayers:           params.MaxPlayers,
+			PlayTimeMinutes:      params.PlayTimeMinutes,
+			Edition:              params.Edition,
+			Barcode:              params.Barcode,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	update: func(db *database.Queries, ctx context.Context, id uuid.UUID, params gameParams) (database.Game, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Game{}, err
+		}
+
+		return db.UpdateGame(ctx, database.UpdateGameParams{
+			ID:                   id,
+			Title:                params.Title,
+			GameType:             params.GameType,
+			Platform:             params.Platform,
+			Publisher:            params.Publisher,
+			Developer:            params.Developer,
+			Genre:                params.Genre,
+			MinPlayers:           params.MinPlayers,
+			MaxPlayers:           params.MaxPlayers,
+			PlayTimeMinutes:      params.PlayTimeMinutes,
+			Edition:              params.Edition,
+			Barcode:              params.Barcode,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	delete: (*database.Queries).DeleteGame,
+	lock:   (*database.Queries).LockGame,
+	setPosition: func(db *database.Queries, ctx context.Context, id uuid.UUID, position int32) error {
+		return db.SetGamePosition(ctx, database.SetGamePositionParams{
+			ID:       id,
+			Position: position,
+		})
+	},
+	move: func(db *database.Queries, ctx context.Context, id, shelfID uuid.UUID) error {
+		return db.MoveGame(ctx, database.MoveGameParams{
+			ID:      id,
+			ShelfID: shelfID,
+		})
+	},
+	setMissingSince: func(db *database.Queries, ctx context.Context, id uuid.UUID, missingSince sql.NullTime) error {
+		return db.SetGameMissingSince(ctx, database.SetGameMissingSinceParams{
+			ID:           id,
+			MissingSince: missingSince,
+		})
+	},
+	location: func(db *database.Queries, ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		location, err := db.GetGameLocation(ctx, id)
+		return location.ID, err
+	},
+	addToBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.AddGameToBundle(ctx, database.AddGameToBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	removeFromBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.RemoveGameFromBundle(ctx, database.RemoveGameFromBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	getByBundle: func(db *database.Queries, ctx context.Context, bundleID uuid.UUID) ([]database.Game, error) {
+		return db.GetGamesByBundle(ctx, uuid.NullUUID{UUID: bundleID, Valid: true})
+	},
+	getForUser: (*database.Queries).GetGamesForUser,
+	getByID:    (*database.Queries).GetGameByID,
+	getByBarcode: func(db *database.Queries, ctx context.Context, barcode string, userID uuid.UUID) ([]database.Game, error) {
+		return db.GetGamesByBarcodeForUser(ctx, database.GetGamesByBarcodeForUserParams{
+			Barcode: barcode,
+			UserID:  userID,
+		})
+	},
+	getByShelf: func(db *database.Queries, ctx context.Context, shelfID uuid.UUID, customFields json.RawMessage) ([]database.Game, error) {
+		return db.GetGamesByShelf(ctx, database.GetGamesByShelfParams{
+			ShelfID:      shelfID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocation: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, customFields json.RawMessage) ([]database.Game, error) {
+		return db.GetGamesByLocation(ctx, database.GetGamesByLocationParams{
+			LocationID:   locationID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocationAndDecade: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, decade int32, customFields json.RawMessage) ([]database.Game, error) {
+		return db.GetGamesByLocationAndDecade(ctx, database.GetGamesByLocationAndDecadeParams{
+			LocationID:   locationID,
+			Decade:       decade,
+			CustomFields: customFields,
+		})
+	},
+}
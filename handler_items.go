@@ -0,0 +1,697 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/Rodabaugh/digitalshelf/internal/search"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+// itemType describes a media type that is stored on shelves, such as movies or books.
+// Row is the sqlc model for the type's table, Item is the API representation of a row
+// and Params is the request body used to create and update items.
+//
+// The list queries take the custom field filter as a JSON object that each row's
+// custom_fields must contain; an empty object matches every row.
+//
+// The query functions take the queries as their first argument so that sqlc methods can
+// be used directly as method expressions, e.g. (*database.Queries).GetMovieByID.
+type itemType[Row, Item, Params any] struct {
+	name   string // Singular name, e.g. "movie". Used for the {name_id} path value.
+	plural string // Plural name, e.g. "movies". Used in routes.
+
+	toItem    func(Row) Item
+	toParams  func(Row) Params
+	id        func(Item) uuid.UUID
+	updatedAt func(Item) time.Time
+	shelfID   func(Params) uuid.UUID
+	date      func(Item) partialdate.Date
+	// customFields points at the custom field values in the params.
+	customFields func(*Params) *customfields.Values
+	// validate is optional. It may fill in defaults before the params are saved.
+	validate func(*Params) error
+
+	create                 func(*database.Queries, context.Context, Params) (Row, error)
+	update                 func(*database.Queries, context.Context, uuid.UUID, Params) (Row, error)
+	delete                 func(*database.Queries, context.Context, uuid.UUID) error
+	lock                   func(*database.Queries, context.Context, uuid.UUID) (time.Time, error)
+	setPosition            func(*database.Queries, context.Context, uuid.UUID, int32) error
+	move                   func(*database.Queries, context.Context, uuid.UUID, uuid.UUID) error
+	setMissingSince        func(*database.Queries, context.Context, uuid.UUID, sql.NullTime) error
+	location               func(*database.Queries, context.Context, uuid.UUID) (uuid.UUID, error)
+	addToBundle            func(*database.Queries, context.Context, uuid.UUID, uuid.UUID) (int64, error)
+	removeFromBundle       func(*database.Queries, context.Context, uuid.UUID, uuid.UUID) (int64, error)
+	getByBundle            func(*database.Queries, context.Context, uuid.UUID) ([]Row, error)
+	getForUser             func(*database.Queries, context.Context, uuid.UUID) ([]Row, error)
+	getByID                func(*database.Queries, context.Context, uuid.UUID) (Row, error)
+	getByBarcode           func(*database.Queries, context.Context, string, uuid.UUID) ([]Row, error)
+	getByShelf             func(*database.Queries, context.Context, uuid.UUID, json.RawMessage) ([]Row, error)
+	getByLocation          func(*database.Queries, context.Context, uuid.UUID, json.RawMessage) ([]Row, error)
+	getByLocationAndDecade func(*database.Queries, context.Context, uuid.UUID, int32, json.RawMessage) ([]Row, error)
+}
+
+// registeredItemType is the part of an itemType that doesn't depend on its type parameters,
+// so that every media type can be kept in one registry.
+type registeredItemType interface {
+	itemName() string
+	itemLocation(ctx context.Context, db *database.Queries, id uuid.UUID) (uuid.UUID, error)
+	itemSetPosition(ctx context.Context, db *database.Queries, id uuid.UUID, position int32) error
+	// itemMove moves an item to the end of another shelf. It does nothing if the item is already on the shelf.
+	itemMove(ctx context.Context, db *database.Queries, id, shelfID uuid.UUID) error
+	itemSetMissingSince(ctx context.Context, db *database.Queries, id uuid.UUID, missingSince sql.NullTime) error
+	// itemShelf returns the shelf an item is on.
+	itemShelf(ctx context.Context, db *database.Queries, id uuid.UUID) (uuid.UUID, error)
+	// itemAddToBundle adds an item to a bundle. It doesn't move the item to the bundle's shelf.
+	// added is false if the item is already in another bundle, which it's left in.
+	itemAddToBundle(ctx context.Context, db *database.Queries, id, bundleID uuid.UUID) (added bool, err error)
+	// itemRemoveFromBundle takes an item out of a bundle. removed is false if the item isn't in the bundle.
+	itemRemoveFromBundle(ctx context.Context, db *database.Queries, id, bundleID uuid.UUID) (removed bool, err error)
+	// itemBundleIDs returns the IDs of the items of the type that are in a bundle, in shelf order.
+	itemBundleIDs(ctx context.Context, db *database.Queries, bundleID uuid.UUID) ([]uuid.UUID, error)
+	// itemEvent returns the data of an event about the item, as it is now.
+	itemEvent(ctx context.Context, db *database.Queries, id uuid.UUID) (ItemEvent, error)
+	// itemCreate, itemUpdate and itemDelete change items outside of the item routes, such as
+	// for offline edits. See helper_item_changes.go.
+	itemCreate(ctx context.Context, cfg *apiConfig, db *database.Queries, body json.RawMessage, allowLocation func(uuid.UUID) error) (itemChange, error)
+	itemUpdate(ctx context.Context, cfg *apiConfig, db *database.Queries, id uuid.UUID, baseUpdatedAt *time.Time, body json.RawMessage, allowLocation func(uuid.UUID) error) (itemChange, error)
+	itemDelete(ctx context.Context, db *database.Queries, id uuid.UUID, baseUpdatedAt *time.Time, allowLocation func(uuid.UUID) error) (itemChange, error)
+	registerRoutes(mux *http.ServeMux, cfg *apiConfig)
+}
+
+// itemTypes is the registry of media types. Adding a type here registers its routes.
+var itemTypes = []registeredItemType{
+	movieItems,
+	showItems,
+	bookItems,
+	musicItems,
+	gameItems,
+}
+
+func lookupItemType(name string) (registeredItemType, bool) {
+	for _, t := range itemTypes {
+		if t.itemName() == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func registerItemRoutes(mux *http.ServeMux, cfg *apiConfig) {
+	for _, t := range itemTypes {
+		t.registerRoutes(mux, cfg)
+	}
+}
+
+func (t *itemType[Row, Item, Params]) itemName() string {
+	return t.name
+}
+
+func (t *itemType[Row, Item, Params]) itemLocation(ctx context.Context, db *database.Queries, id uuid.UUID) (uuid.UUID, error) {
+	return t.location(db, ctx, id)
+}
+
+func (t *itemType[Row, Item, Params]) itemSetPosition(ctx context.Context, db *database.Queries, id uuid.UUID, position int32) error {
+	return t.setPosition(db, ctx, id, position)
+}
+
+func (t *itemType[Row, Item, Params]) itemMove(ctx context.Context, db *database.Queries, id, shelfID uuid.UUID) error {
+	return t.move(db, ctx, id, shelfID)
+}
+
+func (t *itemType[Row, Item, Params]) itemSetMissingSince(ctx context.Context, db *database.Queries, id uuid.UUID, missingSince sql.NullTime) error {
+	return t.setMissingSince(db, ctx, id, missingSince)
+}
+
+func (t *itemType[Row, Item, Params]) itemShelf(ctx context.Context, db *database.Queries, id uuid.UUID) (uuid.UUID, error) {
+	row, err := t.getByID(db, ctx, id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return t.shelfID(t.toParams(row)), nil
+}
+
+func (t *itemType[Row, Item, Params]) itemAddToBundle(ctx context.Context, db *database.Queries, id, bundleID uuid.UUID) (bool, error) {
+	rows, err := t.addToBundle(db, ctx, id, bundleID)
+	return rows > 0, err
+}
+
+func (t *itemType[Row, Item, Params]) itemRemoveFromBundle(ctx context.Context, db *database.Queries, id, bundleID uuid.UUID) (bool, error) {
+	rows, err := t.removeFromBundle(db, ctx, id, bundleID)
+	return rows > 0, err
+}
+
+func (t *itemType[Row, Item, Params]) itemBundleIDs(ctx context.Context, db *database.Queries, bundleID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := t.getByBundle(db, ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, t.id(t.toItem(row)))
+	}
+	return ids, nil
+}
+
+func (t *itemType[Row, Item, Params]) itemEvent(ctx context.Context, db *database.Queries, id uuid.UUID) (ItemEvent, error) {
+	row, err := t.getByID(db, ctx, id)
+	if err != nil {
+		return ItemEvent{}, err
+	}
+	return ItemEvent{ItemType: t.name, Item: t.toItem(row)}, nil
+}
+
+func (t *itemType[Row, Item, Params]) registerRoutes(mux *http.ServeMux, cfg *apiConfig) {
+	h := &itemHandlers[Row, Item, Params]{cfg: cfg, t: t}
+
+	mux.HandleFunc("POST /api/"+t.plural, cfg.idempotent(h.handlerCreate))
+	mux.HandleFunc("GET /api/"+t.plural, h.handlerGetAll)
+	mux.HandleFunc("GET /api/"+t.plural+"/{"+t.name+"_id}", h.handlerGetByID)
+	mux.HandleFunc("PUT /api/"+t.plural+"/{"+t.name+"_id}", h.handlerUpdate)
+	mux.HandleFunc("DELETE /api/"+t.plural+"/{"+t.name+"_id}", h.handlerDelete)
+	mux.HandleFunc("GET /api/shelves/{shelf_id}/"+t.plural, h.handlerGetByShelf)
+	mux.HandleFunc("GET /api/locations/{location_id}/"+t.plural, h.handlerGetByLocation)
+	mux.HandleFunc("GET /api/search/"+t.name+"_barcodes/{barcode}", h.handlerGetByBarcode)
+	mux.HandleFunc("GET /api/search/"+t.plural, h.handlerSearch)
+}
+
+func (t *itemType[Row, Item, Params]) toItems(rows []Row) []Item {
+	items := []Item{}
+	for _, row := range rows {
+		items = append(items, t.toItem(row))
+	}
+	return items
+}
+
+// itemHandlers serves the routes of one item type.
+type itemHandlers[Row, Item, Params any] struct {
+	cfg *apiConfig
+	t   *itemType[Row, Item, Params]
+}
+
+// title returns the singular name with a leading capital, for messages such as "Movie not found".
+func (h *itemHandlers[Row, Item, Params]) title() string {
+	return strings.ToUpper(h.t.name[:1]) + h.t.name[1:]
+}
+
+// parseItemID reads the item's ID from the path, responding with an error if it is missing or invalid.
+func (h *itemHandlers[Row, Item, Params]) parseItemID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idString := r.PathValue(h.t.name + "_id")
+	if idString == "" {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("No %s id was provided", h.t.name), fmt.Errorf("no %s id was provided", h.t.name))
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s ID", h.t.name), err)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// authorizeItem checks that the requester is a member of the item's location and returns the location.
+func (h *itemHandlers[Row, Item, Params]) authorizeItem(w http.ResponseWriter, r *http.Request, id uuid.UUID, action string) (uuid.UUID, bool) {
+	locationID, err := h.t.location(h.cfg.db, r.Context(), id)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to get %s location", h.t.name), err)
+		return uuid.Nil, false
+	}
+
+	err = h.cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s %s at this location", action, h.t.plural), err)
+		return uuid.Nil, false
+	}
+
+	return locationID, true
+}
+
+// authorizeShelf checks that the requester is a member of the shelf's location and returns the location.
+func (h *itemHandlers[Row, Item, Params]) authorizeShelf(w http.ResponseWriter, r *http.Request, shelfID uuid.UUID, action string) (uuid.UUID, bool) {
+	shelfLocation, err := h.cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return uuid.Nil, false
+	}
+
+	err = h.cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s %s in this location", action, h.t.plural), err)
+		return uuid.Nil, false
+	}
+
+	return shelfLocation.ID, true
+}
+
+// customFieldDefinitions loads the location's custom fields for this item type,
+// responding with an error if they can't be loaded.
+func (h *itemHandlers[Row, Item, Params]) customFieldDefinitions(w http.ResponseWriter, r *http.Request, locationID uuid.UUID) ([]customfields.Field, bool) {
+	fields, err := h.cfg.getCustomFieldDefinitions(r.Context(), locationID, h.t.name)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get custom fields", err)
+		return nil, false
+	}
+	return fields, true
+}
+
+// customFieldFilter reads the cf.* query parameters into the JSON filter passed to the list queries.
+func (h *itemHandlers[Row, Item, Params]) customFieldFilter(w http.ResponseWriter, r *http.Request, locationID uuid.UUID) (json.RawMessage, bool) {
+	fields, ok := h.customFieldDefinitions(w, r, locationID)
+	if !ok {
+		return nil, false
+	}
+
+	filter, err := customfields.ParseFilter(fields, r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return nil, false
+	}
+
+	filterJSON, err := json.Marshal(filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to encode custom field filter", err)
+		return nil, false
+	}
+	return filterJSON, true
+}
+
+// lockItem starts a transaction that locks the item, and checks the request's If-Match against
+// the item's ETag. The transaction must be rolled back or committed.
+func (h *itemHandlers[Row, Item, Params]) lockItem(w http.ResponseWriter, r *http.Request, id uuid.UUID) (*sql.Tx, *database.Queries, bool) {
+	tx, err := h.cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return nil, nil, false
+	}
+	qtx := h.cfg.db.WithTx(tx)
+
+	updatedAt, err := h.t.lock(qtx, r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		respondWithError(w, http.StatusNotFound, h.title()+" not found", err)
+		return nil, nil, false
+	}
+	if err != nil {
+		tx.Rollback()
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to lock %s", h.t.name), err)
+		return nil, nil, false
+	}
+
+	if !checkIfMatch(w, r, updatedAt, h.t.name) {
+		tx.Rollback()
+		return nil, nil, false
+	}
+
+	return tx, qtx, true
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerCreate(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var params Params
+	err := decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	if h.t.validate != nil {
+		err = h.t.validate(&params)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+	}
+
+	locationID, ok := h.authorizeShelf(w, r, h.t.shelfID(params), "create")
+	if !ok {
+		return
+	}
+
+	fields, ok := h.customFieldDefinitions(w, r, locationID)
+	if !ok {
+		return
+	}
+
+	values := h.t.customFields(&params)
+	*values, err = customfields.Validate(fields, *values)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	// An item created from a scan draft replaces the draft.
+	draftID := uuid.Nil
+	if r.URL.Query().Has("draft_id") {
+		draftID, ok = h.cfg.parseScanDraftID(w, r, r.URL.Query().Get("draft_id"), locationID)
+		if !ok {
+			return
+		}
+	}
+
+	tx, err := h.cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.db.WithTx(tx)
+
+	row, err := h.t.create(qtx, r.Context(), params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to create %s", h.t.name), err)
+		return
+	}
+
+	if draftID != uuid.Nil {
+		err = qtx.DeleteScanDraft(r.Context(), draftID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to remove scan draft", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	h.cfg.publishEvent(r.Context(), locationID, webhooks.ItemCreated, ItemEvent{ItemType: h.t.name, Item: h.t.toItem(row)})
+
+	respondWithJSON(w, http.StatusCreated, h.t.toItem(row))
+}
+
+// handlerGetAll lists the items at every location the requester is a member of.
+func (h *itemHandlers[Row, Item, Params]) handlerGetAll(w http.ResponseWriter, r *http.Request) {
+	requesterID, err := h.cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	rows, err := h.t.getForUser(h.cfg.db, r.Context(), requesterID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to get %s from database", h.t.plural), err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.t.toItems(rows))
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerGetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.authorizeItem(w, r, id, "get"); !ok {
+		return
+	}
+
+	row, err := h.t.getByID(h.cfg.db, r.Context(), id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, h.title()+" not found", err)
+		return
+	}
+
+	item := h.t.toItem(row)
+	respondWithEntity(w, r, h.t.updatedAt(item), item)
+}
+
+// handlerUpdate applies the request body on top of the item's current values, so only the
+// fields that are sent are changed. Sending just a shelf_id moves the item. Custom fields
+// are merged the same way, and a custom field sent as null is removed.
+//
+// If-Match is checked against the item's ETag, so that a client doesn't overwrite a change it
+// hasn't seen.
+func (h *itemHandlers[Row, Item, Params]) handlerUpdate(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.authorizeItem(w, r, id, "modify"); !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	tx, qtx, ok := h.lockItem(w, r, id)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+
+	change, err := h.t.itemUpdate(r.Context(), h.cfg, qtx, id, nil, body, h.cfg.memberLocations(r))
+	if err != nil {
+		h.respondWithChangeError(w, err, "modify")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	h.cfg.publishItemChange(r.Context(), change)
+
+	item := change.event.Item.(Item)
+	respondWithEntity(w, r, h.t.updatedAt(item), item)
+}
+
+// handlerDelete deletes an item. If-Match is checked against the item's ETag, like handlerUpdate.
+func (h *itemHandlers[Row, Item, Params]) handlerDelete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.authorizeItem(w, r, id, "delete"); !ok {
+		return
+	}
+
+	tx, qtx, ok := h.lockItem(w, r, id)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+
+	change, err := h.t.itemDelete(r.Context(), qtx, id, nil, h.cfg.memberLocations(r))
+	if err != nil {
+		h.respondWithChangeError(w, err, "delete")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	h.cfg.publishItemChange(r.Context(), change)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithChangeError responds to an error from itemUpdate or itemDelete.
+func (h *itemHandlers[Row, Item, Params]) respondWithChangeError(w http.ResponseWriter, err error, action string) {
+	var invalid *invalidChangeError
+	switch {
+	case errors.As(err, &invalid):
+		respondWithError(w, http.StatusBadRequest, invalid.message, err)
+	case errors.Is(err, errNotMember):
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s %s at this location", action, h.t.plural), err)
+	case errors.Is(err, sql.ErrNoRows):
+		respondWithError(w, http.StatusNotFound, h.title()+" not found", err)
+	default:
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to %s %s", action, h.t.name), err)
+	}
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerGetByShelf(w http.ResponseWriter, r *http.Request) {
+	shelfIDString := r.PathValue("shelf_id")
+	if shelfIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No shelf id was provided", fmt.Errorf("no shelf_id was provided"))
+		return
+	}
+
+	shelfID, err := uuid.Parse(shelfIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid shelf ID", err)
+		return
+	}
+
+	// Validate user is authorized to get items at the location of shelf.
+	shelfLocation, err := h.cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf location", err)
+		return
+	}
+
+	err = h.cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to get %s at the location of that shelf", h.t.plural), err)
+		return
+	}
+
+	filter, ok := h.customFieldFilter(w, r, shelfLocation.ID)
+	if !ok {
+		return
+	}
+
+	rows, err := h.t.getByShelf(h.cfg.db, r.Context(), shelfID, filter)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("No %s found for that shelf", h.t.plural), err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.t.toItems(rows))
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get items for the location.
+	err = h.cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to get %s for this location", h.t.plural), err)
+		return
+	}
+
+	decade, filterByDecade, err := parseDecadeQuery(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid decade", err)
+		return
+	}
+
+	sortByDate, err := parseDateSortQuery(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort", err)
+		return
+	}
+
+	filter, ok := h.customFieldFilter(w, r, locationID)
+	if !ok {
+		return
+	}
+
+	var rows []Row
+	if filterByDecade {
+		rows, err = h.t.getByLocationAndDecade(h.cfg.db, r.Context(), locationID, decade, filter)
+	} else {
+		rows, err = h.t.getByLocation(h.cfg.db, r.Context(), locationID, filter)
+	}
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("No %s found for that location", h.t.plural), err)
+		return
+	}
+
+	items := h.t.toItems(rows)
+
+	if sortByDate {
+		slices.SortStableFunc(items, func(a, b Item) int {
+			return partialdate.Compare(h.t.date(a), h.t.date(b))
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerGetByBarcode(w http.ResponseWriter, r *http.Request) {
+	barcode := r.PathValue("barcode")
+	if barcode == "" {
+		respondWithError(w, http.StatusBadRequest, "No barcode was provided", fmt.Errorf("no barcode was provided"))
+		return
+	}
+
+	// Only items at locations the requester is a member of are returned.
+	requesterID, err := h.cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	rows, err := h.t.getByBarcode(h.cfg.db, r.Context(), barcode, requesterID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to search %s", h.t.plural), err)
+		return
+	}
+	if len(rows) == 0 {
+		respondWithError(w, http.StatusNotFound, h.title()+" not found", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.t.toItems(rows))
+}
+
+func (h *itemHandlers[Row, Item, Params]) handlerSearch(w http.ResponseWriter, r *http.Request) {
+	var requestBody struct {
+		LocationID string `json:"location_id"`
+		Query      string `json:"query"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	locationIDString := requestBody.LocationID
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to search items for the location.
+	err = h.cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to search %s for this location", h.t.plural), err)
+		return
+	}
+
+	query := strings.TrimSpace(requestBody.Query)
+	if query == "" {
+		respondWithError(w, http.StatusBadRequest, "No search query was provided", fmt.Errorf("no search query was provided"))
+		return
+	}
+
+	// This is the location search, narrowed to the item type.
+	response, err := h.cfg.searchLocation(r.Context(), locationID, query, map[string][]string{search.FacetType: {h.t.name}})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to search %s", h.t.plural), err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+// maxBatchItems is how many items can be created or moved at once.
+const maxBatchItems = 500
+
+// errNotMember means the requester isn't a member of the location an item is at, or is being
+// moved to.
+var errNotMember = errors.New("user is not a member of the location")
+
+// BatchItemResult is how one item of a batch went. When the batch succeeds every item is
+// "created" or "moved". When it fails, nothing is changed: the item that failed is "failed",
+// the items before it are "rolled_back" and the items after it are "skipped".
+type BatchItemResult struct {
+	Index    int        `json:"index"`
+	ItemType string     `json:"item_type"`
+	ItemID   *uuid.UUID `json:"item_id,omitempty"`
+	Status   string     `json:"status"`
+	Error    string     `json:"error,omitempty"`
+	Item     any        `json:"item,omitempty"`
+}
+
+// BatchResponse reports how each item of a batch went, in the order they were sent. Error is
+// why the batch failed.
+type BatchResponse struct {
+	Error   string            `json:"error,omitempty"`
+	Results []BatchItemResult `json:"results"`
+}
+
+// memberLocations returns a check that the requester is a member of a location, for the
+// allowLocation of item changes. Each location is only checked once.
+func (cfg *apiConfig) memberLocations(r *http.Request) func(uuid.UUID) error {
+	allowed := map[uuid.UUID]bool{}
+	return func(locationID uuid.UUID) error {
+		if allowed[locationID] {
+			return nil
+		}
+		if err := cfg.authorizeMember(locationID, *r); err != nil {
+			return fmt.Errorf("%w: %v", errNotMember, err)
+		}
+		allowed[locationID] = true
+		return nil
+	}
+}
+
+// handlerItemsBatchCreate creates items of any media type in one transaction. Each item's
+// item is the same as the body of its type's POST. Either every item is created, or none are.
+func (cfg *apiConfig) handlerItemsBatchCreate(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Items []struct {
+			ItemType string          `json:"item_type"`
+			Item     json.RawMessage `json:"item"`
+		} `json:"items"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Items) == 0 {
+		respondWithError(w, http.StatusBadRequest, "No items were provided", nil)
+		return
+	}
+	if len(params.Items) > maxBatchItems {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("No more than %d items can be created at once", maxBatchItems), nil)
+		return
+	}
+
+	results := make([]BatchItemResult, len(params.Items))
+	for i, item := range params.Items {
+		results[i] = BatchItemResult{Index: i, ItemType: item.ItemType}
+	}
+
+	cfg.runItemBatch(w, r, results, "created", http.StatusCreated, func(ctx context.Context, db *database.Queries, i int, allowLocation func(uuid.UUID) error) (itemChange, error) {
+		t, ok := lookupItemType(params.Items[i].ItemType)
+		if !ok {
+			return itemChange{}, invalidChange("Unknown item type: %s", params.Items[i].ItemType)
+		}
+		return t.itemCreate(ctx, cfg, db, params.Items[i].Item, allowLocation)
+	})
+}
+
+// handlerItemsBatchMove moves items of any media type to the end of a shelf, in one
+// transaction. Either every item is moved, or none are.
+func (cfg *apiConfig) handlerItemsBatchMove(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ShelfID uuid.UUID `json:"shelf_id"`
+		Items   []struct {
+			ItemType string    `json:"item_type"`
+			ItemID   uuid.UUID `json:"item_id"`
+		} `json:"items"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Items) == 0 {
+		respondWithError(w, http.StatusBadRequest, "No items were provided", nil)
+		return
+	}
+	if len(params.Items) > maxBatchItems {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("No more than %d items can be moved at once", maxBatchItems), nil)
+		return
+	}
+
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), params.ShelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(shelfLocation.ID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to move items to this shelf", err)
+		return
+	}
+
+	body, err := json.Marshal(map[string]uuid.UUID{"shelf_id": params.ShelfID})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to move items", err)
+		return
+	}
+
+	results := make([]BatchItemResult, len(params.Items))
+	for i, item := range params.Items {
+		results[i] = BatchItemResult{Index: i, ItemType: item.ItemType, ItemID: &item.ItemID}
+	}
+
+	cfg.runItemBatch(w, r, results, "moved", http.StatusOK, func(ctx context.Context, db *database.Queries, i int, allowLocation func(uuid.UUID) error) (itemChange, error) {
+		t, ok := lookupItemType(params.Items[i].ItemType)
+		if !ok {
+			return itemChange{}, invalidChange("Unknown item type: %s", params.Items[i].ItemType)
+		}
+		return t.itemUpdate(ctx, cfg, db, params.Items[i].ItemID, nil, body, allowLocation)
+	})
+}
+
+// runItemBatch makes the change to each item of a batch in one transaction, filling in the
+// results. The changes are published once they're all committed. If a change fails, the
+// transaction is rolled back and the response says which item failed and why.
+func (cfg *apiConfig) runItemBatch(w http.ResponseWriter, r *http.Request, results []BatchItemResult, status string, code int, change func(ctx context.Context, db *database.Queries, i int, allowLocation func(uuid.UUID) error) (itemChange, error)) {
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	allowLocation := cfg.memberLocations(r)
+	changes := make([]itemChange, 0, len(results))
+	for i := range results {
+		itemResult, err := change(r.Context(), qtx, i, allowLocation)
+		if err != nil {
+			respondWithBatchFailure(w, results, i, err)
+			return
+		}
+		changes = append(changes, itemResult)
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for i, itemResult := range changes {
+		cfg.publishItemChange(r.Context(), itemResult)
+		results[i].ItemID = &itemResult.id
+		results[i].Status = status
+		results[i].Item = itemResult.event.Item
+	}
+
+	respondWithJSON(w, code, BatchResponse{Results: results})
+}
+
+// respondWithBatchFailure responds to a batch that failed at the item at index failed.
+func respondWithBatchFailure(w http.ResponseWriter, results []BatchItemResult, failed int, err error) {
+	for i := range results {
+		switch {
+		case i < failed:
+			results[i].Status = "rolled_back"
+		case i > failed:
+			results[i].Status = "skipped"
+		}
+	}
+	results[failed].Status = "failed"
+
+	code := http.StatusInternalServerError
+	message := fmt.Sprintf("Unable to change %s", results[failed].ItemType)
+	var invalid *invalidChangeError
+	switch {
+	case errors.As(err, &invalid):
+		code = http.StatusBadRequest
+		message = invalid.message
+	case errors.Is(err, errNotMember):
+		code = http.StatusUnauthorized
+		message = fmt.Sprintf("User is not authorized to change %s items at this location", results[failed].ItemType)
+	case errors.Is(err, sql.ErrNoRows):
+		code = http.StatusNotFound
+		message = fmt.Sprintf("The %s was not found", results[failed].ItemType)
+	}
+	if code > 499 {
+		log.Printf("Unable to change item %d of batch: %v", failed, err)
+	}
+	results[failed].Error = message
+
+	respondWithJSON(w, code, BatchResponse{
+		Error:   fmt.Sprintf("Item %d: %s", failed, message),
+		Results: results,
+	})
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/labels"
+	"github.com/google/uuid"
+)
+
+// baseURL is the address of the web app, which labels link to. It's APP_URL if that's set,
+// otherwise the address the request was made to.
+func (cfg *apiConfig) baseURL(r *http.Request) string {
+	if cfg.appURL != "" {
+		return strings.TrimSuffix(cfg.appURL, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+func shelfLabel(baseURL string, dbShelf database.Shelf, dbCase database.Case, location database.Location) labels.Label {
+	return labels.Label{
+		Title:    dbShelf.Name,
+		Subtitle: location.Name + " · " + dbCase.Name,
+		Barcode:  dbShelf.Barcode,
+		Link:     labels.ShelfLink(baseURL, dbShelf.ID),
+	}
+}
+
+func caseLabel(baseURL string, dbCase database.Case, location database.Location) labels.Label {
+	return labels.Label{
+		Title:    dbCase.Name,
+		Subtitle: location.Name,
+		Barcode:  dbCase.Barcode,
+		Link:     labels.CaseLink(baseURL, dbCase.ID),
+	}
+}
+
+// labelLayout reads the layout query parameter, defaulting to labels.DefaultLayout.
+func labelLayout(w http.ResponseWriter, r *http.Request) (labels.Layout, bool) {
+	name := r.URL.Query().Get("layout")
+	if name == "" {
+		name = labels.DefaultLayout
+	}
+
+	layout, ok := labels.LookupLayout(name)
+	if !ok {
+		names := []string{}
+		for _, l := range labels.Layouts {
+			names = append(names, l.Name)
+		}
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("layout must be one of %s", strings.Join(names, ", ")), fmt.Errorf("unknown label layout: %s", name))
+		return labels.Layout{}, false
+	}
+	return layout, true
+}
+
+func writeLabelSVG(w http.ResponseWriter, label labels.Label, layout labels.Layout) {
+	svg, err := labels.SVG(label, layout.LabelWidth, layout.LabelHeight, layout.Unit)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to draw label", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(svg))
+}
+
+// handlerShelfLabel draws a shelf's label as an SVG, sized for a label of the layout.
+func (cfg *apiConfig) handlerShelfLabel(w http.ResponseWriter, r *http.Request) {
+	shelfID, ok := cfg.parseShelfID(w, r, "print a label for")
+	if !ok {
+		return
+	}
+
+	layout, ok := labelLayout(w, r)
+	if !ok {
+		return
+	}
+
+	dbShelf, err := cfg.db.GetShelfByID(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return
+	}
+
+	dbCase, err := cfg.db.GetCaseByID(r.Context(), dbShelf.CaseID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get the shelf's case", err)
+		return
+	}
+
+	location, err := cfg.db.GetLocationByID(r.Context(), dbCase.LocationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get the shelf's location", err)
+		return
+	}
+
+	writeLabelSVG(w, shelfLabel(cfg.baseURL(r), dbShelf, dbCase, location), layout)
+}
+
+// handlerCaseLabel draws a case's label as an SVG, sized for a label of the layout.
+func (cfg *apiConfig) handlerCaseLabel(w http.ResponseWriter, r *http.Request) {
+	caseIDString := r.PathValue("case_id")
+	if caseIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No case id was provided", fmt.Errorf("no case id was provided"))
+		return
+	}
+
+	caseID, err := uuid.Parse(caseIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid case ID", err)
+		return
+	}
+
+	dbCase, err := cfg.db.GetCaseByID(r.Context(), caseID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Case not found", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(dbCase.LocationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to print a label for this case", err)
+		return
+	}
+
+	layout, ok := labelLayout(w, r)
+	if !ok {
+		return
+	}
+
+	location, err := cfg.db.GetLocationByID(r.Context(), dbCase.LocationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get the case's location", err)
+		return
+	}
+
+	writeLabelSVG(w, caseLabel(cfg.baseURL(r), dbCase, location), layout)
+}
+
+// handlerLocationLabels lays out labels for printing as an HTML page. Each case_id adds the
+// case's label followed by the labels of its shelves, and each shelf_id adds one shelf's
+// label. With neither, every case and shelf at the location is included. skip leaves that
+// many labels at the start of the sheet empty.
+func (cfg *apiConfig) handlerLocationLabels(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to print labels for this location", err)
+		return
+	}
+
+	layout, ok := labelLayout(w, r)
+	if !ok {
+		return
+	}
+
+	skip := 0
+	if skipString := r.URL.Query().Get("skip"); skipString != "" {
+		skip, err = strconv.Atoi(skipString)
+		if err != nil || skip < 0 {
+			respondWithError(w, http.StatusBadRequest, "skip must be a whole number", err)
+			return
+		}
+	}
+
+	selectedCases := map[uuid.UUID]bool{}
+	selectedShelves := map[uuid.UUID]bool{}
+	for param, selected := range map[string]map[uuid.UUID]bool{"case_id": selectedCases, "shelf_id": selectedShelves} {
+		for _, idString := range r.URL.Query()[param] {
+			id, err := uuid.Parse(idString)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s", param), err)
+				return
+			}
+			selected[id] = true
+		}
+	}
+	selectAll := len(selectedCases) == 0 && len(selectedShelves) == 0
+
+	location, err := cfg.db.GetLocationByID(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Location not found", err)
+		return
+	}
+
+	dbCases, err := cfg.db.GetCasesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get cases", err)
+		return
+	}
+	slices.SortFunc(dbCases, func(a, b database.Case) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	dbShelves, err := cfg.db.GetShelvesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelves", err)
+		return
+	}
+
+	baseURL := cfg.baseURL(r)
+	sheetLabels := []labels.Label{}
+	for _, dbCase := range dbCases {
+		wholeCase := selectAll || selectedCases[dbCase.ID]
+		if wholeCase {
+			sheetLabels = append(sheetLabels, caseLabel(baseURL, dbCase, location))
+		}
+		delete(selectedCases, dbCase.ID)
+
+		for _, dbShelf := range dbShelves {
+			if dbShelf.CaseID != dbCase.ID {
+				continue
+			}
+			if wholeCase || selectedShelves[dbShelf.ID] {
+				sheetLabels = append(sheetLabels, shelfLabel(baseURL, dbShelf, dbCase, location))
+			}
+			delete(selectedShelves, dbShelf.ID)
+		}
+	}
+
+	if len(selectedCases) > 0 || len(selectedShelves) > 0 {
+		respondWithError(w, http.StatusBadRequest, "Some of the cases or shelves aren't at this location", nil)
+		return
+	}
+
+	sheet, err := labels.Sheet(layout, sheetLabels, skip)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to draw labels", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(sheet))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
 	"github.com/google/uuid"
 )
 
@@ -97,6 +98,8 @@ func (cfg *apiConfig) handlerAddLocationMember(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	cfg.publishEvent(r.Context(), locationID, webhooks.MemberAdded, MemberEvent{LocationID: locationID, UserID: userID})
+
 	respondWithJSON(w, http.StatusCreated, response{
 		NewLocationUser: NewLocationUser{
 			LocationID: locationUser.LocationID,
@@ -233,5 +236,7 @@ func (cfg *apiConfig) handlerRemoveLocationMember(w http.ResponseWriter, r *http
 		return
 	}
 
+	cfg.publishEvent(r.Context(), locationID, webhooks.MemberRemoved, MemberEvent{LocationID: locationID, UserID: userID})
+
 	w.WriteHeader(http.StatusNoContent)
 }
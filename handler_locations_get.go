@@ -100,7 +100,7 @@ func (cfg *apiConfig) handlerLocationsGetByID(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, Location{
+	respondWithEntity(w, r, dbLocation.UpdatedAt, Location{
 		ID:        dbLocation.ID,
 		Name:      dbLocation.Name,
 		OwnerID:   dbLocation.OwnerID,
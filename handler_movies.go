@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+type Movie struct {
+	ID                uuid.UUID           `json:"id"`
+	Title             string              `json:"title"`
+	Genre             string              `json:"genre"`
+	Actors            string              `json:"actors"`
+	Writer            string              `json:"writer"`
+	Director          string              `json:"director"`
+	Barcode           string              `json:"barcode"`
+	Format            string              `json:"format"`
+	ShelfID           uuid.UUID           `json:"shelf_id"`
+	ReleaseDate       partialdate.Date    `json:"release_date"`
+	RuntimeMinutes    int32               `json:"runtime_minutes"`
+	ContentRating     string              `json:"content_rating"`
+	DiscRegion        string              `json:"disc_region"`
+	AudioLanguages    string              `json:"audio_languages"`
+	SubtitleLanguages string              `json:"subtitle_languages"`
+	Position          int32               `json:"position"`
+	ThicknessCM       float64             `json:"thickness_cm"`
+	MissingSince      *time.Time          `json:"missing_since"`
+	CustomFields      customfields.Values `json:"custom_fields"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+}
+
+type movieParams struct {
+	Title             string              `json:"title"`
+	Genre             string              `json:"genre"`
+	Actors            string              `json:"actors"`
+	Writer            string              `json:"writer"`
+	Director          string              `json:"director"`
+	Barcode           string              `json:"barcode"`
+	Format            string              `json:"format"`
+	ShelfID           uuid.UUID           `json:"shelf_id"`
+	ReleaseDate       partialdate.Date    `json:"release_date"`
+	RuntimeMinutes    int32               `json:"runtime_minutes"`
+	ContentRating     string              `json:"content_rating"`
+	DiscRegion        string              `json:"disc_region"`
+	AudioLanguages    string              `json:"audio_languages"`
+	SubtitleLanguages string              `json:"subtitle_languages"`
+	ThicknessCM       float64             `json:"thickness_cm"`
+	CustomFields      customfields.Values `json:"custom_fields"`
+}
+
+var movieItems = &itemType[database.Movie, Movie, movieParams]{
+	name:   "movie",
+	plural: "movies",
+
+	toItem: func(dbMovie database.Movie) Movie {
+		return Movie{
+			ID:                dbMovie.ID,
+			Title:             dbMovie.Title,
+			Genre:             dbMovie.Genre,
+			Actors:            dbMovie.Actors,
+			Writer:            dbMovie.Writer,
+			Director:          dbMovie.Director,
+			Barcode:           dbMovie.Barcode,
+			Format:            dbMovie.Format,
+			ShelfID:           dbMovie.ShelfID,
+			ReleaseDate:       partialdate.FromNullTime(dbMovie.ReleaseDate, dbMovie.ReleaseDatePrecision),
+			RuntimeMinutes:    dbMovie.RuntimeMinutes,
+			ContentRating:     dbMovie.ContentRating,
+			DiscRegion:        dbMovie.DiscRegion,
+			AudioLanguages:    dbMovie.AudioLanguages,
+			SubtitleLanguages: dbMovie.SubtitleLanguages,
+			Position:          dbMovie.Position,
+			ThicknessCM:       dbMovie.ThicknessCm,
+			MissingSince:      nullTimeToPointer(dbMovie.MissingSince),
+			CustomFields:      customFieldsFromDB(dbMovie.CustomFields),
+			CreatedAt:         dbMovie.CreatedAt,
+			UpdatedAt:         dbMovie.UpdatedAt,
+		}
+	},
+	toParams: func(dbMovie database.Movie) movieParams {
+		return movieParams{
+			Title:             dbMovie.Title,
+			Genre:             dbMovie.Genre,
+			Actors:            dbMovie.Actors,
+			Writer:            dbMovie.Writer,
+			Director:          dbMovie.Director,
+			Barcode:           dbMovie.Barcode,
+			Format:            dbMovie.Format,
+			ShelfID:           dbMovie.ShelfID,
+			ReleaseDate:       partialdate.FromNullTime(dbMovie.ReleaseDate, dbMovie.ReleaseDatePrecision),
+			RuntimeMinutes:    dbMovie.RuntimeMinutes,
+			ContentRating:     dbMovie.ContentRating,
+			DiscRegion:        dbMovie.DiscRegion,
+			AudioLanguages:    dbMovie.AudioLanguages,
+			SubtitleLanguages: dbMovie.SubtitleLanguages,
+			ThicknessCM:       dbMovie.ThicknessCm,
+			CustomFields:      customFieldsFromDB(dbMovie.CustomFields),
+		}
+	},
+	id:           func(movie Movie) uuid.UUID { return movie.ID },
+	updatedAt:    func(movie Movie) time.Time { return movie.UpdatedAt },
+	shelfID:      func(params movieParams) uuid.UUID { return params.ShelfID },
+	date:         func(movie Movie) partialdate.Date { return movie.ReleaseDate },
+	customFields: func(params *movieParams) *customfields.Values { return &params.CustomFields },
+
+	create: func(db *database.Queries, ctx context.Context, params movieParams) (database.Movie, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Movie{}, err
+		}
+
+		return db.CreateMovie(ctx, database.CreateMovieParams{
+			Title:                params.Title,
+			Genre:                params.Genre,
+			Actors:               params.Actors,
+			Writer:               params.Writer,
+			Director:             params.Director,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			RuntimeMinutes:       params.RuntimeMinutes,
+			ContentRating:        params.ContentRating,
+			DiscRegion:           params.DiscRegion,
+			AudioLanguages:       params.AudioLanguages,
+			SubtitleLanguages:    params.SubtitleLanguages,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	update: func(db *database.Queries, ctx context.Context, id uuid.UUID, params movieParams) (database.Movie, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Movie{}, err
+		}
+
+		return db.UpdateMovie(ctx, database.UpdateMovieParams{
+			ID:                   id,
+			Title:                params.Title,
+			Genre:                params.Genre,
+			Actors:               params.Actors,
+			Writer:               params.Writer,
+			Director:             params.Director,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			RuntimeMinutes:       params.RuntimeMinutes,
+			ContentRating:        params.ContentRating,
+			DiscRegion:           params.DiscRegion,
+			AudioLanguages:       params.AudioLanguages,
+			SubtitleLanguages:    params.SubtitleLanguages,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	delete: (*database.Queries).DeleteMovie,
+	lock:   (*database.Queries).LockMovie,
+	setPosition: func(db *database.Queries, ctx context.Context, id uuid.UUID, position int32) error {
+		return db.SetMoviePosition(ctx, database.SetMoviePositionParams{
+			ID:       id,
+			Position: position,
+		})
+	},
+	move: func(db *database.Queries, ctx context.Context, id, shelfID uuid.UUID) error {
+		return db.MoveMovie(ctx, database.MoveMovieParams{
+			ID:      id,
+			ShelfID: shelfID,
+		})
+	},
+	setMissingSince: func(db *database.Queries, ctx context.Context, id uuid.UUID, missingSince sql.NullTime) error {
+		return db.SetMovieMissingSince(ctx, database.SetMovieMissingSinceParams{
+			ID:           id,
+			MissingSince: missingSince,
+		})
+	},
+	location: func(db *database.Queries, ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		location, err := db.GetMovieLocation(ctx, id)
+		return location.ID, err
+	},
+	addToBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.AddMovieToBundle(ctx, database.AddMovieToBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	removeFromBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.RemoveMovieFromBundle(ctx, database.RemoveMovieFromBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	getByBundle: func(db *database.Queries, ctx context.Context, bundleID uuid.UUID) ([]database.Movie, error) {
+		return db.GetMoviesByBundle(ctx, uuid.NullUUID{UUID: bundleID, Valid: true})
+	},
+	getForUser: (*database.Queries).GetMoviesForUser,
+	getByID:    (*database.Queries).GetMovieByID,
+	getByBarcode: func(db *database.Queries, ctx context.Context, barcode string, userID uuid.UUID) ([]database.Movie, error) {
+		return db.GetMoviesByBarcodeForUser(ctx, database.GetMoviesByBarcodeForUserParams{
+			Barcode: barcode,
+			UserID:  userID,
+		})
+	},
+	getByShelf: func(db *database.Queries, ctx context.Context, shelfID uuid.UUID, customFields json.RawMessage) ([]database.Movie, error) {
+		return db.GetMoviesByShelf(ctx, database.GetMoviesByShelfParams{
+			ShelfID:      shelfID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocation: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, customFields json.RawMessage) ([]database.Movie, error) {
+		return db.GetMoviesByLocation(ctx, database.GetMoviesByLocationParams{
+			LocationID:   locationID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocationAndDecade: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, decade int32, customFields json.RawMessage) ([]database.Movie, error) {
+		return db.GetMoviesByLocationAndDecade(ctx, database.GetMoviesByLocationAndDecadeParams{
+			LocationID:   locationID,
+			Decade:       decade,
+			CustomFields: customFields,
+		})
+	},
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+type Music struct {
+	ID            uuid.UUID           `json:"id"`
+	Title         string              `json:"title"`
+	Artist        string              `json:"artist"`
+	Genre         string              `json:"genre"`
+	Barcode       string              `json:"barcode"`
+	Format        string              `json:"format"`
+	ShelfID       uuid.UUID           `json:"shelf_id"`
+	ReleaseDate   partialdate.Date    `json:"release_date"`
+	Label         string              `json:"label"`
+	CatalogNumber string              `json:"catalog_number"`
+	DiscCount     int32               `json:"disc_count"`
+	Tracklist     []Track             `json:"tracklist"`
+	Position      int32               `json:"position"`
+	ThicknessCM   float64             `json:"thickness_cm"`
+	MissingSince  *time.Time          `json:"missing_since"`
+	CustomFields  customfields.Values `json:"custom_fields"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type Track struct {
+	Disc            int32  `json:"disc"`
+	Number          int32  `json:"number"`
+	Title           string `json:"title"`
+	DurationSeconds int32  `json:"duration_seconds"`
+}
+
+type musicParams struct {
+	Title         string              `json:"title"`
+	Artist        string              `json:"artist"`
+	Genre         string              `json:"genre"`
+	Barcode       string              `json:"barcode"`
+	Format        string              `json:"format"`
+	ShelfID       uuid.UUID           `json:"shelf_id"`
+	ReleaseDate   partialdate.Date    `json:"release_date"`
+	Label         string              `json:"label"`
+	CatalogNumber string              `json:"catalog_number"`
+	DiscCount     int32               `json:"disc_count"`
+	Tracklist     []Track             `json:"tracklist"`
+	ThicknessCM   float64             `json:"thickness_cm"`
+	CustomFields  customfields.Values `json:"custom_fields"`
+}
+
+var musicItems = &itemType[database.Music, Music, musicParams]{
+	name:   "music",
+	plural: "music",
+
+	toItem: func(dbMusic database.Music) Music {
+		return Music{
+			ID:            dbMusic.ID,
+			Title:         dbMusic.Title,
+			Artist:        dbMusic.Artist,
+			Genre:         dbMusic.Genre,
+			Barcode:       dbMusic.Barcode,
+			Format:        dbMusic.Format,
+			ShelfID:       dbMusic.ShelfID,
+			ReleaseDate:   partialdate.FromNullTime(dbMusic.ReleaseDate, dbMusic.ReleaseDatePrecision),
+			Label:         dbMusic.Label,
+			CatalogNumber: dbMusic.CatalogNumber,
+			DiscCount:     dbMusic.DiscCount,
+			Tracklist:     tracklistFromDB(dbMusic.Tracklist),
+			Position:      dbMusic.Position,
+			ThicknessCM:   dbMusic.ThicknessCm,
+			MissingSince:  nullTimeToPointer(dbMusic.MissingSince),
+			CustomFields:  customFieldsFromDB(dbMusic.CustomFields),
+			CreatedAt:     dbMusic.CreatedAt,
+			UpdatedAt:     dbMusic.UpdatedAt,
+		}
+	},
+	toParams: func(dbMusic database.Music) musicParams {
+		return musicParams{
+			Title:         dbMusic.Title,
+			Artist:        dbMusic.Artist,
+			Genre:         dbMusic.Genre,
+			Barcode:       dbMusic.Barcode,
+			Format:        dbMusic.Format,
+			ShelfID:       dbMusic.ShelfID,
+			ReleaseDate:   partialdate.FromNullTime(dbMusic.ReleaseDate, dbMusic.ReleaseDatePrecision),
+			Label:         dbMusic.Label,
+			CatalogNumber: dbMusic.CatalogNumber,
+			DiscCount:     dbMusic.DiscCount,
+			Tracklist:     tracklistFromDB(dbMusic.Tracklist),
+			ThicknessCM:   dbMusic.ThicknessCm,
+			CustomFields:  customFieldsFromDB(dbMusic.CustomFields),
+		}
+	},
+	id:           func(music Music) uuid.UUID { return music.ID },
+	updatedAt:    func(music Music) time.Time { return music.UpdatedAt },
+	shelfID:      func(params musicParams) uuid.UUID { return params.ShelfID },
+	date:         func(music Music) partialdate.Date { return music.ReleaseDate },
+	customFields: func(params *musicParams) *customfields.Values { return &params.CustomFields },
+	validate: func(params *musicParams) error {
+		if params.DiscCount == 0 {
+			params.DiscCount = 1
+		}
+		if params.Tracklist == nil {
+			params.Tracklist = []Track{}
+		}
+		return nil
+	},
+
+	create: func(db *database.Queries, ctx context.Context, params musicParams) (database.Music, error) {
+		tracklist, err := json.Marshal(params.Tracklist)
+		if err != nil {
+			return database.Music{}, err
+		}
+
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Music{}, err
+		}
+
+		return db.CreateMusic(ctx, database.CreateMusicParams{
+			Title:                params.Title,
+			Artist:               params.Artist,
+			Genre:                params.Genre,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			Label:                params.Label,
+			CatalogNumber:        params.CatalogNumber,
+			DiscCount:            params.DiscCount,
+			Tracklist:            tracklist,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	update: func(db *database.Queries, ctx context.Context, id uuid.UUID, params musicParams) (database.Music, error) {
+		tracklist, err := json.Marshal(params.Tracklist)
+		if err != nil {
+			return database.Music{}, err
+		}
+
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Music{}, err
+		}
+
+		return db.UpdateMusic(ctx, database.UpdateMusicParams{
+			ID:                   id,
+			Title:                params.Title,
+			Artist:               params.Artist,
+			Genre:                params.Genre,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			Label:                params.Label,
+			CatalogNumber:        params.CatalogNumber,
+			DiscCount:            params.DiscCount,
+			Tracklist:            tracklist,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	delete: (*database.Queries).DeleteMusic,
+	lock:   (*database.Queries).LockMusic,
+	setPosition: func(db *database.Queries, ctx context.Context, id uuid.UUID, position int32) error {
+		return db.SetMusicPosition(ctx, database.SetMusicPositionParams{
+			ID:       id,
+			Position: position,
+		})
+	},
+	move: func(db *database.Queries, ctx context.Context, id, shelfID uuid.UUID) error {
+		return db.MoveMusic(ctx, database.MoveMusicParams{
+			ID:      id,
+			ShelfID: shelfID,
+		})
+	},
+	setMissingSince: func(db *database.Queries, ctx context.Context, id uuid.UUID, missingSince sql.NullTime) error {
+		return db.SetMusicMissingSince(ctx, database.SetMusicMissingSinceParams{
+			ID:           id,
+			MissingSince: missingSince,
+		})
+	},
+	location: func(db *database.Queries, ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		location, err := db.GetMusicLocation(ctx, id)
+		return location.ID, err
+	},
+	addToBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.AddMusicToBundle(ctx, database.AddMusicToBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	removeFromBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.RemoveMusicFromBundle(ctx, database.RemoveMusicFromBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	getByBundle: func(db *database.Queries, ctx context.Context, bundleID uuid.UUID) ([]database.Music, error) {
+		return db.GetMusicByBundle(ctx, uuid.NullUUID{UUID: bundleID, Valid: true})
+	},
+	getForUser: (*database.Queries).GetMusicForUser,
+	getByID:    (*database.Queries).GetMusicByID,
+	getByBarcode: func(db *database.Queries, ctx context.Context, barcode string, userID uuid.UUID) ([]database.Music, error) {
+		return db.GetMusicByBarcodeForUser(ctx, database.GetMusicByBarcodeForUserParams{
+			Barcode: barcode,
+			UserID:  userID,
+		})
+	},
+	getByShelf: func(db *database.Queries, ctx context.Context, shelfID uuid.UUID, customFields json.RawMessage) ([]database.Music, error) {
+		return db.GetMusicByShelf(ctx, database.GetMusicByShelfParams{
+			ShelfID:      shelfID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocation: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, customFields json.RawMessage) ([]database.Music, error) {
+		return db.GetMusicByLocation(ctx, database.GetMusicByLocationParams{
+			LocationID:   locationID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocationAndDecade: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, decade int32, customFields json.RawMessage) ([]database.Music, error) {
+		return db.GetMusicByLocationAndDecade(ctx, database.GetMusicByLocationAndDecadeParams{
+			LocationID:   locationID,
+			Decade:       decade,
+			CustomFields: customFields,
+		})
+	},
+}
+
+// tracklistFromDB decodes a stored tracklist. Tracklists are only ever written from a
+// []Track, so a value that fails to decode is treated as empty.
+func tracklistFromDB(raw json.RawMessage) []Track {
+	tracklist := []Track{}
+	if err := json.Unmarshal(raw, &tracklist); err != nil {
+		return []Track{}
+	}
+	return tracklist
+}
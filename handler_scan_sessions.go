@@ -0,0 +1,698 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/labels"
+	"github.com/Rodabaugh/digitalshelf/internal/metadata"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+// metadataLookupTimeout keeps a slow lookup service from holding up the scanner.
+const metadataLookupTimeout = 5 * time.Second
+
+// A ScanSession shelves items as they are scanned. Scanning a shelf's barcode makes it the
+// current shelf, and the items scanned after it are moved there.
+type ScanSession struct {
+	ID         uuid.UUID     `json:"id"`
+	LocationID uuid.UUID     `json:"location_id"`
+	StartedBy  uuid.UUID     `json:"started_by"`
+	ShelfID    uuid.NullUUID `json:"shelf_id"`
+	ClosedAt   *time.Time    `json:"closed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// A ScanDraft is a scanned barcode that isn't an item at the location yet, filled in with
+// whatever could be looked up about it. ItemType is empty when nothing was found.
+type ScanDraft struct {
+	ID          uuid.UUID        `json:"id"`
+	SessionID   uuid.UUID        `json:"session_id"`
+	ShelfID     uuid.UUID        `json:"shelf_id"`
+	Barcode     string           `json:"barcode"`
+	ItemType    string           `json:"item_type"`
+	Title       string           `json:"title"`
+	Creator     string           `json:"creator"`
+	Genre       string           `json:"genre"`
+	ReleaseDate partialdate.Date `json:"release_date"`
+	Source      string           `json:"source"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type ScannedItem struct {
+	ItemType    string    `json:"item_type"`
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Barcode     string    `json:"barcode"`
+	FromShelfID uuid.UUID `json:"from_shelf_id"`
+	ShelfID     uuid.UUID `json:"shelf_id"`
+}
+
+// ScanResult says what a scan did. Action is one of:
+//   - shelf: a shelf's barcode was scanned and it is now the current shelf.
+//   - case: a case's barcode was scanned. If the case has one shelf it's now the current
+//     shelf, otherwise there is no current shelf until one of Shelves is scanned.
+//   - moved: an item at the location was moved to the current shelf.
+//   - already_on_shelf: the item, or the bundle and everything in it, was already on the
+//     current shelf.
+//   - bundle_moved: a bundle's barcode was scanned, and the bundle and everything in it were
+//     moved to the current shelf.
+//   - draft: the barcode didn't match an item or bundle, and a draft was added for it.
+type ScanResult struct {
+	Action  string          `json:"action"`
+	Session ScanSession     `json:"session"`
+	Shelf   *Shelf          `json:"shelf,omitempty"`
+	Case    *Case           `json:"case,omitempty"`
+	Shelves []Shelf         `json:"shelves,omitempty"`
+	Item    *ScannedItem    `json:"item,omitempty"`
+	Bundle  *BundleContents `json:"bundle,omitempty"`
+	Draft   *ScanDraft      `json:"draft,omitempty"`
+}
+
+func scanSessionFromDB(dbSession database.ScanSession) ScanSession {
+	return ScanSession{
+		ID:         dbSession.ID,
+		LocationID: dbSession.LocationID,
+		StartedBy:  dbSession.StartedBy,
+		ShelfID:    dbSession.ShelfID,
+		ClosedAt:   nullTimeToPointer(dbSession.ClosedAt),
+		CreatedAt:  dbSession.CreatedAt,
+		UpdatedAt:  dbSession.UpdatedAt,
+	}
+}
+
+func scanDraftFromDB(dbDraft database.ScanDraft) ScanDraft {
+	// Drafts only store release dates that were valid when they were looked up.
+	releaseDate, _ := partialdate.Parse(dbDraft.ReleaseDate)
+	return ScanDraft{
+		ID:          dbDraft.ID,
+		SessionID:   dbDraft.SessionID,
+		ShelfID:     dbDraft.ShelfID,
+		Barcode:     dbDraft.Barcode,
+		ItemType:    dbDraft.ItemType,
+		Title:       dbDraft.Title,
+		Creator:     dbDraft.Creator,
+		Genre:       dbDraft.Genre,
+		ReleaseDate: releaseDate,
+		Source:      dbDraft.Source,
+		CreatedAt:   dbDraft.CreatedAt,
+	}
+}
+
+// getScanSession reads the session ID from the path, loads the session and checks that the
+// requester is a member of its location, responding with an error if not.
+func (cfg *apiConfig) getScanSession(w http.ResponseWriter, r *http.Request) (database.ScanSession, bool) {
+	sessionIDString := r.PathValue("session_id")
+	if sessionIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No session id was provided", fmt.Errorf("no session id was provided"))
+		return database.ScanSession{}, false
+	}
+
+	sessionID, err := uuid.Parse(sessionIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid session ID", err)
+		return database.ScanSession{}, false
+	}
+
+	dbSession, err := cfg.db.GetScanSessionByID(r.Context(), sessionID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Scan session not found", err)
+		return database.ScanSession{}, false
+	}
+
+	err = cfg.authorizeMember(dbSession.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to access scan sessions at this location", err)
+		return database.ScanSession{}, false
+	}
+
+	return dbSession, true
+}
+
+// handlerScanSessionCreate starts a scan session at a location. A shelf_id may be sent to
+// start with a current shelf.
+func (cfg *apiConfig) handlerScanSessionCreate(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ShelfID uuid.NullUUID `json:"shelf_id"`
+	}
+
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to scan items at this location", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&params)
+	if err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if params.ShelfID.Valid {
+		shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), params.ShelfID.UUID)
+		if err != nil || shelfLocation.ID != locationID {
+			respondWithError(w, http.StatusBadRequest, "Shelf is not at this location", err)
+			return
+		}
+	}
+
+	userID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	dbSession, err := cfg.db.CreateScanSession(r.Context(), database.CreateScanSessionParams{
+		LocationID: locationID,
+		StartedBy:  userID,
+		ShelfID:    params.ShelfID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create scan session", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, scanSessionFromDB(dbSession))
+}
+
+func (cfg *apiConfig) handlerScanSessionGetByID(w http.ResponseWriter, r *http.Request) {
+	dbSession, ok := cfg.getScanSession(w, r)
+	if !ok {
+		return
+	}
+
+	respondWithEntity(w, r, dbSession.UpdatedAt, scanSessionFromDB(dbSession))
+}
+
+// handlerScanSessionScan handles one scanned barcode. Shelf and case barcodes change the
+// current shelf. Any other barcode is an item, which is moved to the current shelf if it's
+// at the location, or added as a draft if it isn't.
+func (cfg *apiConfig) handlerScanSessionScan(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Barcode string `json:"barcode"`
+	}
+
+	dbSession, ok := cfg.getScanSession(w, r)
+	if !ok {
+		return
+	}
+
+	if dbSession.ClosedAt.Valid {
+		respondWithError(w, http.StatusConflict, "Scan session is closed", fmt.Errorf("scan session %s is closed", dbSession.ID))
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Barcode) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Barcode is required", nil)
+		return
+	}
+
+	// The QR codes on labels hold a link to the shelf or case instead of its barcode.
+	if kind, id, ok := labels.ParseLink(params.Barcode); ok {
+		cfg.scanLabelLink(w, r, dbSession, kind, id)
+		return
+	}
+
+	dbShelf, err := cfg.db.GetShelfByBarcode(r.Context(), database.GetShelfByBarcodeParams{
+		LocationID: dbSession.LocationID,
+		Barcode:    params.Barcode,
+	})
+	if err == nil {
+		cfg.scanShelf(w, r, dbSession, dbShelf)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Unable to look up barcode", err)
+		return
+	}
+
+	dbCase, err := cfg.db.GetCaseByBarcode(r.Context(), database.GetCaseByBarcodeParams{
+		LocationID: dbSession.LocationID,
+		Barcode:    params.Barcode,
+	})
+	if err == nil {
+		cfg.scanCase(w, r, dbSession, dbCase)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, http.StatusInternalServerError, "Unable to look up barcode", err)
+		return
+	}
+
+	if !dbSession.ShelfID.Valid {
+		respondWithError(w, http.StatusConflict, "Scan a shelf before scanning items", nil)
+		return
+	}
+
+	cfg.scanItem(w, r, dbSession, params.Barcode)
+}
+
+func (cfg *apiConfig) scanLabelLink(w http.ResponseWriter, r *http.Request, dbSession database.ScanSession, kind string, id uuid.UUID) {
+	switch kind {
+	case "shelf":
+		shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), id)
+		if err != nil || shelfLocation.ID != dbSession.LocationID {
+			respondWithError(w, http.StatusBadRequest, "Shelf is not at this location", err)
+			return
+		}
+
+		dbShelf, err := cfg.db.GetShelfByID(r.Context(), id)
+		if err != nil {
+			respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+			return
+		}
+		cfg.scanShelf(w, r, dbSession, dbShelf)
+	case "case":
+		dbCase, err := cfg.db.GetCaseByID(r.Context(), id)
+		if err != nil || dbCase.LocationID != dbSession.LocationID {
+			respondWithError(w, http.StatusBadRequest, "Case is not at this location", err)
+			return
+		}
+		cfg.scanCase(w, r, dbSession, dbCase)
+	}
+}
+
+func (cfg *apiConfig) scanShelf(w http.ResponseWriter, r *http.Request, dbSession database.ScanSession, dbShelf database.Shelf) {
+	dbSession, err := cfg.db.SetScanSessionShelf(r.Context(), database.SetScanSessionShelfParams{
+		ID:      dbSession.ID,
+		ShelfID: uuid.NullUUID{UUID: dbShelf.ID, Valid: true},
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to set the current shelf", err)
+		return
+	}
+
+	shelf := shelfFromDB(dbShelf)
+	respondWithJSON(w, http.StatusOK, ScanResult{
+		Action:  "shelf",
+		Session: scanSessionFromDB(dbSession),
+		Shelf:   &shelf,
+	})
+}
+
+func (cfg *apiConfig) scanCase(w http.ResponseWriter, r *http.Request, dbSession database.ScanSession, dbCase database.Case) {
+	dbShelves, err := cfg.db.GetShelvesByCase(r.Context(), dbCase.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelves", err)
+		return
+	}
+
+	result := ScanResult{Action: "case", Shelves: []Shelf{}}
+	for _, dbShelf := range dbShelves {
+		result.Shelves = append(result.Shelves, shelfFromDB(dbShelf))
+	}
+
+	shelfID := uuid.NullUUID{}
+	if len(dbShelves) == 1 {
+		shelfID = uuid.NullUUID{UUID: dbShelves[0].ID, Valid: true}
+		result.Shelf = &result.Shelves[0]
+	}
+
+	dbSession, err = cfg.db.SetScanSessionShelf(r.Context(), database.SetScanSessionShelfParams{
+		ID:      dbSession.ID,
+		ShelfID: shelfID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to set the current shelf", err)
+		return
+	}
+
+	itemCase := caseFromDB(dbCase)
+	result.Case = &itemCase
+	result.Session = scanSessionFromDB(dbSession)
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (cfg *apiConfig) scanItem(w http.ResponseWriter, r *http.Request, dbSession database.ScanSession, barcode string) {
+	shelfID := dbSession.ShelfID.UUID
+	result := ScanResult{Session: scanSessionFromDB(dbSession)}
+
+	dbItems, err := cfg.db.GetLocationItemsByBarcode(r.Context(), database.GetLocationItemsByBarcodeParams{
+		LocationID: dbSession.LocationID,
+		Barcode:    barcode,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to look up barcode", err)
+		return
+	}
+
+	if len(dbItems) > 0 {
+		// When there are several copies of an item, each scan shelves the next copy that
+		// isn't on the current shelf yet.
+		dbItem := dbItems[0]
+		result.Action = "already_on_shelf"
+		for _, candidate := range dbItems {
+			if candidate.ShelfID != shelfID {
+				dbItem = candidate
+				result.Action = "moved"
+				break
+			}
+		}
+
+		if result.Action == "moved" {
+			err = cfg.moveScannedItem(r.Context(), dbItem, shelfID)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Unable to move %s", dbItem.ItemType), err)
+				return
+			}
+		}
+
+		result.Item = &ScannedItem{
+			ItemType:    dbItem.ItemType,
+			ID:          dbItem.ID,
+			Title:       dbItem.Title,
+			Barcode:     dbItem.Barcode,
+			FromShelfID: dbItem.ShelfID,
+			ShelfID:     shelfID,
+		}
+		respondWithJSON(w, http.StatusOK, result)
+		return
+	}
+
+	dbBundles, err := cfg.getLocationBundlesByBarcode(r, dbSession.LocationID, barcode)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to look up barcode", err)
+		return
+	}
+
+	if len(dbBundles) > 0 {
+		// Like items, each scan shelves the next bundle that isn't on the current shelf yet.
+		dbBundle := dbBundles[0]
+		for _, candidate := range dbBundles {
+			if candidate.ShelfID != shelfID {
+				dbBundle = candidate
+				break
+			}
+		}
+
+		dbBundle, moved, err := cfg.moveScannedBundle(r.Context(), dbBundle.ID, shelfID, dbSession.LocationID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to move bundle", err)
+			return
+		}
+
+		bundleContents, err := cfg.getBundleContents(r.Context(), cfg.db, dbBundle)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to get bundle contents", err)
+			return
+		}
+
+		result.Action = "already_on_shelf"
+		if moved {
+			result.Action = "bundle_moved"
+		}
+		result.Bundle = &bundleContents
+		respondWithJSON(w, http.StatusOK, result)
+		return
+	}
+
+	details := cfg.lookupBarcode(r, barcode)
+	dbDraft, err := cfg.db.CreateScanDraft(r.Context(), database.CreateScanDraftParams{
+		SessionID:   dbSession.ID,
+		ShelfID:     shelfID,
+		Barcode:     barcode,
+		ItemType:    details.ItemType,
+		Title:       details.Title,
+		Creator:     details.Creator,
+		Genre:       details.Genre,
+		ReleaseDate: details.ReleaseDate,
+		Source:      details.Source,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create scan draft", err)
+		return
+	}
+
+	draft := scanDraftFromDB(dbDraft)
+	result.Action = "draft"
+	result.Draft = &draft
+	respondWithJSON(w, http.StatusCreated, result)
+}
+
+// moveScannedItem moves an item to the current shelf. An item that was marked missing has
+// been found, so it's no longer missing.
+func (cfg *apiConfig) moveScannedItem(ctx context.Context, dbItem database.LocationItem, shelfID uuid.UUID) error {
+	t, ok := lookupItemType(dbItem.ItemType)
+	if !ok {
+		return fmt.Errorf("unknown item type: %s", dbItem.ItemType)
+	}
+
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	err = t.itemMove(ctx, qtx, dbItem.ID, shelfID)
+	if err != nil {
+		return err
+	}
+
+	if dbItem.MissingSince.Valid {
+		err = t.itemSetMissingSince(ctx, qtx, dbItem.ID, sql.NullTime{})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cfg.publishItemMoved(ctx, t, dbItem.LocationID, dbItem.ID, dbItem.ShelfID)
+	return nil
+}
+
+// getLocationBundlesByBarcode returns the bundles at a location with a barcode.
+func (cfg *apiConfig) getLocationBundlesByBarcode(r *http.Request, locationID uuid.UUID, barcode string) ([]database.Bundle, error) {
+	userID, err := cfg.getRequesterID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dbBundles, err := cfg.db.GetBundlesByBarcodeForUser(r.Context(), database.GetBundlesByBarcodeForUserParams{
+		Barcode: barcode,
+		UserID:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := []database.Bundle{}
+	for _, dbBundle := range dbBundles {
+		bundleLocation, err := cfg.db.GetBundleLocation(r.Context(), dbBundle.ID)
+		if err != nil {
+			return nil, err
+		}
+		if bundleLocation.ID == locationID {
+			bundles = append(bundles, dbBundle)
+		}
+	}
+	return bundles, nil
+}
+
+// moveScannedBundle moves a bundle and every item in it to the current shelf. moved is false
+// if the bundle and its items were all already there.
+func (cfg *apiConfig) moveScannedBundle(ctx context.Context, bundleID, shelfID, locationID uuid.UUID) (dbBundle database.Bundle, moved bool, err error) {
+	tx, err := cfg.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Bundle{}, false, err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	dbBundle, err = qtx.LockBundle(ctx, bundleID)
+	if err != nil {
+		return database.Bundle{}, false, err
+	}
+	moved = dbBundle.ShelfID != shelfID
+
+	if moved {
+		dbBundle, err = qtx.UpdateBundleShelf(ctx, database.UpdateBundleShelfParams{
+			ID:      bundleID,
+			ShelfID: shelfID,
+		})
+		if err != nil {
+			return database.Bundle{}, false, err
+		}
+	}
+
+	changes, err := moveBundleItems(ctx, qtx, bundleID, shelfID, locationID, locationID)
+	if err != nil {
+		return database.Bundle{}, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return database.Bundle{}, false, err
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(ctx, change)
+	}
+	return dbBundle, moved || len(changes) > 0, nil
+}
+
+// lookupBarcode finds the details of a new item. The requester's other locations are checked
+// first, then the metadata lookup service if one is configured. Lookup failures are logged
+// and leave the draft blank rather than failing the scan.
+func (cfg *apiConfig) lookupBarcode(r *http.Request, barcode string) metadata.Result {
+	userID, err := cfg.getRequesterID(r)
+	if err == nil {
+		dbItem, err := cfg.db.GetMemberItemByBarcode(r.Context(), database.GetMemberItemByBarcodeParams{
+			UserID:  userID,
+			Barcode: barcode,
+		})
+		if err == nil {
+			return metadata.Result{
+				ItemType:    dbItem.ItemType,
+				Title:       dbItem.Title,
+				Creator:     dbItem.Creator,
+				Genre:       dbItem.Genre,
+				Source:      "collection",
+				ReleaseDate: partialdate.FromNullTime(dbItem.ReleaseDate, dbItem.ReleaseDatePrecision).String(),
+			}
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Unable to look up barcode %s in collection: %s", barcode, err)
+		}
+	}
+
+	if cfg.metadata == nil {
+		return metadata.Result{}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), metadataLookupTimeout)
+	defer cancel()
+
+	result, err := cfg.metadata.Lookup(ctx, barcode)
+	if err != nil {
+		if !errors.Is(err, metadata.ErrNotFound) {
+			log.Printf("Unable to look up barcode %s: %s", barcode, err)
+		}
+		return metadata.Result{}
+	}
+
+	// Only keep dates that items will accept.
+	if _, err := partialdate.Parse(result.ReleaseDate); err != nil {
+		result.ReleaseDate = ""
+	}
+	return result
+}
+
+func (cfg *apiConfig) handlerScanSessionClose(w http.ResponseWriter, r *http.Request) {
+	dbSession, ok := cfg.getScanSession(w, r)
+	if !ok {
+		return
+	}
+
+	if dbSession.ClosedAt.Valid {
+		respondWithError(w, http.StatusConflict, "Scan session is already closed", fmt.Errorf("scan session %s is closed", dbSession.ID))
+		return
+	}
+
+	dbSession, err := cfg.db.CloseScanSession(r.Context(), dbSession.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to close scan session", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, scanSessionFromDB(dbSession))
+}
+
+func (cfg *apiConfig) handlerScanDraftsGetBySession(w http.ResponseWriter, r *http.Request) {
+	dbSession, ok := cfg.getScanSession(w, r)
+	if !ok {
+		return
+	}
+
+	dbDrafts, err := cfg.db.GetScanDraftsBySession(r.Context(), dbSession.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get scan drafts", err)
+		return
+	}
+
+	drafts := []ScanDraft{}
+	for _, dbDraft := range dbDrafts {
+		drafts = append(drafts, scanDraftFromDB(dbDraft))
+	}
+
+	respondWithJSON(w, http.StatusOK, drafts)
+}
+
+func (cfg *apiConfig) handlerScanDraftDelete(w http.ResponseWriter, r *http.Request) {
+	draftID, ok := cfg.parseScanDraftID(w, r, r.PathValue("draft_id"), uuid.Nil)
+	if !ok {
+		return
+	}
+
+	err := cfg.db.DeleteScanDraft(r.Context(), draftID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete scan draft", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseScanDraftID checks that the requester may use a draft. If locationID isn't nil, the
+// draft must also be from a session at that location.
+func (cfg *apiConfig) parseScanDraftID(w http.ResponseWriter, r *http.Request, draftIDString string, locationID uuid.UUID) (uuid.UUID, bool) {
+	if draftIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No draft id was provided", fmt.Errorf("no draft id was provided"))
+		return uuid.Nil, false
+	}
+
+	draftID, err := uuid.Parse(draftIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid draft ID", err)
+		return uuid.Nil, false
+	}
+
+	draftLocationID, err := cfg.db.GetScanDraftLocation(r.Context(), draftID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Scan draft not found", err)
+		return uuid.Nil, false
+	}
+
+	if locationID != uuid.Nil && draftLocationID != locationID {
+		respondWithError(w, http.StatusBadRequest, "Scan draft is from a different location", nil)
+		return uuid.Nil, false
+	}
+
+	err = cfg.authorizeMember(draftLocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to access this scan draft", err)
+		return uuid.Nil, false
+	}
+
+	return draftID, true
+}
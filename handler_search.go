@@ -0,0 +1,490 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/Rodabaugh/digitalshelf/internal/search"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 25
+)
+
+// Suggestion is an item title offered while a search is being typed.
+type Suggestion struct {
+	ItemType string    `json:"item_type"`
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Creator  string    `json:"creator"`
+}
+
+// SearchResult is an item of any media type found by a location search. Highlights has the
+// fields that matched, as HTML with the matching words in <mark> tags.
+type SearchResult struct {
+	ItemType    string            `json:"item_type"`
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Creator     string            `json:"creator"`
+	Genre       string            `json:"genre"`
+	Format      string            `json:"format"`
+	ReleaseDate partialdate.Date  `json:"release_date"`
+	ShelfID     uuid.UUID         `json:"shelf_id"`
+	CaseID      uuid.UUID         `json:"case_id"`
+	Rank        float32           `json:"rank"`
+	Highlights  map[string]string `json:"highlights"`
+}
+
+// FacetCount is how many results have a value of a facet. Label names the value when the value
+// is an ID.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+type SearchResponse struct {
+	Query   string                  `json:"query"`
+	Total   int                     `json:"total"`
+	Results []SearchResult          `json:"results"`
+	Facets  map[string][]FacetCount `json:"facets"`
+}
+
+// LocationSearchResults are the results of a search of all of a user's locations that are at
+// one of them.
+type LocationSearchResults struct {
+	LocationID   uuid.UUID      `json:"location_id"`
+	LocationName string         `json:"location_name"`
+	Results      []SearchResult `json:"results"`
+}
+
+type UserSearchResponse struct {
+	Query     string                  `json:"query"`
+	Total     int                     `json:"total"`
+	Locations []LocationSearchResults `json:"locations"`
+}
+
+func searchResult(row database.SearchLocationItemsRow) SearchResult {
+	result := SearchResult{
+		ItemType:    row.ItemType,
+		ID:          row.ID,
+		Title:       row.Title,
+		Creator:     row.Creator,
+		Genre:       row.Genre,
+		Format:      row.Format,
+		ReleaseDate: partialdate.FromNullTime(row.ReleaseDate, row.ReleaseDatePrecision),
+		ShelfID:     row.ShelfID,
+		CaseID:      row.CaseID,
+		Rank:        row.Rank,
+		Highlights:  map[string]string{},
+	}
+	for field, headline := range map[string]string{
+		"title":   row.TitleHeadline,
+		"creator": row.CreatorHeadline,
+		"genre":   row.GenreHeadline,
+		"details": row.DetailsHeadline,
+	} {
+		if snippet, matched := search.Highlight(headline); matched {
+			result.Highlights[field] = snippet
+		}
+	}
+	return result
+}
+
+func searchFacetValues(row database.SearchLocationItemsRow) map[string]string {
+	values := map[string]string{
+		search.FacetType:   row.ItemType,
+		search.FacetGenre:  row.Genre,
+		search.FacetFormat: row.Format,
+		search.FacetShelf:  row.ShelfID.String(),
+	}
+	if decade, ok := partialdate.FromNullTime(row.ReleaseDate, row.ReleaseDatePrecision).Decade(); ok {
+		values[search.FacetDecade] = strconv.Itoa(decade)
+	}
+	return values
+}
+
+// searchFilters reads the facet filters from the query parameters. Each can be repeated to match
+// any of the values.
+func searchFilters(r *http.Request) (map[string][]string, error) {
+	filters := map[string][]string{}
+	for _, name := range search.FacetNames {
+		if values := r.URL.Query()[name]; len(values) > 0 {
+			filters[name] = values
+		}
+	}
+	return filters, validateSearchFilters(filters)
+}
+
+func validateSearchFilters(filters map[string][]string) error {
+	for name, values := range filters {
+		if !slices.Contains(search.FacetNames, name) {
+			return fmt.Errorf("unknown filter: %s", name)
+		}
+
+		for _, value := range values {
+			switch name {
+			case search.FacetType:
+				if _, ok := lookupItemType(value); !ok {
+					return fmt.Errorf("unknown media type: %s", value)
+				}
+			case search.FacetDecade:
+				decade, err := strconv.Atoi(value)
+				if err != nil || decade%10 != 0 {
+					return fmt.Errorf("decade must be a year ending in 0, like 1990: %s", value)
+				}
+			case search.FacetShelf:
+				if _, err := uuid.Parse(value); err != nil {
+					return fmt.Errorf("invalid shelf_id: %s", value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// searchLocation searches a location and counts the facets of the results. An empty query
+// matches every item, so that the filters alone choose the results.
+func (cfg *apiConfig) searchLocation(ctx context.Context, locationID uuid.UUID, query string, filters map[string][]string) (SearchResponse, error) {
+	rows, err := cfg.db.SearchLocationItems(ctx, database.SearchLocationItemsParams{
+		LocationID:  locationID,
+		Query:       query,
+		PrefixQuery: search.PrefixQuery(query),
+	})
+	if err != nil {
+		return SearchResponse{}, err
+	}
+
+	matches, counts := search.Facet(rows, searchFacetValues, filters)
+
+	shelfLabels := map[string]string{}
+	for _, row := range rows {
+		shelfLabels[row.ShelfID.String()] = row.CaseName + " · " + row.ShelfName
+	}
+
+	response := SearchResponse{
+		Query:   query,
+		Total:   len(matches),
+		Results: []SearchResult{},
+		Facets:  map[string][]FacetCount{},
+	}
+
+	for _, row := range matches {
+		response.Results = append(response.Results, searchResult(row))
+	}
+
+	for name, facetCounts := range counts {
+		response.Facets[name] = []FacetCount{}
+		for _, count := range facetCounts {
+			facetCount := FacetCount{Value: count.Value, Count: count.Count}
+			if name == search.FacetShelf {
+				facetCount.Label = shelfLabels[count.Value]
+			}
+			response.Facets[name] = append(response.Facets[name], facetCount)
+		}
+	}
+
+	return response, nil
+}
+
+// handlerLocationSearch searches every media type at a location. Along with the results, it counts
+// the results by media type, genre, format, decade and shelf, and those facets can be used to
+// filter the results.
+func (cfg *apiConfig) handlerLocationSearch(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to search items for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to search this location", err)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondWithError(w, http.StatusBadRequest, "No search query was provided", fmt.Errorf("no search query was provided"))
+		return
+	}
+
+	filters, err := searchFilters(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	response, err := cfg.searchLocation(r.Context(), locationID, query, filters)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search items", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// handlerUserSearch searches every media type at all of the user's locations, and groups the
+// results by location. case_id or shelf_id narrow the search to one case or shelf.
+func (cfg *apiConfig) handlerUserSearch(w http.ResponseWriter, r *http.Request) {
+	userIDString := r.PathValue("user_id")
+	if userIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No user id was provided", fmt.Errorf("no user id was provided"))
+		return
+	}
+
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	// Validate the user is authorized to search for this user.
+	requestUserID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get requester ID", err)
+		return
+	}
+	if userID != requestUserID {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to search for this user", err)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondWithError(w, http.StatusBadRequest, "No search query was provided", fmt.Errorf("no search query was provided"))
+		return
+	}
+
+	var caseID, shelfID uuid.UUID
+	for param, id := range map[string]*uuid.UUID{"case_id": &caseID, "shelf_id": &shelfID} {
+		if idString := r.URL.Query().Get(param); idString != "" {
+			*id, err = uuid.Parse(idString)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s", param), err)
+				return
+			}
+		}
+	}
+
+	dbLocations, err := cfg.db.GetUserLocations(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get locations for user", err)
+		return
+	}
+	slices.SortFunc(dbLocations, func(a, b database.GetUserLocationsRow) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	rows, err := cfg.db.SearchUserItems(r.Context(), database.SearchUserItemsParams{
+		UserID:      userID,
+		Query:       query,
+		PrefixQuery: search.PrefixQuery(query),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search items", err)
+		return
+	}
+
+	results := map[uuid.UUID][]SearchResult{}
+	total := 0
+	for _, row := range rows {
+		if caseID != uuid.Nil && row.CaseID != caseID {
+			continue
+		}
+		if shelfID != uuid.Nil && row.ShelfID != shelfID {
+			continue
+		}
+		results[row.LocationID] = append(results[row.LocationID], searchResult(database.SearchLocationItemsRow(row)))
+		total++
+	}
+
+	response := UserSearchResponse{
+		Query:     query,
+		Total:     total,
+		Locations: []LocationSearchResults{},
+	}
+	for _, location := range dbLocations {
+		if len(results[location.ID]) == 0 {
+			continue
+		}
+		response.Locations = append(response.Locations, LocationSearchResults{
+			LocationID:   location.ID,
+			LocationName: location.Name,
+			Results:      results[location.ID],
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// ShelfPath is where an item is kept. Path names the location, case and shelf, in that order.
+type ShelfPath struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	CaseID       uuid.UUID `json:"case_id"`
+	CaseName     string    `json:"case_name"`
+	ShelfID      uuid.UUID `json:"shelf_id"`
+	ShelfName    string    `json:"shelf_name"`
+	Path         string    `json:"path"`
+}
+
+type BarcodeMatch struct {
+	ItemType     string     `json:"item_type"`
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Creator      string     `json:"creator"`
+	Format       string     `json:"format"`
+	Barcode      string     `json:"barcode"`
+	MissingSince *time.Time `json:"missing_since"`
+	Shelf        ShelfPath  `json:"shelf"`
+}
+
+// handlerBarcodeLookup finds the items of every media type with a barcode, at all of the
+// requester's locations or only the one given by location_id.
+func (cfg *apiConfig) handlerBarcodeLookup(w http.ResponseWriter, r *http.Request) {
+	barcode := r.PathValue("barcode")
+	if barcode == "" {
+		respondWithError(w, http.StatusBadRequest, "No barcode was provided", fmt.Errorf("no barcode was provided"))
+		return
+	}
+
+	// Only items at locations the requester is a member of are returned.
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	locationID := uuid.Nil
+	if locationIDString := r.URL.Query().Get("location_id"); locationIDString != "" {
+		locationID, err = uuid.Parse(locationIDString)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+			return
+		}
+
+		if err := cfg.authorizeMember(locationID, *r); err != nil {
+			respondWithError(w, http.StatusUnauthorized, "User is not authorized to search this location", err)
+			return
+		}
+	}
+
+	rows, err := cfg.db.GetMemberItemsByBarcode(r.Context(), database.GetMemberItemsByBarcodeParams{
+		UserID:  requesterID,
+		Barcode: barcode,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search items", err)
+		return
+	}
+
+	matches := []BarcodeMatch{}
+	for _, row := range rows {
+		if locationID != uuid.Nil && row.LocationID != locationID {
+			continue
+		}
+
+		matches = append(matches, BarcodeMatch{
+			ItemType:     row.ItemType,
+			ID:           row.ID,
+			Title:        row.Title,
+			Creator:      row.Creator,
+			Format:       row.Format,
+			Barcode:      row.Barcode,
+			MissingSince: nullTimeToPointer(row.MissingSince),
+			Shelf: ShelfPath{
+				LocationID:   row.LocationID,
+				LocationName: row.LocationName,
+				CaseID:       row.CaseID,
+				CaseName:     row.CaseName,
+				ShelfID:      row.ShelfID,
+				ShelfName:    row.ShelfName,
+				Path:         row.LocationName + " · " + row.CaseName + " · " + row.ShelfName,
+			},
+		})
+	}
+
+	if len(matches) == 0 {
+		respondWithError(w, http.StatusNotFound, "No items have this barcode", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, matches)
+}
+
+// handlerLocationAutocomplete suggests item titles of any media type for the text typed so far.
+func (cfg *apiConfig) handlerLocationAutocomplete(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to search items for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to search this location", err)
+		return
+	}
+
+	limit := defaultAutocompleteLimit
+	if limitString := r.URL.Query().Get("limit"); limitString != "" {
+		limit, err = strconv.Atoi(limitString)
+		if err != nil || limit < 1 || limit > maxAutocompleteLimit {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAutocompleteLimit), err)
+			return
+		}
+	}
+
+	suggestions := []Suggestion{}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondWithJSON(w, http.StatusOK, suggestions)
+		return
+	}
+
+	rows, err := cfg.db.GetLocationAutocomplete(r.Context(), database.GetLocationAutocompleteParams{
+		LocationID: locationID,
+		Query:      query,
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get suggestions", err)
+		return
+	}
+
+	for _, row := range rows {
+		suggestions = append(suggestions, Suggestion{
+			ItemType: row.ItemType,
+			ID:       row.ID,
+			Title:    row.Title,
+			Creator:  row.Creator,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+type Series struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	LocationID uuid.UUID `json:"location_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Episode struct {
+	ID            uuid.UUID     `json:"id"`
+	SeriesID      uuid.UUID     `json:"series_id"`
+	SeasonNumber  int32         `json:"season_number"`
+	EpisodeNumber int32         `json:"episode_number"`
+	Title         string        `json:"title"`
+	ShowID        uuid.NullUUID `json:"show_id"`
+	DiscNumber    int32         `json:"disc_number"`
+	Watched       bool          `json:"watched"`
+	WatchedAt     *time.Time    `json:"watched_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+func (cfg *apiConfig) handlerSeriesCreate(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Title      string    `json:"title"`
+		LocationID uuid.UUID `json:"location_id"`
+	}
+
+	type response struct {
+		Series
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := parameters{}
+	err := decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	if len(params.Title) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Series title is required", nil)
+		return
+	}
+
+	err = cfg.authorizeMember(params.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to create series in this location", err)
+		return
+	}
+
+	series, err := cfg.db.CreateSeries(r.Context(), database.CreateSeriesParams{
+		Title:      params.Title,
+		LocationID: params.LocationID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create series", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response{
+		Series: Series{
+			ID:         series.ID,
+			Title:      series.Title,
+			LocationID: series.LocationID,
+			CreatedAt:  series.CreatedAt,
+			UpdatedAt:  series.UpdatedAt,
+		},
+	})
+}
+
+func (cfg *apiConfig) handlerEpisodeCreate(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		SeasonNumber  int32         `json:"season_number"`
+		EpisodeNumber int32         `json:"episode_number"`
+		Title         string        `json:"title"`
+		ShowID        uuid.NullUUID `json:"show_id"`
+		DiscNumber    int32         `json:"disc_number"`
+	}
+
+	type response struct {
+		Episode
+	}
+
+	seriesID, err := uuid.Parse(r.PathValue("series_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid series ID", err)
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	params := parameters{}
+	err = decoder.Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Was unable to decode parameters", err)
+		return
+	}
+
+	if params.DiscNumber == 0 {
+		params.DiscNumber = 1
+	}
+
+	series, err := cfg.db.GetSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Series not found", err)
+		return
+	}
+
+	err = cfg.authorizeMember(series.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to create episodes in this location", err)
+		return
+	}
+
+	// The show holding the episode must be at the same location as the series.
+	if params.ShowID.Valid {
+		showLocation, err := cfg.db.GetShowLocation(r.Context(), params.ShowID.UUID)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to get show location", err)
+			return
+		}
+		if showLocation.ID != series.LocationID {
+			respondWithError(w, http.StatusBadRequest, "Show is not at the same location as the series", nil)
+			return
+		}
+	}
+
+	episode, err := cfg.db.CreateEpisode(r.Context(), database.CreateEpisodeParams{
+		SeriesID:      series.ID,
+		SeasonNumber:  params.SeasonNumber,
+		EpisodeNumber: params.EpisodeNumber,
+		Title:         params.Title,
+		ShowID:        params.ShowID,
+		DiscNumber:    params.DiscNumber,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create episode", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, response{
+		Episode: Episode{
+			ID:            episode.ID,
+			SeriesID:      episode.SeriesID,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			Title:         episode.Title,
+			ShowID:        episode.ShowID,
+			DiscNumber:    episode.DiscNumber,
+			CreatedAt:     episode.CreatedAt,
+			UpdatedAt:     episode.UpdatedAt,
+		},
+	})
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+type NextEpisode struct {
+	Episode
+	SeriesTitle string        `json:"series_title"`
+	ShelfID     uuid.NullUUID `json:"shelf_id"`
+	ShelfName   string        `json:"shelf_name"`
+}
+
+func (cfg *apiConfig) handlerSeriesGetByID(w http.ResponseWriter, r *http.Request) {
+	seriesIDString := r.PathValue("series_id")
+	if seriesIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No series id was provided", fmt.Errorf("no series id was provided"))
+		return
+	}
+
+	seriesID, err := uuid.Parse(seriesIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid series ID", err)
+		return
+	}
+
+	dbSeries, err := cfg.db.GetSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Series not found", err)
+		return
+	}
+
+	err = cfg.authorizeMember(dbSeries.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get series at this location", err)
+		return
+	}
+
+	respondWithEntity(w, r, dbSeries.UpdatedAt, Series{
+		ID:         dbSeries.ID,
+		Title:      dbSeries.Title,
+		LocationID: dbSeries.LocationID,
+		CreatedAt:  dbSeries.CreatedAt,
+		UpdatedAt:  dbSeries.UpdatedAt,
+	})
+}
+
+func (cfg *apiConfig) handlerSeriesGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get series for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get series for this location", err)
+		return
+	}
+
+	dbSeries, err := cfg.db.GetSeriesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "No series found for that location", err)
+		return
+	}
+
+	series := []Series{}
+
+	for _, dbS := range dbSeries {
+		series = append(series, Series{
+			ID:         dbS.ID,
+			Title:      dbS.Title,
+			LocationID: dbS.LocationID,
+			CreatedAt:  dbS.CreatedAt,
+			UpdatedAt:  dbS.UpdatedAt,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, series)
+}
+
+func (cfg *apiConfig) handlerEpisodesGetBySeries(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := uuid.Parse(r.PathValue("series_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid series ID", err)
+		return
+	}
+
+	dbSeries, err := cfg.db.GetSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Series not found", err)
+		return
+	}
+
+	err = cfg.authorizeMember(dbSeries.LocationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get episodes at this location", err)
+		return
+	}
+
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	dbEpisodes, err := cfg.db.GetEpisodesBySeriesForUser(r.Context(), database.GetEpisodesBySeriesForUserParams{
+		UserID:   requesterID,
+		SeriesID: dbSeries.ID,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get episodes", err)
+		return
+	}
+
+	episodes := []Episode{}
+
+	for _, dbEpisode := range dbEpisodes {
+		episodes = append(episodes, Episode{
+			ID:            dbEpisode.ID,
+			SeriesID:      dbEpisode.SeriesID,
+			SeasonNumber:  dbEpisode.SeasonNumber,
+			EpisodeNumber: dbEpisode.EpisodeNumber,
+			Title:         dbEpisode.Title,
+			ShowID:        dbEpisode.ShowID,
+			DiscNumber:    dbEpisode.DiscNumber,
+			Watched:       dbEpisode.WatchedAt.Valid,
+			WatchedAt:     nullTimeToPointer(dbEpisode.WatchedAt),
+			CreatedAt:     dbEpisode.CreatedAt,
+			UpdatedAt:     dbEpisode.UpdatedAt,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, episodes)
+}
+
+func (cfg *apiConfig) handlerEpisodesGetByShow(w http.ResponseWriter, r *http.Request) {
+	showID, err := uuid.Parse(r.PathValue("show_id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid show ID", err)
+		return
+	}
+
+	// Validate user is authorized to get episodes at the location of requested show.
+	showLocation, err := cfg.db.GetShowLocation(r.Context(), showID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get show location", err)
+		return
+	}
+
+	err = cfg.authorizeMember(showLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get episodes at this location", err)
+		return
+	}
+
+	requesterID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Unable to get requester ID", err)
+		return
+	}
+
+	dbEpisodes, err := cfg.db.GetEpisodesByShowForUser(r.Context(), database.GetEpisodesByShowForUserParams{
+		UserID: requesterID,
+		ShowID: uuid.NullUUID{UUID: showID, Valid: true},
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get episodes", err)
+		return
+	}
+
+	episodes := []Episode{}
+
+	for _, dbEpisode := range dbEpisodes {
+		episodes = append(episodes, Episode{
+			ID:            dbEpisode.ID,
+			SeriesID:      dbEpisode.SeriesID,
+			SeasonNumber:  dbEpisode.SeasonNumber,
+			EpisodeNumber: dbEpisode.EpisodeNumber,
+			Title:         dbEpisode.Title,
+			ShowID:        dbEpisode.ShowID,
+			DiscNumber:    dbEpisode.DiscNumber,
+			Watched:       dbEpisode.WatchedAt.Valid,
+			WatchedAt:     nullTimeToPointer(dbEpisode.WatchedAt),
+			CreatedAt:     dbEpisode.CreatedAt,
+			UpdatedAt:     dbEpisode.UpdatedAt,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, episodes)
+}
+
+func (cfg *apiConfig) handlerGetUserNextEpisodes(w http.ResponseWriter, r *http.Request) {
+	userIDString := r.PathValue("user_id")
+	if userIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No user id was provided", fmt.Errorf("no user id was provided"))
+		return
+	}
+
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	// Validate the user is authorized to view progress for this user.
+	requestUserID, err := cfg.getRequesterID(r)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get requester ID", err)
+		return
+	}
+	if userID != requestUserID {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to view progress for this user", err)
+		return
+	}
+
+	dbEpisodes, err := cfg.db.GetNextEpisodesForUser(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get next episodes", err)
+		return
+	}
+
+	nextEpisodes := []NextEpisode{}
+
+	for _, dbEpisode := range dbEpisodes {
+		nextEpisodes = append(nextEpisodes, NextEpisode{
+			Episode: Episode{
+				ID:            dbEpisode.ID,
+				SeriesID:      dbEpisode.SeriesID,
+				SeasonNumber:  dbEpisode.SeasonNumber,
+				EpisodeNumber: dbEpisode.EpisodeNumber,
+				Title:         dbEpisode.Title,
+				ShowID:        dbEpisode.ShowID,
+				DiscNumber:    dbEpisode.DiscNumber,
+				CreatedAt:     dbEpisode.CreatedAt,
+				UpdatedAt:     dbEpisode.UpdatedAt,
+			},
+			SeriesTitle: dbEpisode.SeriesTitle,
+			ShelfID:     dbEpisode.ShelfID,
+			ShelfName:   dbEpisode.ShelfName.String,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, nextEpisodes)
+}
+
+func nullTimeToPointer(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
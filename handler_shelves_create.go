@@ -1,26 +1,80 @@
 package main
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
 	"github.com/google/uuid"
 )
 
+// Shelf capacity is optional. Capacity is a number of items, and WidthCM is the usable
+// width that the thickness of the items on the shelf is compared against. Barcode is the
+// code printed on the shelf's label, for scan sessions.
 type Shelf struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
 	CaseID    uuid.UUID `json:"case_id"`
+	Barcode   string    `json:"barcode"`
+	Capacity  *int32    `json:"capacity"`
+	WidthCM   *float64  `json:"width_cm"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+func shelfFromDB(dbShelf database.Shelf) Shelf {
+	shelf := Shelf{
+		ID:        dbShelf.ID,
+		Name:      dbShelf.Name,
+		CaseID:    dbShelf.CaseID,
+		Barcode:   dbShelf.Barcode,
+		CreatedAt: dbShelf.CreatedAt,
+		UpdatedAt: dbShelf.UpdatedAt,
+	}
+	if dbShelf.Capacity.Valid {
+		shelf.Capacity = &dbShelf.Capacity.Int32
+	}
+	if dbShelf.WidthCm.Valid {
+		shelf.WidthCM = &dbShelf.WidthCm.Float64
+	}
+	return shelf
+}
+
+// validateShelfCapacity rejects capacities that can never be met.
+func validateShelfCapacity(capacity *int32, widthCM *float64) error {
+	if capacity != nil && *capacity <= 0 {
+		return fmt.Errorf("capacity must be greater than 0")
+	}
+	if widthCM != nil && *widthCM <= 0 {
+		return fmt.Errorf("width_cm must be greater than 0")
+	}
+	return nil
+}
+
+func nullInt32(i *int32) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *i, Valid: true}
+}
+
+func nullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
 func (cfg *apiConfig) handlerShelfCreate(w http.ResponseWriter, r *http.Request) {
 	type parameters struct {
-		Name   string    `json:"name"`
-		CaseID uuid.UUID `json:"case_id"`
+		Name     string    `json:"name"`
+		CaseID   uuid.UUID `json:"case_id"`
+		Capacity *int32    `json:"capacity"`
+		WidthCM  *float64  `json:"width_cm"`
 	}
 	type response struct {
 		Shelf
@@ -39,6 +93,12 @@ func (cfg *apiConfig) handlerShelfCreate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = validateShelfCapacity(params.Capacity, params.WidthCM)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	// Validate user is authorized to create shelves in this case
 	caseLocation, err := cfg.db.GetCaseLocation(r.Context(), params.CaseID)
 	if err != nil {
@@ -53,21 +113,19 @@ func (cfg *apiConfig) handlerShelfCreate(w http.ResponseWriter, r *http.Request)
 	}
 
 	shelf, err := cfg.db.CreateShelf(r.Context(), database.CreateShelfParams{
-		Name:   params.Name,
-		CaseID: params.CaseID,
+		Name:     params.Name,
+		CaseID:   params.CaseID,
+		Capacity: nullInt32(params.Capacity),
+		WidthCm:  nullFloat64(params.WidthCM),
 	})
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Unable to create shelf", err)
 		return
 	}
 
+	cfg.publishEvent(r.Context(), caseLocation.ID, webhooks.ShelfCreated, shelfFromDB(shelf))
+
 	respondWithJSON(w, http.StatusCreated, response{
-		Shelf: Shelf{
-			ID:        shelf.ID,
-			Name:      shelf.Name,
-			CaseID:    shelf.CaseID,
-			CreatedAt: shelf.CreatedAt,
-			UpdatedAt: shelf.UpdatedAt,
-		},
+		Shelf: shelfFromDB(shelf),
 	})
 }
@@ -4,9 +4,50 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Rodabaugh/digitalshelf/internal/database"
 	"github.com/google/uuid"
 )
 
+type ShelfWithFullness struct {
+	Shelf
+	Fullness ShelfFullness `json:"fullness"`
+}
+
+// ShelfFullness describes how full a shelf is. Percent is the larger of the item count
+// compared to the capacity and the used width compared to the shelf's width, and is null
+// when the shelf has neither. Items that haven't been measured don't count towards the width.
+type ShelfFullness struct {
+	ItemCount       int64    `json:"item_count"`
+	UsedWidthCM     float64  `json:"used_width_cm"`
+	UnmeasuredItems int64    `json:"unmeasured_items"`
+	Percent         *float64 `json:"percent"`
+	Full            bool     `json:"full"`
+}
+
+func shelfFullness(shelf Shelf, itemCount int64, usedWidthCM float64, unmeasuredItems int64) ShelfFullness {
+	fullness := ShelfFullness{
+		ItemCount:       itemCount,
+		UsedWidthCM:     usedWidthCM,
+		UnmeasuredItems: unmeasuredItems,
+	}
+
+	var percent float64
+	known := false
+	if shelf.Capacity != nil {
+		percent = max(percent, float64(itemCount)/float64(*shelf.Capacity)*100)
+		known = true
+	}
+	if shelf.WidthCM != nil {
+		percent = max(percent, usedWidthCM / *shelf.WidthCM * 100)
+		known = true
+	}
+	if known {
+		fullness.Percent = &percent
+		fullness.Full = percent >= 100
+	}
+	return fullness
+}
+
 func (cfg *apiConfig) handlerShelvesGet(w http.ResponseWriter, r *http.Request) {
 	dbShelves, err := cfg.db.GetShelves(r.Context())
 	if err != nil {
@@ -17,13 +58,7 @@ func (cfg *apiConfig) handlerShelvesGet(w http.ResponseWriter, r *http.Request)
 	shelves := []Shelf{}
 
 	for _, dbShelf := range dbShelves {
-		shelves = append(shelves, Shelf{
-			ID:        dbShelf.ID,
-			Name:      dbShelf.Name,
-			CaseID:    dbShelf.CaseID,
-			CreatedAt: dbShelf.CreatedAt,
-			UpdatedAt: dbShelf.UpdatedAt,
-		})
+		shelves = append(shelves, shelfFromDB(dbShelf))
 	}
 
 	respondWithJSON(w, http.StatusOK, shelves)
@@ -54,21 +89,28 @@ func (cfg *apiConfig) handlerShelvesGetByCase(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	dbShelves, err := cfg.db.GetShelvesByCase(r.Context(), caseID)
+	dbShelves, err := cfg.db.GetShelvesByCaseWithFullness(r.Context(), caseID)
 	if err != nil {
 		respondWithError(w, http.StatusNotFound, "No shelves found for that case", err)
 		return
 	}
 
-	shelves := []Shelf{}
+	shelves := []ShelfWithFullness{}
 
 	for _, dbShelf := range dbShelves {
-		shelves = append(shelves, Shelf{
+		shelf := shelfFromDB(database.Shelf{
 			ID:        dbShelf.ID,
-			Name:      dbShelf.Name,
-			CaseID:    dbShelf.CaseID,
 			CreatedAt: dbShelf.CreatedAt,
 			UpdatedAt: dbShelf.UpdatedAt,
+			Name:      dbShelf.Name,
+			CaseID:    dbShelf.CaseID,
+			Barcode:   dbShelf.Barcode,
+			Capacity:  dbShelf.Capacity,
+			WidthCm:   dbShelf.WidthCm,
+		})
+		shelves = append(shelves, ShelfWithFullness{
+			Shelf:    shelf,
+			Fullness: shelfFullness(shelf, dbShelf.ItemCount, dbShelf.UsedWidthCm, dbShelf.UnmeasuredItems),
 		})
 	}
 
@@ -106,11 +148,5 @@ func (cfg *apiConfig) handlerShelfGetByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, Shelf{
-		ID:        dbShelf.ID,
-		Name:      dbShelf.Name,
-		CaseID:    dbShelf.CaseID,
-		CreatedAt: dbShelf.CreatedAt,
-		UpdatedAt: dbShelf.UpdatedAt,
-	})
+	respondWithEntity(w, r, dbShelf.UpdatedAt, shelfFromDB(dbShelf))
 }
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+// ShelfItem is an item of any media type, as it sits on a shelf.
+type ShelfItem struct {
+	ItemType    string    `json:"item_type"`
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Position    int32     `json:"position"`
+	ThicknessCM float64   `json:"thickness_cm"`
+}
+
+// parseShelfID reads the shelf ID from the path and checks that the requester is a member
+// of the shelf's location, responding with an error if not.
+func (cfg *apiConfig) parseShelfID(w http.ResponseWriter, r *http.Request, action string) (uuid.UUID, bool) {
+	shelfIDString := r.PathValue("shelf_id")
+	if shelfIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No shelf id was provided", fmt.Errorf("no shelf id was provided"))
+		return uuid.Nil, false
+	}
+
+	shelfID, err := uuid.Parse(shelfIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid shelf ID", err)
+		return uuid.Nil, false
+	}
+
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return uuid.Nil, false
+	}
+
+	err = cfg.authorizeMember(shelfLocation.ID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s this shelf", action), err)
+		return uuid.Nil, false
+	}
+
+	return shelfID, true
+}
+
+// handlerShelfUpdate changes a shelf's name, barcode and capacity. Only the fields that are
+// sent are changed, and sending null for capacity or width_cm removes it. If-Match is checked
+// against the shelf's ETag, so that a client doesn't overwrite a change it hasn't seen.
+func (cfg *apiConfig) handlerShelfUpdate(w http.ResponseWriter, r *http.Request) {
+	shelfID, ok := cfg.parseShelfID(w, r, "modify")
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	updatedAt, err := qtx.LockShelf(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return
+	}
+
+	if !checkIfMatch(w, r, updatedAt, "shelf") {
+		return
+	}
+
+	dbShelf, err := qtx.GetShelfByID(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Shelf not found", err)
+		return
+	}
+
+	shelf := shelfFromDB(dbShelf)
+	params := struct {
+		Name     string   `json:"name"`
+		Barcode  string   `json:"barcode"`
+		Capacity *int32   `json:"capacity"`
+		WidthCM  *float64 `json:"width_cm"`
+	}{
+		Name:     shelf.Name,
+		Barcode:  shelf.Barcode,
+		Capacity: shelf.Capacity,
+		WidthCM:  shelf.WidthCM,
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if len(params.Name) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Shelf name is required", nil)
+		return
+	}
+
+	if len(params.Barcode) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Shelf barcode can't be empty", nil)
+		return
+	}
+
+	err = validateShelfCapacity(params.Capacity, params.WidthCM)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	dbShelf, err = qtx.UpdateShelf(r.Context(), database.UpdateShelfParams{
+		ID:       shelfID,
+		Name:     params.Name,
+		Capacity: nullInt32(params.Capacity),
+		WidthCm:  nullFloat64(params.WidthCM),
+		Barcode:  params.Barcode,
+	})
+	if isUniqueViolation(err) {
+		respondWithError(w, http.StatusConflict, "That barcode is already used by another shelf", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to update shelf", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	shelfLocation, err := cfg.db.GetShelfLocation(r.Context(), shelfID)
+	if err != nil {
+		log.Printf("Unable to get shelf location for %s event: %v", webhooks.ShelfUpdated, err)
+	} else {
+		cfg.publishEvent(r.Context(), shelfLocation.ID, webhooks.ShelfUpdated, shelfFromDB(dbShelf))
+	}
+
+	respondWithEntity(w, r, dbShelf.UpdatedAt, shelfFromDB(dbShelf))
+}
+
+// updateShelf applies an offline edit to a shelf, like handlerShelfUpdate. If the shelf has
+// been updated since baseUpdatedAt, nothing is changed and errChangeConflict is returned along
+// with the shelf as it is now.
+func updateShelf(ctx context.Context, db *database.Queries, shelfID uuid.UUID, baseUpdatedAt *time.Time, body json.RawMessage, allowLocation func(uuid.UUID) error) (Shelf, error) {
+	updatedAt, err := db.LockShelf(ctx, shelfID)
+	if err != nil {
+		return Shelf{}, err
+	}
+
+	location, err := db.GetShelfLocation(ctx, shelfID)
+	if err != nil {
+		return Shelf{}, err
+	}
+	if err := allowLocation(location.ID); err != nil {
+		return Shelf{}, err
+	}
+
+	dbShelf, err := db.GetShelfByID(ctx, shelfID)
+	if err != nil {
+		return Shelf{}, err
+	}
+	shelf := shelfFromDB(dbShelf)
+	if baseUpdatedAt != nil && !updatedAt.Equal(*baseUpdatedAt) {
+		return shelf, errChangeConflict
+	}
+
+	params := struct {
+		Name     string   `json:"name"`
+		Barcode  string   `json:"barcode"`
+		Capacity *int32   `json:"capacity"`
+		WidthCM  *float64 `json:"width_cm"`
+	}{
+		Name:     shelf.Name,
+		Barcode:  shelf.Barcode,
+		Capacity: shelf.Capacity,
+		WidthCM:  shelf.WidthCM,
+	}
+	if err := json.Unmarshal(body, &params); err != nil {
+		return Shelf{}, invalidChange("Invalid shelf: %v", err)
+	}
+
+	if len(params.Name) == 0 {
+		return Shelf{}, invalidChange("Shelf name is required")
+	}
+	if len(params.Barcode) == 0 {
+		return Shelf{}, invalidChange("Shelf barcode can't be empty")
+	}
+	if err := validateShelfCapacity(params.Capacity, params.WidthCM); err != nil {
+		return Shelf{}, invalidChange("%s", err.Error())
+	}
+
+	dbShelf, err = db.UpdateShelf(ctx, database.UpdateShelfParams{
+		ID:       shelfID,
+		Name:     params.Name,
+		Capacity: nullInt32(params.Capacity),
+		WidthCm:  nullFloat64(params.WidthCM),
+		Barcode:  params.Barcode,
+	})
+	if isUniqueViolation(err) {
+		return Shelf{}, invalidChange("That barcode is already used by another shelf")
+	}
+	if err != nil {
+		return Shelf{}, err
+	}
+	return shelfFromDB(dbShelf), nil
+}
+
+// handlerShelfItemsGet lists the items on a shelf, of every media type, in shelf order.
+func (cfg *apiConfig) handlerShelfItemsGet(w http.ResponseWriter, r *http.Request) {
+	shelfID, ok := cfg.parseShelfID(w, r, "get items on")
+	if !ok {
+		return
+	}
+
+	dbItems, err := cfg.db.GetShelfItems(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf items", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, shelfItemsFromDB(dbItems))
+}
+
+// handlerShelfReorder moves the listed items to the front of the shelf, in the order given.
+// Items that aren't listed keep their order after them.
+func (cfg *apiConfig) handlerShelfReorder(w http.ResponseWriter, r *http.Request) {
+	type itemRef struct {
+		ItemType string    `json:"item_type"`
+		ItemID   uuid.UUID `json:"item_id"`
+	}
+	type parameters struct {
+		Items []itemRef `json:"items"`
+	}
+
+	shelfID, ok := cfg.parseShelfID(w, r, "reorder")
+	if !ok {
+		return
+	}
+
+	params := parameters{}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	current, err := qtx.GetShelfItems(r.Context(), shelfID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf items", err)
+		return
+	}
+
+	onShelf := map[itemRef]database.LocationItem{}
+	for _, item := range current {
+		onShelf[itemRef{ItemType: item.ItemType, ItemID: item.ID}] = item
+	}
+
+	ordered := []database.LocationItem{}
+	for _, ref := range params.Items {
+		item, ok := onShelf[ref]
+		if !ok {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("The %s %s is not on this shelf, or is listed twice", ref.ItemType, ref.ItemID), nil)
+			return
+		}
+		ordered = append(ordered, item)
+		delete(onShelf, ref)
+	}
+	for _, item := range current {
+		if _, ok := onShelf[itemRef{ItemType: item.ItemType, ItemID: item.ID}]; ok {
+			ordered = append(ordered, item)
+		}
+	}
+
+	changes, err := writeShelfOrder(r.Context(), qtx, ordered)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to reorder shelf", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	respondWithJSON(w, http.StatusOK, shelfItemsFromDB(ordered))
+}
+
+// handlerShelfSort rewrites the positions of every item on a shelf, sorted by title or by release date.
+func (cfg *apiConfig) handlerShelfSort(w http.ResponseWriter, r *http.Request) {
+	type parameters struct {
+		Mode string `json:"mode"`
+	}
+
+	shelfID, ok := cfg.parseShelfID(w, r, "sort")
+	if !ok {
+		return
+	}
+
+	params := parameters{}
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	var sorted []database.LocationItem
+	switch params.Mode {
+	case "title":
+		sorted, err = qtx.GetShelfItemsByTitle(r.Context(), shelfID)
+	case "release_date":
+		sorted, err = qtx.GetShelfItemsByReleaseDate(r.Context(), shelfID)
+	default:
+		respondWithError(w, http.StatusBadRequest, "mode must be title or release_date", fmt.Errorf("invalid sort mode: %s", params.Mode))
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelf items", err)
+		return
+	}
+
+	changes, err := writeShelfOrder(r.Context(), qtx, sorted)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to sort shelf", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	for _, change := range changes {
+		cfg.publishItemChange(r.Context(), change)
+	}
+
+	respondWithJSON(w, http.StatusOK, shelfItemsFromDB(sorted))
+}
+
+// writeShelfOrder numbers the items from 0 in the order given. Items already in place aren't
+// written. It returns an item.updated change for each item that was, to publish once the
+// positions are saved.
+func writeShelfOrder(ctx context.Context, db *database.Queries, items []database.LocationItem) ([]itemChange, error) {
+	changes := []itemChange{}
+	for i := range items {
+		position := int32(i)
+		if items[i].Position == position {
+			continue
+		}
+
+		t, ok := lookupItemType(items[i].ItemType)
+		if !ok {
+			return nil, fmt.Errorf("unknown item type: %s", items[i].ItemType)
+		}
+
+		err := t.itemSetPosition(ctx, db, items[i].ID, position)
+		if err != nil {
+			return nil, err
+		}
+		items[i].Position = position
+
+		change, err := updatedItemChange(ctx, db, t, items[i].ID, items[i].LocationID)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func shelfItemsFromDB(dbItems []database.LocationItem) []ShelfItem {
+	items := []ShelfItem{}
+	for _, dbItem := range dbItems {
+		items = append(items, ShelfItem{
+			ItemType:    dbItem.ItemType,
+			ID:          dbItem.ID,
+			Title:       dbItem.Title,
+			Position:    dbItem.Position,
+			ThicknessCM: dbItem.ThicknessCm,
+		})
+	}
+	return items
+}
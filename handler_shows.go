@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/partialdate"
+	"github.com/google/uuid"
+)
+
+type Show struct {
+	ID                uuid.UUID           `json:"id"`
+	Title             string              `json:"title"`
+	Season            string              `json:"season"`
+	Genre             string              `json:"genre"`
+	Actors            string              `json:"actors"`
+	Writer            string              `json:"writer"`
+	Director          string              `json:"director"`
+	Barcode           string              `json:"barcode"`
+	Format            string              `json:"format"`
+	ShelfID           uuid.UUID           `json:"shelf_id"`
+	ReleaseDate       partialdate.Date    `json:"release_date"`
+	RuntimeMinutes    int32               `json:"runtime_minutes"`
+	ContentRating     string              `json:"content_rating"`
+	DiscRegion        string              `json:"disc_region"`
+	AudioLanguages    string              `json:"audio_languages"`
+	SubtitleLanguages string              `json:"subtitle_languages"`
+	Position          int32               `json:"position"`
+	ThicknessCM       float64             `json:"thickness_cm"`
+	MissingSince      *time.Time          `json:"missing_since"`
+	CustomFields      customfields.Values `json:"custom_fields"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+}
+
+type showParams struct {
+	Title             string              `json:"title"`
+	Season            string              `json:"season"`
+	Genre             string              `json:"genre"`
+	Actors            string              `json:"actors"`
+	Writer            string              `json:"writer"`
+	Director          string              `json:"director"`
+	Barcode           string              `json:"barcode"`
+	Format            string              `json:"format"`
+	ShelfID           uuid.UUID           `json:"shelf_id"`
+	ReleaseDate       partialdate.Date    `json:"release_date"`
+	RuntimeMinutes    int32               `json:"runtime_minutes"`
+	ContentRating     string              `json:"content_rating"`
+	DiscRegion        string              `json:"disc_region"`
+	AudioLanguages    string              `json:"audio_languages"`
+	SubtitleLanguages string              `json:"subtitle_languages"`
+	ThicknessCM       float64             `json:"thickness_cm"`
+	CustomFields      customfields.Values `json:"custom_fields"`
+}
+
+var showItems = &itemType[database.Show, Show, showParams]{
+	name:   "show",
+	plural: "shows",
+
+	toItem: func(dbShow database.Show) Show {
+		return Show{
+			ID:                dbShow.ID,
+			Title:             dbShow.Title,
+			Season:            dbShow.Season,
+			Genre:             dbShow.Genre,
+			Actors:            dbShow.Actors,
+			Writer:            dbShow.Writer,
+			Director:          dbShow.Director,
+			Barcode:           dbShow.Barcode,
+			Format:            dbShow.Format,
+			ShelfID:           dbShow.ShelfID,
+			ReleaseDate:       partialdate.FromNullTime(dbShow.ReleaseDate, dbShow.ReleaseDatePrecision),
+			RuntimeMinutes:    dbShow.RuntimeMinutes,
+			ContentRating:     dbShow.ContentRating,
+			DiscRegion:        dbShow.DiscRegion,
+			AudioLanguages:    dbShow.AudioLanguages,
+			SubtitleLanguages: dbShow.SubtitleLanguages,
+			Position:          dbShow.Position,
+			ThicknessCM:       dbShow.ThicknessCm,
+			MissingSince:      nullTimeToPointer(dbShow.MissingSince),
+			CustomFields:      customFieldsFromDB(dbShow.CustomFields),
+			CreatedAt:         dbShow.CreatedAt,
+			UpdatedAt:         dbShow.UpdatedAt,
+		}
+	},
+	toParams: func(dbShow database.Show) showParams {
+		return showParams{
+			Title:             dbShow.Title,
+			Season:            dbShow.Season,
+			Genre:             dbShow.Genre,
+			Actors:            dbShow.Actors,
+			Writer:            dbShow.Writer,
+			Director:          dbShow.Director,
+			Barcode:           dbShow.Barcode,
+			Format:            dbShow.Format,
+			ShelfID:           dbShow.ShelfID,
+			ReleaseDate:       partialdate.FromNullTime(dbShow.ReleaseDate, dbShow.ReleaseDatePrecision),
+			RuntimeMinutes:    dbShow.RuntimeMinutes,
+			ContentRating:     dbShow.ContentRating,
+			DiscRegion:        dbShow.DiscRegion,
+			AudioLanguages:    dbShow.AudioLanguages,
+			SubtitleLanguages: dbShow.SubtitleLanguages,
+			ThicknessCM:       dbShow.ThicknessCm,
+			CustomFields:      customFieldsFromDB(dbShow.CustomFields),
+		}
+	},
+	id:           func(show Show) uuid.UUID { return show.ID },
+	updatedAt:    func(show Show) time.Time { return show.UpdatedAt },
+	shelfID:      func(params showParams) uuid.UUID { return params.ShelfID },
+	date:         func(show Show) partialdate.Date { return show.ReleaseDate },
+	customFields: func(params *showParams) *customfields.Values { return &params.CustomFields },
+
+	create: func(db *database.Queries, ctx context.Context, params showParams) (database.Show, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Show{}, err
+		}
+
+		return db.CreateShow(ctx, database.CreateShowParams{
+			Title:                params.Title,
+			Season:               params.Season,
+			Genre:                params.Genre,
+			Actors:               params.Actors,
+			Writer:               params.Writer,
+			Director:             params.Director,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			RuntimeMinutes:       params.RuntimeMinutes,
+			ContentRating:        params.ContentRating,
+			DiscRegion:           params.DiscRegion,
+			AudioLanguages:       params.AudioLanguages,
+			SubtitleLanguages:    params.SubtitleLanguages,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	update: func(db *database.Queries, ctx context.Context, id uuid.UUID, params showParams) (database.Show, error) {
+		customFields, err := json.Marshal(params.CustomFields)
+		if err != nil {
+			return database.Show{}, err
+		}
+
+		return db.UpdateShow(ctx, database.UpdateShowParams{
+			ID:                   id,
+			Title:                params.Title,
+			Season:               params.Season,
+			Genre:                params.Genre,
+			Actors:               params.Actors,
+			Writer:               params.Writer,
+			Director:             params.Director,
+			Barcode:              params.Barcode,
+			Format:               params.Format,
+			ShelfID:              params.ShelfID,
+			ReleaseDate:          params.ReleaseDate.NullTime(),
+			ReleaseDatePrecision: params.ReleaseDate.PrecisionString(),
+			RuntimeMinutes:       params.RuntimeMinutes,
+			ContentRating:        params.ContentRating,
+			DiscRegion:           params.DiscRegion,
+			AudioLanguages:       params.AudioLanguages,
+			SubtitleLanguages:    params.SubtitleLanguages,
+			ThicknessCm:          params.ThicknessCM,
+			CustomFields:         customFields,
+		})
+	},
+	delete: (*database.Queries).DeleteShow,
+	lock:   (*database.Queries).LockShow,
+	setPosition: func(db *database.Queries, ctx context.Context, id uuid.UUID, position int32) error {
+		return db.SetShowPosition(ctx, database.SetShowPositionParams{
+			ID:       id,
+			Position: position,
+		})
+	},
+	move: func(db *database.Queries, ctx context.Context, id, shelfID uuid.UUID) error {
+		return db.MoveShow(ctx, database.MoveShowParams{
+			ID:      id,
+			ShelfID: shelfID,
+		})
+	},
+	setMissingSince: func(db *database.Queries, ctx context.Context, id uuid.UUID, missingSince sql.NullTime) error {
+		return db.SetShowMissingSince(ctx, database.SetShowMissingSinceParams{
+			ID:           id,
+			MissingSince: missingSince,
+		})
+	},
+	location: func(db *database.Queries, ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		location, err := db.GetShowLocation(ctx, id)
+		return location.ID, err
+	},
+	addToBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.AddShowToBundle(ctx, database.AddShowToBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	removeFromBundle: func(db *database.Queries, ctx context.Context, id, bundleID uuid.UUID) (int64, error) {
+		return db.RemoveShowFromBundle(ctx, database.RemoveShowFromBundleParams{
+			ID:       id,
+			BundleID: uuid.NullUUID{UUID: bundleID, Valid: true},
+		})
+	},
+	getByBundle: func(db *database.Queries, ctx context.Context, bundleID uuid.UUID) ([]database.Show, error) {
+		return db.GetShowsByBundle(ctx, uuid.NullUUID{UUID: bundleID, Valid: true})
+	},
+	getForUser: (*database.Queries).GetShowsForUser,
+	getByID:    (*database.Queries).GetShowByID,
+	getByBarcode: func(db *database.Queries, ctx context.Context, barcode string, userID uuid.UUID) ([]database.Show, error) {
+		return db.GetShowsByBarcodeForUser(ctx, database.GetShowsByBarcodeForUserParams{
+			Barcode: barcode,
+			UserID:  userID,
+		})
+	},
+	getByShelf: func(db *database.Queries, ctx context.Context, shelfID uuid.UUID, customFields json.RawMessage) ([]database.Show, error) {
+		return db.GetShowsByShelf(ctx, database.GetShowsByShelfParams{
+			ShelfID:      shelfID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocation: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, customFields json.RawMessage) ([]database.Show, error) {
+		return db.GetShowsByLocation(ctx, database.GetShowsByLocationParams{
+			LocationID:   locationID,
+			CustomFields: customFields,
+		})
+	},
+	getByLocationAndDecade: func(db *database.Queries, ctx context.Context, locationID uuid.UUID, decade int32, customFields json.RawMessage) ([]database.Show, error) {
+		return db.GetShowsByLocationAndDecade(ctx, database.GetShowsByLocationAndDecadeParams{
+			LocationID:   locationID,
+			Decade:       decade,
+			CustomFields: customFields,
+		})
+	},
+}
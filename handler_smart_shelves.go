@@ -0,0 +1,423 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+// SmartShelf is a saved search of a location. Its items are found each time it's viewed, with
+// the query and filters of GET /api/locations/{location_id}/search.
+type SmartShelf struct {
+	ID         uuid.UUID           `json:"id"`
+	LocationID uuid.UUID           `json:"location_id"`
+	Name       string              `json:"name"`
+	Query      string              `json:"query"`
+	Filters    map[string][]string `json:"filters"`
+	Pinned     bool                `json:"pinned"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// LocationShelves are the shelves and smart shelves at a location.
+type LocationShelves struct {
+	Shelves      []Shelf      `json:"shelves"`
+	SmartShelves []SmartShelf `json:"smart_shelves"`
+}
+
+func smartShelfFromDB(dbSmartShelf database.SmartShelf) (SmartShelf, error) {
+	filters := map[string][]string{}
+	if err := json.Unmarshal(dbSmartShelf.Filters, &filters); err != nil {
+		return SmartShelf{}, fmt.Errorf("unable to read filters of smart shelf %s: %w", dbSmartShelf.ID, err)
+	}
+
+	return SmartShelf{
+		ID:         dbSmartShelf.ID,
+		LocationID: dbSmartShelf.LocationID,
+		Name:       dbSmartShelf.Name,
+		Query:      dbSmartShelf.Query,
+		Filters:    filters,
+		Pinned:     dbSmartShelf.Pinned,
+		CreatedAt:  dbSmartShelf.CreatedAt,
+		UpdatedAt:  dbSmartShelf.UpdatedAt,
+	}, nil
+}
+
+func smartShelvesFromDB(dbSmartShelves []database.SmartShelf) ([]SmartShelf, error) {
+	smartShelves := []SmartShelf{}
+	for _, dbSmartShelf := range dbSmartShelves {
+		smartShelf, err := smartShelfFromDB(dbSmartShelf)
+		if err != nil {
+			return nil, err
+		}
+		smartShelves = append(smartShelves, smartShelf)
+	}
+	return smartShelves, nil
+}
+
+type smartShelfParams struct {
+	Name    string              `json:"name"`
+	Query   string              `json:"query"`
+	Filters map[string][]string `json:"filters"`
+	Pinned  bool                `json:"pinned"`
+}
+
+// validate tidies the params, and checks that there's a name and that the filters could be
+// used for a search.
+func (params *smartShelfParams) validate() error {
+	params.Name = strings.TrimSpace(params.Name)
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Filters == nil {
+		params.Filters = map[string][]string{}
+	}
+
+	if params.Name == "" {
+		return fmt.Errorf("smart shelf name is required")
+	}
+	return validateSearchFilters(params.Filters)
+}
+
+// getSmartShelf reads the smart shelf ID from the path and checks that the requester is a
+// member of the smart shelf's location, responding with an error if not.
+func (cfg *apiConfig) getSmartShelf(w http.ResponseWriter, r *http.Request, action string) (database.SmartShelf, bool) {
+	smartShelfIDString := r.PathValue("smart_shelf_id")
+	if smartShelfIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No smart shelf id was provided", fmt.Errorf("no smart shelf id was provided"))
+		return database.SmartShelf{}, false
+	}
+
+	smartShelfID, err := uuid.Parse(smartShelfIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid smart shelf ID", err)
+		return database.SmartShelf{}, false
+	}
+
+	dbSmartShelf, err := cfg.db.GetSmartShelfByID(r.Context(), smartShelfID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Smart shelf not found", err)
+		return database.SmartShelf{}, false
+	}
+
+	if err := cfg.authorizeMember(dbSmartShelf.LocationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s this smart shelf", action), err)
+		return database.SmartShelf{}, false
+	}
+
+	return dbSmartShelf, true
+}
+
+// lockSmartShelf starts a transaction that locks the smart shelf, and checks the request's
+// If-Match against its ETag. It returns the smart shelf as it is once it's locked. The
+// transaction must be rolled back or committed.
+func (cfg *apiConfig) lockSmartShelf(w http.ResponseWriter, r *http.Request, smartShelfID uuid.UUID) (*sql.Tx, *database.Queries, database.SmartShelf, bool) {
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return nil, nil, database.SmartShelf{}, false
+	}
+	qtx := cfg.db.WithTx(tx)
+
+	updatedAt, err := qtx.LockSmartShelf(r.Context(), smartShelfID)
+	if err != nil {
+		tx.Rollback()
+		respondWithError(w, http.StatusNotFound, "Smart shelf not found", err)
+		return nil, nil, database.SmartShelf{}, false
+	}
+
+	if !checkIfMatch(w, r, updatedAt, "smart shelf") {
+		tx.Rollback()
+		return nil, nil, database.SmartShelf{}, false
+	}
+
+	dbSmartShelf, err := qtx.GetSmartShelfByID(r.Context(), smartShelfID)
+	if err != nil {
+		tx.Rollback()
+		respondWithError(w, http.StatusNotFound, "Smart shelf not found", err)
+		return nil, nil, database.SmartShelf{}, false
+	}
+
+	return tx, qtx, dbSmartShelf, true
+}
+
+func (cfg *apiConfig) handlerSmartShelfCreate(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to create smart shelves at this location", err)
+		return
+	}
+
+	params := smartShelfParams{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := params.validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	filters, err := json.Marshal(params.Filters)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to save filters", err)
+		return
+	}
+
+	dbSmartShelf, err := cfg.db.CreateSmartShelf(r.Context(), database.CreateSmartShelfParams{
+		LocationID: locationID,
+		Name:       params.Name,
+		Query:      params.Query,
+		Filters:    filters,
+		Pinned:     params.Pinned,
+	})
+	if isUniqueViolation(err) {
+		respondWithError(w, http.StatusConflict, "There's already a smart shelf with that name at this location", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create smart shelf", err)
+		return
+	}
+
+	smartShelf, err := smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, smartShelf)
+}
+
+func (cfg *apiConfig) handlerSmartShelfGet(w http.ResponseWriter, r *http.Request) {
+	dbSmartShelf, ok := cfg.getSmartShelf(w, r, "view")
+	if !ok {
+		return
+	}
+
+	smartShelf, err := smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	respondWithEntity(w, r, dbSmartShelf.UpdatedAt, smartShelf)
+}
+
+// handlerSmartShelfUpdate changes a smart shelf. Only the fields that are sent are changed.
+// If-Match is checked against the smart shelf's ETag.
+func (cfg *apiConfig) handlerSmartShelfUpdate(w http.ResponseWriter, r *http.Request) {
+	dbSmartShelf, ok := cfg.getSmartShelf(w, r, "modify")
+	if !ok {
+		return
+	}
+
+	tx, qtx, dbSmartShelf, ok := cfg.lockSmartShelf(w, r, dbSmartShelf.ID)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+
+	smartShelf, err := smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	params := smartShelfParams{
+		Name:    smartShelf.Name,
+		Query:   smartShelf.Query,
+		Filters: smartShelf.Filters,
+		Pinned:  smartShelf.Pinned,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := params.validate(); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	filters, err := json.Marshal(params.Filters)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to save filters", err)
+		return
+	}
+
+	dbSmartShelf, err = qtx.UpdateSmartShelf(r.Context(), database.UpdateSmartShelfParams{
+		ID:      dbSmartShelf.ID,
+		Name:    params.Name,
+		Query:   params.Query,
+		Filters: filters,
+		Pinned:  params.Pinned,
+	})
+	if isUniqueViolation(err) {
+		respondWithError(w, http.StatusConflict, "There's already a smart shelf with that name at this location", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to update smart shelf", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	smartShelf, err = smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	respondWithEntity(w, r, dbSmartShelf.UpdatedAt, smartShelf)
+}
+
+// handlerSmartShelfDelete deletes a smart shelf. If-Match is checked against its ETag.
+func (cfg *apiConfig) handlerSmartShelfDelete(w http.ResponseWriter, r *http.Request) {
+	dbSmartShelf, ok := cfg.getSmartShelf(w, r, "delete")
+	if !ok {
+		return
+	}
+
+	tx, qtx, dbSmartShelf, ok := cfg.lockSmartShelf(w, r, dbSmartShelf.ID)
+	if !ok {
+		return
+	}
+	defer tx.Rollback()
+
+	if err := qtx.DeleteSmartShelf(r.Context(), dbSmartShelf.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete smart shelf", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlerSmartShelfItems runs a smart shelf's search, responding in the same way as a search
+// of its location.
+func (cfg *apiConfig) handlerSmartShelfItems(w http.ResponseWriter, r *http.Request) {
+	dbSmartShelf, ok := cfg.getSmartShelf(w, r, "view")
+	if !ok {
+		return
+	}
+
+	smartShelf, err := smartShelfFromDB(dbSmartShelf)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelf", err)
+		return
+	}
+
+	response, err := cfg.searchLocation(r.Context(), smartShelf.LocationID, smartShelf.Query, smartShelf.Filters)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to search items", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// handlerLocationShelvesGet lists every shelf at a location, alongside its smart shelves.
+func (cfg *apiConfig) handlerLocationShelvesGet(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get shelves at this location", err)
+		return
+	}
+
+	dbShelves, err := cfg.db.GetShelvesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get shelves", err)
+		return
+	}
+
+	dbSmartShelves, err := cfg.db.GetSmartShelvesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get smart shelves", err)
+		return
+	}
+
+	smartShelves, err := smartShelvesFromDB(dbSmartShelves)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelves", err)
+		return
+	}
+
+	shelves := LocationShelves{
+		Shelves:      []Shelf{},
+		SmartShelves: smartShelves,
+	}
+	for _, dbShelf := range dbShelves {
+		shelves.Shelves = append(shelves.Shelves, shelfFromDB(dbShelf))
+	}
+
+	respondWithJSON(w, http.StatusOK, shelves)
+}
+
+func (cfg *apiConfig) handlerSmartShelvesGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeMember(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get smart shelves at this location", err)
+		return
+	}
+
+	dbSmartShelves, err := cfg.db.GetSmartShelvesByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get smart shelves", err)
+		return
+	}
+
+	smartShelves, err := smartShelvesFromDB(dbSmartShelves)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to read smart shelves", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, smartShelves)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/google/uuid"
+)
+
+// topCreatorsLimit is how many directors, authors, artists or developers are listed for each media type.
+const topCreatorsLimit = 10
+
+type LocationStats struct {
+	LocationID    uuid.UUID        `json:"location_id"`
+	TotalItems    int64            `json:"total_items"`
+	ItemsByType   map[string]int64 `json:"items_by_type"`
+	ItemsByFormat []FormatCount    `json:"items_by_format"`
+	ItemsByGenre  []GenreCount     `json:"items_by_genre"`
+	ItemsByDecade []DecadeCount    `json:"items_by_decade"`
+	ItemsByCase   []CaseCount      `json:"items_by_case"`
+	ItemsByShelf  []ShelfCount     `json:"items_by_shelf"`
+	AddedByMonth  []MonthCount     `json:"added_by_month"`
+	TopCreators   []CreatorCount   `json:"top_creators"`
+}
+
+type FormatCount struct {
+	ItemType string `json:"item_type"`
+	Format   string `json:"format"`
+	Count    int64  `json:"count"`
+}
+
+type GenreCount struct {
+	ItemType string `json:"item_type"`
+	Genre    string `json:"genre"`
+	Count    int64  `json:"count"`
+}
+
+type DecadeCount struct {
+	Decade int32 `json:"decade"`
+	Count  int64 `json:"count"`
+}
+
+type CaseCount struct {
+	CaseID   uuid.UUID `json:"case_id"`
+	CaseName string    `json:"case_name"`
+	Count    int64     `json:"count"`
+}
+
+type ShelfCount struct {
+	ShelfID   uuid.UUID `json:"shelf_id"`
+	ShelfName string    `json:"shelf_name"`
+	CaseID    uuid.UUID `json:"case_id"`
+	Count     int64     `json:"count"`
+}
+
+type MonthCount struct {
+	Month string `json:"month"` // YYYY-MM
+	Count int64  `json:"count"`
+}
+
+// CreatorCount is a director, author, artist or developer, depending on the item type.
+type CreatorCount struct {
+	ItemType string `json:"item_type"`
+	Creator  string `json:"creator"`
+	Count    int64  `json:"count"`
+}
+
+func (cfg *apiConfig) handlerLocationStats(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	// Validate user is permitted to get stats for the location.
+	err = cfg.authorizeMember(locationID, *r)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "User is not authorized to get stats for this location", err)
+		return
+	}
+
+	// Run every aggregate against the same snapshot, so the totals agree with each other.
+	tx, err := cfg.dbConn.BeginTx(r.Context(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+
+	stats, err := getLocationStats(r.Context(), cfg.db.WithTx(tx), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get location stats", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
+func getLocationStats(ctx context.Context, db *database.Queries, locationID uuid.UUID) (LocationStats, error) {
+	stats := LocationStats{
+		LocationID:    locationID,
+		ItemsByType:   map[string]int64{},
+		ItemsByFormat: []FormatCount{},
+		ItemsByGenre:  []GenreCount{},
+		ItemsByDecade: []DecadeCount{},
+		ItemsByCase:   []CaseCount{},
+		ItemsByShelf:  []ShelfCount{},
+		AddedByMonth:  []MonthCount{},
+		TopCreators:   []CreatorCount{},
+	}
+
+	// List every media type, even those the location has none of.
+	for _, t := range itemTypes {
+		stats.ItemsByType[t.itemName()] = 0
+	}
+
+	typeCounts, err := db.GetLocationItemCountsByType(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by type: %w", err)
+	}
+	for _, row := range typeCounts {
+		stats.ItemsByType[row.ItemType] = row.Count
+		stats.TotalItems += row.Count
+	}
+
+	formatCounts, err := db.GetLocationItemCountsByFormat(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by format: %w", err)
+	}
+	for _, row := range formatCounts {
+		stats.ItemsByFormat = append(stats.ItemsByFormat, FormatCount{ItemType: row.ItemType, Format: row.Format, Count: row.Count})
+	}
+
+	genreCounts, err := db.GetLocationItemCountsByGenre(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by genre: %w", err)
+	}
+	for _, row := range genreCounts {
+		stats.ItemsByGenre = append(stats.ItemsByGenre, GenreCount{ItemType: row.ItemType, Genre: row.Genre, Count: row.Count})
+	}
+
+	decadeCounts, err := db.GetLocationItemCountsByDecade(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by decade: %w", err)
+	}
+	for _, row := range decadeCounts {
+		stats.ItemsByDecade = append(stats.ItemsByDecade, DecadeCount{Decade: row.Decade, Count: row.Count})
+	}
+
+	caseCounts, err := db.GetLocationItemCountsByCase(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by case: %w", err)
+	}
+	for _, row := range caseCounts {
+		stats.ItemsByCase = append(stats.ItemsByCase, CaseCount{CaseID: row.ID, CaseName: row.Name, Count: row.Count})
+	}
+
+	shelfCounts, err := db.GetLocationItemCountsByShelf(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items by shelf: %w", err)
+	}
+	for _, row := range shelfCounts {
+		stats.ItemsByShelf = append(stats.ItemsByShelf, ShelfCount{ShelfID: row.ID, ShelfName: row.Name, CaseID: row.CaseID, Count: row.Count})
+	}
+
+	monthCounts, err := db.GetLocationItemsAddedByMonth(ctx, locationID)
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to count items added by month: %w", err)
+	}
+	for _, row := range monthCounts {
+		stats.AddedByMonth = append(stats.AddedByMonth, MonthCount{Month: row.Month.Format("2006-01"), Count: row.Count})
+	}
+
+	topCreators, err := db.GetLocationTopCreators(ctx, database.GetLocationTopCreatorsParams{
+		LocationID: locationID,
+		TopLimit:   topCreatorsLimit,
+	})
+	if err != nil {
+		return LocationStats{}, fmt.Errorf("unable to get top creators: %w", err)
+	}
+	for _, row := range topCreators {
+		stats.TopCreators = append(stats.TopCreators, CreatorCount{ItemType: row.ItemType, Creator: row.Creator, Count: row.Count})
+	}
+
+	return stats, nil
+}
@@ -70,7 +70,7 @@ func (cfg *apiConfig) handlerUserGetByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, User{
+	respondWithEntity(w, r, dbUser.UpdatedAt, User{
 		ID:        dbUser.ID,
 		Name:      dbUser.Name,
 		Email:     dbUser.Email,
@@ -42,7 +42,25 @@ func (apiCfg *apiConfig) handlerUsersUpdate(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	user, err := apiCfg.db.UpdateUser(r.Context(), database.UpdateUserParams{
+	tx, err := apiCfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := apiCfg.db.WithTx(tx)
+
+	updatedAt, err := qtx.LockUser(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "User not found", err)
+		return
+	}
+
+	if !checkIfMatch(w, r, updatedAt, "user") {
+		return
+	}
+
+	user, err := qtx.UpdateUser(r.Context(), database.UpdateUserParams{
 		ID:             userID,
 		Email:          parms.Email,
 		HashedPassword: hashedPassword,
@@ -52,7 +70,12 @@ func (apiCfg *apiConfig) handlerUsersUpdate(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, response{
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	respondWithEntity(w, r, user.UpdatedAt, response{
 		User: User{
 			ID:    user.ID,
 			Email: user.Email,
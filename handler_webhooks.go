@@ -0,0 +1,430 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 200
+)
+
+// Webhook is a subscription to a location's events. The secret is only included when the
+// webhook is created, or when its secret is changed.
+type Webhook struct {
+	ID         uuid.UUID `json:"id"`
+	LocationID uuid.UUID `json:"location_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook. NextAttemptAt is only set
+// while the delivery is pending.
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	ResponseStatus *int32          `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func webhookFromDB(dbWebhook database.Webhook) Webhook {
+	eventTypes := dbWebhook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return Webhook{
+		ID:         dbWebhook.ID,
+		LocationID: dbWebhook.LocationID,
+		URL:        dbWebhook.Url,
+		EventTypes: eventTypes,
+		Active:     dbWebhook.Active,
+		CreatedAt:  dbWebhook.CreatedAt,
+		UpdatedAt:  dbWebhook.UpdatedAt,
+	}
+}
+
+func webhookDeliveryFromDB(dbDelivery database.WebhookDelivery) WebhookDelivery {
+	delivery := WebhookDelivery{
+		ID:            dbDelivery.ID,
+		WebhookID:     dbDelivery.WebhookID,
+		EventType:     dbDelivery.EventType,
+		Payload:       dbDelivery.Payload,
+		Status:        dbDelivery.Status,
+		Attempts:      dbDelivery.Attempts,
+		LastAttemptAt: nullTimeToPointer(dbDelivery.LastAttemptAt),
+		LastError:     dbDelivery.LastError,
+		CreatedAt:     dbDelivery.CreatedAt,
+	}
+	if dbDelivery.Status == "pending" {
+		delivery.NextAttemptAt = &dbDelivery.NextAttemptAt
+	}
+	if dbDelivery.ResponseStatus.Valid {
+		delivery.ResponseStatus = &dbDelivery.ResponseStatus.Int32
+	}
+	return delivery
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func validateWebhook(webhookURL string, eventTypes []string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+
+	for _, eventType := range eventTypes {
+		if !slices.Contains(webhooks.EventTypes, eventType) {
+			return fmt.Errorf("unknown event type: %s", eventType)
+		}
+	}
+	return nil
+}
+
+// getWebhook reads the webhook ID from the path and checks that the requester owns the
+// webhook's location, responding with an error if not.
+func (cfg *apiConfig) getWebhook(w http.ResponseWriter, r *http.Request, action string) (database.Webhook, bool) {
+	webhookIDString := r.PathValue("webhook_id")
+	if webhookIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No webhook id was provided", fmt.Errorf("no webhook id was provided"))
+		return database.Webhook{}, false
+	}
+
+	webhookID, err := uuid.Parse(webhookIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid webhook ID", err)
+		return database.Webhook{}, false
+	}
+
+	dbWebhook, err := cfg.db.GetWebhookByID(r.Context(), webhookID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Webhook not found", err)
+		return database.Webhook{}, false
+	}
+
+	if err := cfg.authorizeOwner(dbWebhook.LocationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, fmt.Sprintf("User is not authorized to %s this webhook", action), err)
+		return database.Webhook{}, false
+	}
+
+	return dbWebhook, true
+}
+
+// handlerWebhookCreate subscribes a URL to a location's events. A secret is made for the
+// webhook if one isn't given.
+func (cfg *apiConfig) handlerWebhookCreate(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeOwner(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Only the owner of a location can add webhooks", err)
+		return
+	}
+
+	params := struct {
+		URL        string   `json:"url"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"event_types"`
+		Active     bool     `json:"active"`
+	}{
+		EventTypes: []string{},
+		Active:     true,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if err := validateWebhook(params.URL, params.EventTypes); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if params.Secret == "" {
+		params.Secret, err = newWebhookSecret()
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to make webhook secret", err)
+			return
+		}
+	}
+
+	dbWebhook, err := cfg.db.CreateWebhook(r.Context(), database.CreateWebhookParams{
+		LocationID: locationID,
+		Url:        params.URL,
+		Secret:     params.Secret,
+		EventTypes: params.EventTypes,
+		Active:     params.Active,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create webhook", err)
+		return
+	}
+
+	webhook := webhookFromDB(dbWebhook)
+	webhook.Secret = dbWebhook.Secret
+	respondWithJSON(w, http.StatusCreated, webhook)
+}
+
+func (cfg *apiConfig) handlerWebhooksGetByLocation(w http.ResponseWriter, r *http.Request) {
+	locationIDString := r.PathValue("location_id")
+	if locationIDString == "" {
+		respondWithError(w, http.StatusBadRequest, "No location id was provided", fmt.Errorf("no location id was provided"))
+		return
+	}
+
+	locationID, err := uuid.Parse(locationIDString)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid location ID", err)
+		return
+	}
+
+	if err := cfg.authorizeOwner(locationID, *r); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "Only the owner of a location can view its webhooks", err)
+		return
+	}
+
+	dbWebhooks, err := cfg.db.GetWebhooksByLocation(r.Context(), locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get webhooks", err)
+		return
+	}
+
+	locationWebhooks := []Webhook{}
+	for _, dbWebhook := range dbWebhooks {
+		locationWebhooks = append(locationWebhooks, webhookFromDB(dbWebhook))
+	}
+
+	respondWithJSON(w, http.StatusOK, locationWebhooks)
+}
+
+// handlerWebhookUpdate changes a webhook. Only the fields that are sent are changed.
+func (cfg *apiConfig) handlerWebhookUpdate(w http.ResponseWriter, r *http.Request) {
+	dbWebhook, ok := cfg.getWebhook(w, r, "modify")
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	dbWebhook, err = qtx.LockWebhook(r.Context(), dbWebhook.ID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Webhook not found", err)
+		return
+	}
+
+	if !checkIfMatch(w, r, dbWebhook.UpdatedAt, "webhook") {
+		return
+	}
+
+	params := struct {
+		URL        string   `json:"url"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"event_types"`
+		Active     bool     `json:"active"`
+	}{
+		URL:        dbWebhook.Url,
+		Secret:     dbWebhook.Secret,
+		EventTypes: webhookFromDB(dbWebhook).EventTypes,
+		Active:     dbWebhook.Active,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	if params.EventTypes == nil {
+		params.EventTypes = []string{}
+	}
+
+	if err := validateWebhook(params.URL, params.EventTypes); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if params.Secret == "" {
+		respondWithError(w, http.StatusBadRequest, "Webhook secret can't be empty", nil)
+		return
+	}
+
+	secretChanged := params.Secret != dbWebhook.Secret
+	dbWebhook, err = qtx.UpdateWebhook(r.Context(), database.UpdateWebhookParams{
+		ID:         dbWebhook.ID,
+		Url:        params.URL,
+		Secret:     params.Secret,
+		EventTypes: params.EventTypes,
+		Active:     params.Active,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to update webhook", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	webhook := webhookFromDB(dbWebhook)
+	if secretChanged {
+		webhook.Secret = dbWebhook.Secret
+	}
+	respondWithEntity(w, r, dbWebhook.UpdatedAt, webhook)
+}
+
+func (cfg *apiConfig) handlerWebhookDelete(w http.ResponseWriter, r *http.Request) {
+	dbWebhook, ok := cfg.getWebhook(w, r, "delete")
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.dbConn.BeginTx(r.Context(), nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to start transaction", err)
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	dbWebhook, err = qtx.LockWebhook(r.Context(), dbWebhook.ID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Webhook not found", err)
+		return
+	}
+
+	if !checkIfMatch(w, r, dbWebhook.UpdatedAt, "webhook") {
+		return
+	}
+
+	if err := qtx.DeleteWebhook(r.Context(), dbWebhook.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to delete webhook", err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to commit transaction", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlerWebhookDeliveries lists a webhook's most recent deliveries, newest first.
+func (cfg *apiConfig) handlerWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	dbWebhook, ok := cfg.getWebhook(w, r, "view")
+	if !ok {
+		return
+	}
+
+	limit := defaultWebhookDeliveriesLimit
+	if limitString := r.URL.Query().Get("limit"); limitString != "" {
+		var err error
+		limit, err = strconv.Atoi(limitString)
+		if err != nil || limit < 1 || limit > maxWebhookDeliveriesLimit {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxWebhookDeliveriesLimit), err)
+			return
+		}
+	}
+
+	dbDeliveries, err := cfg.db.GetWebhookDeliveries(r.Context(), database.GetWebhookDeliveriesParams{
+		WebhookID: dbWebhook.ID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to get webhook deliveries", err)
+		return
+	}
+
+	deliveries := []WebhookDelivery{}
+	for _, dbDelivery := range dbDeliveries {
+		deliveries = append(deliveries, webhookDeliveryFromDB(dbDelivery))
+	}
+
+	respondWithJSON(w, http.StatusOK, deliveries)
+}
+
+// handlerWebhookTest sends a ping event to a webhook straight away, and responds with how the
+// delivery went. If it fails, it's tried again like any other delivery.
+func (cfg *apiConfig) handlerWebhookTest(w http.ResponseWriter, r *http.Request) {
+	dbWebhook, ok := cfg.getWebhook(w, r, "test")
+	if !ok {
+		return
+	}
+
+	payload, err := json.Marshal(webhooks.Event{
+		ID:         uuid.New(),
+		Type:       webhooks.Ping,
+		LocationID: dbWebhook.LocationID,
+		OccurredAt: time.Now().UTC(),
+		Data: map[string]uuid.UUID{
+			"webhook_id": dbWebhook.ID,
+		},
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to encode ping event", err)
+		return
+	}
+
+	// The delivery is created already claimed, so that the background worker can't send it
+	// while it's sent here.
+	dbDelivery, err := cfg.db.CreateClaimedWebhookDelivery(r.Context(), database.CreateClaimedWebhookDeliveryParams{
+		WebhookID: dbWebhook.ID,
+		EventType: webhooks.Ping,
+		Payload:   payload,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to create webhook delivery", err)
+		return
+	}
+
+	dbDelivery, err = cfg.attemptWebhookDelivery(r.Context(), dbDelivery)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to send webhook delivery", err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, webhookDeliveryFromDB(dbDelivery))
+}
@@ -86,3 +86,35 @@ func (cfg *apiConfig) getRequesterID(r *http.Request) (uuid.UUID, error) {
 	}
 	return userID, nil
 }
+
+// invalidTokenWriter marks a 401 response as caused by an invalid or expired access token,
+// rather than the user not being allowed to do something, so that clients know whether
+// refreshing the token will help.
+type invalidTokenWriter struct {
+	http.ResponseWriter
+}
+
+func (w invalidTokenWriter) WriteHeader(code int) {
+	if code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush events.
+func (w invalidTokenWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flagInvalidTokens sets WWW-Authenticate on 401 responses to requests whose bearer token
+// isn't a valid access token.
+func (cfg *apiConfig) flagInvalidTokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenString, err := auth.GetBearerToken(r.Header); err == nil {
+			if _, err := auth.ValidateJWT(tokenString, cfg.jwtSecret); err != nil {
+				w = invalidTokenWriter{w}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/etag"
+)
+
+// respondWithEntity responds with a single entity, along with an ETag derived from when it was
+// last updated. A GET whose If-None-Match names the ETag gets 304 Not Modified instead, so
+// clients can poll for changes cheaply.
+func respondWithEntity(w http.ResponseWriter, r *http.Request, updatedAt time.Time, payload any) {
+	respondWithETag(w, r, etag.FromTime(updatedAt), payload)
+}
+
+// respondWithETag is respondWithEntity for an entity whose ETag isn't derived from a single
+// time, such as a bundle and the items in it.
+func respondWithETag(w http.ResponseWriter, r *http.Request, tag string, payload any) {
+	w.Header().Set("ETag", tag)
+
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && etag.NoneMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, payload)
+}
+
+// checkIfMatch checks that an entity hasn't been changed since the client fetched it, if the
+// request has an If-Match header. Otherwise it responds with 412 Precondition Failed and the
+// entity's current ETag. The entity should be locked, so that it can't change after it's checked.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, updatedAt time.Time, name string) bool {
+	return checkIfMatchETag(w, r, etag.FromTime(updatedAt), name)
+}
+
+// checkIfMatchETag is checkIfMatch for an entity whose ETag isn't derived from a single time.
+func checkIfMatchETag(w http.ResponseWriter, r *http.Request, tag, name string) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return true
+	}
+
+	if etag.Match(header, tag) {
+		return true
+	}
+
+	w.Header().Set("ETag", tag)
+	respondWithError(w, http.StatusPreconditionFailed, fmt.Sprintf("The %s has been changed since it was fetched", name), nil)
+	return false
+}
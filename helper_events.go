@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/events"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// eventChannel is the Postgres channel events are sent on when they're fanned out
+	// between servers.
+	eventChannel = "location_events"
+	// maxNotifyPayload is the largest payload Postgres accepts in a notification.
+	maxNotifyPayload = 7999
+)
+
+// ItemEvent is the data of item events. FromShelfID is only set when an item is moved.
+type ItemEvent struct {
+	ItemType    string     `json:"item_type"`
+	Item        any        `json:"item"`
+	FromShelfID *uuid.UUID `json:"from_shelf_id,omitempty"`
+}
+
+// MemberEvent is the data of member events.
+type MemberEvent struct {
+	LocationID uuid.UUID `json:"location_id"`
+	UserID     uuid.UUID `json:"user_id"`
+}
+
+// publishEvent sends an event to the location's event streams and queues it for the
+// location's webhooks. Events are published after the change they describe is saved, and
+// failing to publish one doesn't undo the change, so errors are only logged.
+func (cfg *apiConfig) publishEvent(ctx context.Context, locationID uuid.UUID, eventType string, data any) {
+	// The event is still published if the request is cancelled once the change is saved.
+	ctx = context.WithoutCancel(ctx)
+
+	event := webhooks.Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		LocationID: locationID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Unable to encode %s event: %v", eventType, err)
+		return
+	}
+
+	cfg.broadcastEvent(ctx, event, payload)
+	cfg.queueWebhookDeliveries(ctx, event, payload)
+}
+
+// publishItemMoved publishes an item.moved event for an item that was moved off fromShelfID
+// outside of the item routes, such as by a scan session or an audit.
+func (cfg *apiConfig) publishItemMoved(ctx context.Context, t registeredItemType, locationID, id, fromShelfID uuid.UUID) {
+	event, err := t.itemEvent(ctx, cfg.db, id)
+	if err != nil {
+		log.Printf("Unable to get moved %s for %s event: %v", t.itemName(), webhooks.ItemMoved, err)
+		return
+	}
+	event.FromShelfID = &fromShelfID
+	cfg.publishEvent(ctx, locationID, webhooks.ItemMoved, event)
+}
+
+// broadcastEvent sends an event to the location's event streams. When events are fanned out
+// through Postgres, the event is sent to every server, including this one, by a notification.
+func (cfg *apiConfig) broadcastEvent(ctx context.Context, event webhooks.Event, payload []byte) {
+	if !cfg.notifyEvents {
+		cfg.broker.Publish(event.LocationID, events.Message{
+			ID:   event.ID.String(),
+			Type: event.Type,
+			Data: payload,
+		})
+		return
+	}
+
+	// Notifications are limited in size, so an event that's too large is sent without its
+	// data. Clients can fetch whatever changed.
+	if len(payload) > maxNotifyPayload {
+		event.Data = nil
+		var err error
+		payload, err = json.Marshal(event)
+		if err != nil {
+			log.Printf("Unable to encode %s event: %v", event.Type, err)
+			return
+		}
+	}
+
+	err := cfg.db.NotifyLocationEvent(ctx, string(payload))
+	if err != nil {
+		log.Printf("Unable to send %s event: %v", event.Type, err)
+	}
+}
+
+// listenForEvents passes the events other servers send through Postgres to this server's
+// event streams, until the context is cancelled.
+func (cfg *apiConfig) listenForEvents(ctx context.Context, dbURL string) {
+	listener := pq.NewListener(dbURL, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Event listener error: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	err := listener.Listen(eventChannel)
+	if err != nil {
+		log.Printf("Unable to listen for events: %v", err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-listener.Notify:
+			// A nil notification means the connection was re-established, and events sent
+			// while it was down are lost.
+			if notification == nil {
+				continue
+			}
+
+			var event struct {
+				ID         uuid.UUID `json:"id"`
+				Type       string    `json:"type"`
+				LocationID uuid.UUID `json:"location_id"`
+			}
+			err := json.Unmarshal([]byte(notification.Extra), &event)
+			if err != nil {
+				log.Printf("Unable to decode event notification: %v", err)
+				continue
+			}
+
+			cfg.broker.Publish(event.LocationID, events.Message{
+				ID:   event.ID.String(),
+				Type: event.Type,
+				Data: []byte(notification.Extra),
+			})
+		case <-time.After(90 * time.Second):
+			// Check the connection is still up when things are quiet.
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+)
+
+const (
+	// defaultIdempotencyWindow is how long an idempotency key is kept, unless
+	// IDEMPOTENCY_WINDOW is set.
+	defaultIdempotencyWindow = 24 * time.Hour
+	// maxIdempotencyKeyLength is the longest idempotency key that's accepted.
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyRecorder passes a response through to the client, keeping a copy of it.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// idempotent lets a client safely retry a create request by sending an Idempotency-Key header.
+// The response to the first request with a key is kept for the idempotency window, and a retry
+// with the same key gets that response again instead of creating another entity. Reusing a key
+// for a different request is a conflict.
+//
+// Only responses that created something are kept. The key of a request that failed is freed,
+// so the request can be fixed and tried again. Requests without a key, or without a valid
+// token to tell whose key it is, are handled as usual.
+func (cfg *apiConfig) idempotent(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			handler(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			respondWithError(w, http.StatusBadRequest, "Idempotency-Key is too long", nil)
+			return
+		}
+
+		requesterID, err := cfg.getRequesterID(r)
+		if err != nil {
+			handler(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Unable to read request body", err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		hash := requestHash(r, body)
+
+		claim, err := cfg.db.ClaimIdempotencyKey(r.Context(), database.ClaimIdempotencyKeyParams{
+			UserID:        requesterID,
+			Key:           key,
+			WindowSeconds: int64(cfg.idempotencyWindow / time.Second),
+			RequestHash:   hash,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			cfg.replayIdempotentResponse(w, r, database.GetIdempotencyKeyParams{UserID: requesterID, Key: key}, hash)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to check Idempotency-Key", err)
+			return
+		}
+
+		rec := &idempotencyRecorder{ResponseWriter: w}
+		handler(rec, r)
+
+		// The request may have been cancelled once the response was sent, and the key still
+		// needs to be saved.
+		ctx := context.WithoutCancel(r.Context())
+		if rec.status < 200 || rec.status > 299 {
+			err = cfg.db.ReleaseIdempotencyKey(ctx, database.ReleaseIdempotencyKeyParams{
+				UserID: claim.UserID,
+				Key:    claim.Key,
+			})
+			if err != nil {
+				log.Printf("Unable to release idempotency key: %v", err)
+			}
+			return
+		}
+
+		err = cfg.db.SaveIdempotentResponse(ctx, database.SaveIdempotentResponseParams{
+			UserID:       claim.UserID,
+			Key:          claim.Key,
+			StatusCode:   sql.NullInt32{Int32: int32(rec.status), Valid: true},
+			ContentType:  rec.Header().Get("Content-Type"),
+			ResponseBody: rec.body.Bytes(),
+		})
+		if err != nil {
+			log.Printf("Unable to save response for idempotency key: %v", err)
+		}
+	}
+}
+
+// replayIdempotentResponse responds to a retry with the response to the first request that
+// used its key.
+func (cfg *apiConfig) replayIdempotentResponse(w http.ResponseWriter, r *http.Request, params database.GetIdempotencyKeyParams, hash string) {
+	stored, err := cfg.db.GetIdempotencyKey(r.Context(), params)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed in the meantime and freed its key.
+		respondWithError(w, http.StatusConflict, "The request with this Idempotency-Key failed, please try again", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Unable to check Idempotency-Key", err)
+		return
+	}
+
+	if stored.RequestHash != hash {
+		respondWithError(w, http.StatusConflict, "This Idempotency-Key was already used for a different request", nil)
+		return
+	}
+	if !stored.StatusCode.Valid {
+		respondWithError(w, http.StatusConflict, "A request with this Idempotency-Key is still being handled", nil)
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(int(stored.StatusCode.Int32))
+	if _, err := w.Write(stored.ResponseBody); err != nil {
+		log.Printf("Error writing replayed response: %s", err)
+	}
+}
+
+// requestHash identifies a request by its method, path and body, so that a retry can be told
+// apart from a different request that reuses a key.
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// deleteExpiredIdempotencyKeys deletes the idempotency keys whose window has passed, every interval.
+func (cfg *apiConfig) deleteExpiredIdempotencyKeys(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := cfg.db.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+			log.Printf("Unable to delete expired idempotency keys: %v", err)
+		}
+	}
+}
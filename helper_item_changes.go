@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/customfields"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+// errChangeConflict means an entity was changed by someone else after the version a change
+// was based on.
+var errChangeConflict = errors.New("changed since the version the change was based on")
+
+// invalidChangeError is a change that can't be made because of what was sent, such as an
+// unknown shelf. Its message is safe to show the client.
+type invalidChangeError struct {
+	message string
+}
+
+func (e *invalidChangeError) Error() string {
+	return e.message
+}
+
+func invalidChange(format string, args ...any) error {
+	return &invalidChangeError{message: fmt.Sprintf(format, args...)}
+}
+
+// itemChange is an item created, updated or deleted outside of the item routes, with what's
+// needed to publish it once it's saved.
+type itemChange struct {
+	id         uuid.UUID
+	eventType  string
+	event      ItemEvent
+	locationID uuid.UUID
+	// fromLocationID is the item's location before it was moved to another location. It's
+	// the same as locationID otherwise.
+	fromLocationID uuid.UUID
+}
+
+// updatedItemChange returns an item.updated change for an item changed outside of the item
+// routes, to publish once the change is saved.
+func updatedItemChange(ctx context.Context, db *database.Queries, t registeredItemType, id, locationID uuid.UUID) (itemChange, error) {
+	event, err := t.itemEvent(ctx, db, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+	return itemChange{
+		id:             id,
+		eventType:      webhooks.ItemUpdated,
+		event:          event,
+		locationID:     locationID,
+		fromLocationID: locationID,
+	}, nil
+}
+
+// publishItemChange publishes an item change. An item moved between locations is reported
+// to both.
+func (cfg *apiConfig) publishItemChange(ctx context.Context, change itemChange) {
+	cfg.publishEvent(ctx, change.locationID, change.eventType, change.event)
+	if change.fromLocationID != change.locationID {
+		cfg.publishEvent(ctx, change.fromLocationID, change.eventType, change.event)
+	}
+}
+
+// shelfLocation returns the location of a shelf that's named in a change.
+func shelfLocation(ctx context.Context, db *database.Queries, shelfID uuid.UUID) (uuid.UUID, error) {
+	location, err := db.GetShelfLocation(ctx, shelfID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, invalidChange("Shelf not found")
+	}
+	return location.ID, err
+}
+
+// itemCreate creates an item from the JSON of its params, like POST does. allowLocation
+// checks that the item can be created at its shelf's location.
+func (t *itemType[Row, Item, Params]) itemCreate(ctx context.Context, cfg *apiConfig, db *database.Queries, body json.RawMessage, allowLocation func(uuid.UUID) error) (itemChange, error) {
+	var params Params
+	if err := json.Unmarshal(body, &params); err != nil {
+		return itemChange{}, invalidChange("Invalid %s: %v", t.name, err)
+	}
+
+	if t.validate != nil {
+		if err := t.validate(&params); err != nil {
+			return itemChange{}, invalidChange("%s", err.Error())
+		}
+	}
+
+	locationID, err := shelfLocation(ctx, db, t.shelfID(params))
+	if err != nil {
+		return itemChange{}, err
+	}
+	if err := allowLocation(locationID); err != nil {
+		return itemChange{}, err
+	}
+
+	fields, err := cfg.getCustomFieldDefinitions(ctx, locationID, t.name)
+	if err != nil {
+		return itemChange{}, err
+	}
+	values := t.customFields(&params)
+	*values, err = customfields.Validate(fields, *values)
+	if err != nil {
+		return itemChange{}, invalidChange("%s", err.Error())
+	}
+
+	row, err := t.create(db, ctx, params)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	item := t.toItem(row)
+	return itemChange{
+		id:             t.id(item),
+		eventType:      webhooks.ItemCreated,
+		event:          ItemEvent{ItemType: t.name, Item: item},
+		locationID:     locationID,
+		fromLocationID: locationID,
+	}, nil
+}
+
+// itemUpdate applies the JSON of some of an item's params on top of its current values, like
+// PUT does. If baseUpdatedAt is set and the item has been updated since, nothing is changed and
+// errChangeConflict is returned along with the item as it is now. allowLocation checks that
+// the item can be changed at its current location and at the location it's moved to.
+//
+// The item is locked until the transaction db belongs to ends, so nothing else can change it
+// in between.
+func (t *itemType[Row, Item, Params]) itemUpdate(ctx context.Context, cfg *apiConfig, db *database.Queries, id uuid.UUID, baseUpdatedAt *time.Time, body json.RawMessage, allowLocation func(uuid.UUID) error) (itemChange, error) {
+	updatedAt, err := t.lock(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	fromLocationID, err := t.location(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+	if err := allowLocation(fromLocationID); err != nil {
+		return itemChange{}, err
+	}
+
+	row, err := t.getByID(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	if baseUpdatedAt != nil && !updatedAt.Equal(*baseUpdatedAt) {
+		return itemChange{
+			id:             id,
+			event:          ItemEvent{ItemType: t.name, Item: t.toItem(row)},
+			locationID:     fromLocationID,
+			fromLocationID: fromLocationID,
+		}, errChangeConflict
+	}
+
+	params := t.toParams(row)
+	fromShelfID := t.shelfID(params)
+	values := t.customFields(&params)
+	stored := *values
+	*values = nil
+	if err := json.Unmarshal(body, &params); err != nil {
+		return itemChange{}, invalidChange("Invalid %s: %v", t.name, err)
+	}
+
+	if t.validate != nil {
+		if err := t.validate(&params); err != nil {
+			return itemChange{}, invalidChange("%s", err.Error())
+		}
+	}
+
+	locationID, err := shelfLocation(ctx, db, t.shelfID(params))
+	if err != nil {
+		return itemChange{}, err
+	}
+	if err := allowLocation(locationID); err != nil {
+		return itemChange{}, err
+	}
+
+	// Stored values are checked against the fields of the new location, in case the item moved.
+	fields, err := cfg.getCustomFieldDefinitions(ctx, locationID, t.name)
+	if err != nil {
+		return itemChange{}, err
+	}
+	*values, err = customfields.Merge(fields, stored, *values)
+	if err != nil {
+		return itemChange{}, invalidChange("%s", err.Error())
+	}
+
+	row, err = t.update(db, ctx, id, params)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	change := itemChange{
+		id:             id,
+		eventType:      webhooks.ItemUpdated,
+		event:          ItemEvent{ItemType: t.name, Item: t.toItem(row)},
+		locationID:     locationID,
+		fromLocationID: fromLocationID,
+	}
+	if t.shelfID(params) != fromShelfID {
+		change.eventType = webhooks.ItemMoved
+		change.event.FromShelfID = &fromShelfID
+	}
+	return change, nil
+}
+
+// itemDelete deletes an item. If baseUpdatedAt is set and the item has been updated since,
+// nothing is changed and errChangeConflict is returned along with the item as it is now.
+// allowLocation checks that the item can be deleted at its location.
+func (t *itemType[Row, Item, Params]) itemDelete(ctx context.Context, db *database.Queries, id uuid.UUID, baseUpdatedAt *time.Time, allowLocation func(uuid.UUID) error) (itemChange, error) {
+	updatedAt, err := t.lock(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	locationID, err := t.location(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+	if err := allowLocation(locationID); err != nil {
+		return itemChange{}, err
+	}
+
+	row, err := t.getByID(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+
+	change := itemChange{
+		id:             id,
+		eventType:      webhooks.ItemDeleted,
+		event:          ItemEvent{ItemType: t.name, Item: t.toItem(row)},
+		locationID:     locationID,
+		fromLocationID: locationID,
+	}
+	if baseUpdatedAt != nil && !updatedAt.Equal(*baseUpdatedAt) {
+		return change, errChangeConflict
+	}
+
+	err = t.delete(db, ctx, id)
+	if err != nil {
+		return itemChange{}, err
+	}
+	return change, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// parseDecadeQuery reads the optional decade URL query, e.g. ?decade=1970 or ?decade=1970s.
+func parseDecadeQuery(r *http.Request) (decade int32, ok bool, err error) {
+	decadeString := r.URL.Query().Get("decade")
+	if decadeString == "" {
+		return 0, false, nil
+	}
+
+	year, err := strconv.Atoi(strings.TrimSuffix(decadeString, "s"))
+	if err != nil {
+		return 0, false, fmt.Errorf("decade must be a year such as 1970: %w", err)
+	}
+	if year%10 != 0 {
+		return 0, false, fmt.Errorf("decade must start on a year ending in 0")
+	}
+
+	return int32(year), true, nil
+}
+
+// parseDateSortQuery reports whether the results should be ordered by their release or publication date.
+func parseDateSortQuery(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("sort") {
+	case "":
+		return false, nil
+	case "release_date", "publication_date":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported sort: %s", r.URL.Query().Get("sort"))
+	}
+}
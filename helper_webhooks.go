@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/webhooks"
+)
+
+// webhookBatchSize is how many due deliveries are sent on each pass of the delivery worker.
+const webhookBatchSize = 20
+
+// queueWebhookDeliveries queues a delivery of the event to each of the location's webhooks
+// that's subscribed to it.
+func (cfg *apiConfig) queueWebhookDeliveries(ctx context.Context, event webhooks.Event, payload []byte) {
+	dbWebhooks, err := cfg.db.GetActiveWebhooksByLocation(ctx, event.LocationID)
+	if err != nil {
+		log.Printf("Unable to get webhooks for %s event: %v", event.Type, err)
+		return
+	}
+
+	for _, dbWebhook := range dbWebhooks {
+		if !webhooks.Subscribed(dbWebhook.EventTypes, event.Type) {
+			continue
+		}
+
+		_, err = cfg.db.CreateWebhookDelivery(ctx, database.CreateWebhookDeliveryParams{
+			WebhookID: dbWebhook.ID,
+			EventType: event.Type,
+			Payload:   payload,
+		})
+		if err != nil {
+			log.Printf("Unable to queue %s event for webhook %s: %v", event.Type, dbWebhook.ID, err)
+		}
+	}
+}
+
+// deliverWebhooks sends due webhook deliveries every interval, until the context is cancelled.
+func (cfg *apiConfig) deliverWebhooks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deliveries, err := cfg.db.ClaimDueWebhookDeliveries(ctx, webhookBatchSize)
+		if err != nil {
+			log.Printf("Unable to get due webhook deliveries: %v", err)
+			continue
+		}
+
+		for _, delivery := range deliveries {
+			if _, err := cfg.attemptWebhookDelivery(ctx, delivery); err != nil {
+				log.Printf("Unable to record webhook delivery %s: %v", delivery.ID, err)
+			}
+		}
+	}
+}
+
+// attemptWebhookDelivery sends a delivery and records how it went. A delivery that fails is
+// tried again after a backoff, until it has been tried webhooks.MaxAttempts times. The returned
+// error is only for failing to record the attempt.
+func (cfg *apiConfig) attemptWebhookDelivery(ctx context.Context, delivery database.WebhookDelivery) (database.WebhookDelivery, error) {
+	params := database.RecordWebhookDeliveryAttemptParams{
+		ID:     delivery.ID,
+		Status: "succeeded",
+	}
+
+	dbWebhook, err := cfg.db.GetWebhookByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return database.WebhookDelivery{}, fmt.Errorf("unable to get webhook: %w", err)
+	}
+
+	if !dbWebhook.Active {
+		params.Status = "failed"
+		params.LastError = "webhook is not active"
+		return cfg.db.RecordWebhookDeliveryAttempt(ctx, params)
+	}
+
+	status, err := webhooks.Deliver(ctx, cfg.webhookClient, dbWebhook.Url, dbWebhook.Secret, delivery.EventType, delivery.ID, delivery.Payload)
+	params.ResponseStatus = sql.NullInt32{Int32: int32(status), Valid: status != 0}
+	if err != nil {
+		params.LastError = err.Error()
+		failures := int(delivery.Attempts) + 1
+		if failures >= webhooks.MaxAttempts {
+			params.Status = "failed"
+		} else {
+			params.Status = "pending"
+			params.RetryAfterSeconds = int32(webhooks.Backoff(failures).Seconds())
+		}
+	}
+
+	return cfg.db.RecordWebhookDeliveryAttempt(ctx, params)
+}
@@ -0,0 +1,199 @@
+// Package customfields validates the user-defined fields that location owners add to items.
+// Values are stored on each item as a JSON object keyed by field name.
+package customfields
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeText   Type = "text"
+	TypeNumber Type = "number"
+	TypeBool   Type = "bool"
+	TypeDate   Type = "date"
+	TypeEnum   Type = "enum"
+)
+
+// FilterPrefix marks URL query parameters that filter by a custom field, e.g. ?cf.signed=true.
+const FilterPrefix = "cf."
+
+var ErrInvalidValue = errors.New("invalid custom field value")
+
+// Field is the definition of a custom field. Options are the allowed values of an enum field.
+type Field struct {
+	Name    string
+	Type    Type
+	Options []string
+}
+
+// Values holds the custom field values of an item, as decoded from JSON.
+type Values map[string]any
+
+// ValidType reports whether t is a supported field type.
+func ValidType(t Type) bool {
+	switch t {
+	case TypeText, TypeNumber, TypeBool, TypeDate, TypeEnum:
+		return true
+	}
+	return false
+}
+
+// Validate checks values against the field definitions. It returns a copy of the values
+// with null values removed, so that sending null for a field clears it.
+func Validate(fields []Field, values Values) (Values, error) {
+	validated := Values{}
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+
+		field, ok := find(fields, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not a custom field", ErrInvalidValue, name)
+		}
+
+		err := checkValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+
+		validated[name] = value
+	}
+	return validated, nil
+}
+
+// Prune returns a copy of the values without any fields that are no longer defined.
+func Prune(fields []Field, values Values) Values {
+	pruned := Values{}
+	for name, value := range values {
+		if _, ok := find(fields, name); ok {
+			pruned[name] = value
+		}
+	}
+	return pruned
+}
+
+// Merge applies the values sent in an update on top of the stored values. Stored values
+// for fields that are no longer defined are dropped, the sent values are validated and a
+// null sent value removes the field.
+func Merge(fields []Field, stored, sent Values) (Values, error) {
+	validated, err := Validate(fields, sent)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := Prune(fields, stored)
+	for name, value := range sent {
+		if value == nil {
+			delete(merged, name)
+		}
+	}
+	for name, value := range validated {
+		merged[name] = value
+	}
+	return merged, nil
+}
+
+// ParseFilter reads the custom field filters from URL query parameters. Each value is
+// converted to the field's type, so the result can be matched against stored values.
+func ParseFilter(fields []Field, query url.Values) (Values, error) {
+	filter := Values{}
+	for key, queryValues := range query {
+		name, ok := strings.CutPrefix(key, FilterPrefix)
+		if !ok {
+			continue
+		}
+
+		field, ok := find(fields, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not a custom field", ErrInvalidValue, name)
+		}
+
+		value, err := parseValue(field, queryValues[0])
+		if err != nil {
+			return nil, err
+		}
+
+		filter[name] = value
+	}
+	return filter, nil
+}
+
+func find(fields []Field, name string) (Field, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+func checkValue(field Field, value any) error {
+	switch field.Type {
+	case TypeText:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+	case TypeNumber:
+		if _, ok := value.(float64); ok {
+			return nil
+		}
+	case TypeBool:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+	case TypeDate:
+		if s, ok := value.(string); ok {
+			if _, err := time.Parse(time.DateOnly, s); err == nil {
+				return nil
+			}
+		}
+	case TypeEnum:
+		if s, ok := value.(string); ok && slices.Contains(field.Options, s) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s must be a %s", ErrInvalidValue, field.Name, describe(field))
+}
+
+func parseValue(field Field, s string) (any, error) {
+	var value any = s
+	switch field.Type {
+	case TypeNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a %s", ErrInvalidValue, field.Name, describe(field))
+		}
+		value = n
+	case TypeBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be a %s", ErrInvalidValue, field.Name, describe(field))
+		}
+		value = b
+	}
+	return value, checkValue(field, value)
+}
+
+func describe(field Field) string {
+	switch field.Type {
+	case TypeNumber:
+		return "number"
+	case TypeBool:
+		return "boolean"
+	case TypeDate:
+		return "date formatted as YYYY-MM-DD"
+	case TypeEnum:
+		return "one of " + strings.Join(field.Options, ", ")
+	default:
+		return "string"
+	}
+}
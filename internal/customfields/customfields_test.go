@@ -0,0 +1,147 @@
+package customfields
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var testFields = []Field{
+	{Name: "signed", Type: TypeBool},
+	{Name: "copies", Type: TypeNumber},
+	{Name: "note", Type: TypeText},
+	{Name: "bought", Type: TypeDate},
+	{Name: "case", Type: TypeEnum, Options: []string{"steelbook", "keepcase"}},
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  Values
+		want    Values
+		wantErr bool
+	}{
+		{
+			name:   "Valid values of every type",
+			values: Values{"signed": true, "copies": 2.0, "note": "gift", "bought": "2024-03-01", "case": "steelbook"},
+			want:   Values{"signed": true, "copies": 2.0, "note": "gift", "bought": "2024-03-01", "case": "steelbook"},
+		},
+		{
+			name:   "Null clears a field",
+			values: Values{"signed": nil, "note": "gift"},
+			want:   Values{"note": "gift"},
+		},
+		{
+			name:    "Unknown field",
+			values:  Values{"region": "A"},
+			wantErr: true,
+		},
+		{
+			name:    "Wrong type",
+			values:  Values{"signed": "yes"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			values:  Values{"bought": "March 2024"},
+			wantErr: true,
+		},
+		{
+			name:    "Enum value not in options",
+			values:  Values{"case": "digipak"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Validate(testFields, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidValue) {
+					t.Errorf("Validate() error = %v, want ErrInvalidValue", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	got := Prune(testFields, Values{"signed": true, "removed": "old"})
+	want := Values{"signed": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Prune() = %v, want %v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	stored := Values{"signed": true, "note": "gift", "removed": "old"}
+	sent := Values{"note": nil, "copies": 3.0}
+
+	got, err := Merge(testFields, stored, sent)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	want := Values{"signed": true, "copies": 3.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+
+	_, err = Merge(testFields, stored, Values{"copies": "three"})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Merge() error = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Values
+		wantErr bool
+	}{
+		{
+			name:  "Typed values",
+			query: "cf.signed=true&cf.copies=2&cf.case=steelbook",
+			want:  Values{"signed": true, "copies": 2.0, "case": "steelbook"},
+		},
+		{
+			name:  "Other parameters are ignored",
+			query: "decade=1970&sort=release_date",
+			want:  Values{},
+		},
+		{
+			name:    "Unknown field",
+			query:   "cf.region=A",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid boolean",
+			query:   "cf.signed=maybe",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseFilter(testFields, query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,25 +7,58 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addBookToBundle = `-- name: AddBookToBundle :execrows
+UPDATE books
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2)
+`
+
+type AddBookToBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+// AddBookToBundle doesn't take an item out of another bundle.
+func (q *Queries) AddBookToBundle(ctx context.Context, arg AddBookToBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addBookToBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createBook = `-- name: CreateBook :one
-INSERT INTO books (id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id)
+INSERT INTO books (id, created_at, updated_at, title, author, genre, publication_date, publication_date_precision, barcode, shelf_id, isbn, publisher, page_count, edition, language, series, series_number, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6
-) RETURNING id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, search
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $7)
+) RETURNING id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, publication_date_precision, bundle_id, isbn, publisher, page_count, edition, language, series, series_number, search, custom_fields, position, thickness_cm, missing_since
 `
 
 type CreateBookParams struct {
-	Title           string
-	Author          string
-	Genre           string
-	PublicationDate time.Time
-	Barcode         string
-	ShelfID         uuid.UUID
+	Title                    string
+	Author                   string
+	Genre                    string
+	PublicationDate          sql.NullTime
+	PublicationDatePrecision string
+	Barcode                  string
+	ShelfID                  uuid.UUID
+	Isbn                     string
+	Publisher                string
+	PageCount                int32
+	Edition                  string
+	Language                 string
+	Series                   string
+	SeriesNumber             string
+	CustomFields             json.RawMessage
+	ThicknessCm              float64
 }
 
 func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
@@ -34,8 +67,18 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		arg.Author,
 		arg.Genre,
 		arg.PublicationDate,
+		arg.PublicationDatePrecision,
 		arg.Barcode,
 		arg.ShelfID,
+		arg.Isbn,
+		arg.Publisher,
+		arg.PageCount,
+		arg.Edition,
+		arg.Language,
+		arg.Series,
+		arg.SeriesNumber,
+		arg.CustomFields,
+		arg.ThicknessCm,
 	)
 	var i Book
 	err := row.Scan(
@@ -48,35 +91,35 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		&i.PublicationDate,
 		&i.Barcode,
 		&i.ShelfID,
+		&i.PublicationDatePrecision,
+		&i.BundleID,
+		&i.Isbn,
+		&i.Publisher,
+		&i.PageCount,
+		&i.Edition,
+		&i.Language,
+		&i.Series,
+		&i.SeriesNumber,
 		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
 
-const getBookByBarcode = `-- name: GetBookByBarcode :one
-SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, search FROM books WHERE barcode = $1
+const deleteBook = `-- name: DeleteBook :exec
+DELETE FROM books WHERE id = $1
 `
 
-func (q *Queries) GetBookByBarcode(ctx context.Context, barcode string) (Book, error) {
-	row := q.db.QueryRowContext(ctx, getBookByBarcode, barcode)
-	var i Book
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Title,
-		&i.Author,
-		&i.Genre,
-		&i.PublicationDate,
-		&i.Barcode,
-		&i.ShelfID,
-		&i.Search,
-	)
-	return i, err
+func (q *Queries) DeleteBook(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteBook, id)
+	return err
 }
 
 const getBookByID = `-- name: GetBookByID :one
-SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, search FROM books WHERE id = $1
+SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, publication_date_precision, bundle_id, isbn, publisher, page_count, edition, language, series, series_number, search, custom_fields, position, thickness_cm, missing_since FROM books WHERE id = $1
 `
 
 func (q *Queries) GetBookByID(ctx context.Context, id uuid.UUID) (Book, error) {
@@ -92,7 +135,20 @@ func (q *Queries) GetBookByID(ctx context.Context, id uuid.UUID) (Book, error) {
 		&i.PublicationDate,
 		&i.Barcode,
 		&i.ShelfID,
+		&i.PublicationDatePrecision,
+		&i.BundleID,
+		&i.Isbn,
+		&i.Publisher,
+		&i.PageCount,
+		&i.Edition,
+		&i.Language,
+		&i.Series,
+		&i.SeriesNumber,
 		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
@@ -118,12 +174,77 @@ func (q *Queries) GetBookLocation(ctx context.Context, id uuid.UUID) (GetBookLoc
 	return i, err
 }
 
-const getBooks = `-- name: GetBooks :many
-SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, search FROM books
+const getBooksByBarcodeForUser = `-- name: GetBooksByBarcodeForUser :many
+SELECT books.id, books.created_at, books.updated_at, books.title, books.author, books.genre, books.publication_date, books.barcode, books.shelf_id, books.publication_date_precision, books.bundle_id, books.isbn, books.publisher, books.page_count, books.edition, books.language, books.series, books.series_number, books.search, books.custom_fields, books.position, books.thickness_cm, books.missing_since FROM books
+INNER JOIN shelves
+ON books.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE books.barcode = $1
+AND location_user.user_id = $2
+`
+
+type GetBooksByBarcodeForUserParams struct {
+	Barcode string
+	UserID  uuid.UUID
+}
+
+func (q *Queries) GetBooksByBarcodeForUser(ctx context.Context, arg GetBooksByBarcodeForUserParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksByBarcodeForUser, arg.Barcode, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Author,
+			&i.Genre,
+			&i.PublicationDate,
+			&i.Barcode,
+			&i.ShelfID,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBooksByBundle = `-- name: GetBooksByBundle :many
+SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, publication_date_precision, bundle_id, isbn, publisher, page_count, edition, language, series, series_number, search, custom_fields, position, thickness_cm, missing_since FROM books WHERE bundle_id = $1
+ORDER BY position, title
 `
 
-func (q *Queries) GetBooks(ctx context.Context) ([]Book, error) {
-	rows, err := q.db.QueryContext(ctx, getBooks)
+func (q *Queries) GetBooksByBundle(ctx context.Context, bundleID uuid.NullUUID) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksByBundle, bundleID)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +262,20 @@ func (q *Queries) GetBooks(ctx context.Context) ([]Book, error) {
 			&i.PublicationDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -157,7 +291,7 @@ func (q *Queries) GetBooks(ctx context.Context) ([]Book, error) {
 }
 
 const getBooksByLocation = `-- name: GetBooksByLocation :many
-SELECT books.id, books.created_at, books.updated_at, title, author, genre, publication_date, barcode, shelf_id, search, shelves.id, shelves.created_at, shelves.updated_at, shelves.name, case_id, cases.id, cases.created_at, cases.updated_at, cases.name, location_id, locations.id, locations.created_at, locations.updated_at, locations.name, owner_id FROM books
+SELECT books.id, books.created_at, books.updated_at, books.title, books.author, books.genre, books.publication_date, books.barcode, books.shelf_id, books.publication_date_precision, books.bundle_id, books.isbn, books.publisher, books.page_count, books.edition, books.language, books.series, books.series_number, books.search, books.custom_fields, books.position, books.thickness_cm, books.missing_since FROM books
 INNER JOIN shelves
 ON books.shelf_id = shelves.id
 INNER JOIN cases
@@ -165,45 +299,91 @@ ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
 WHERE locations.id = $1
+AND books.custom_fields @> $2::jsonb
+`
+
+type GetBooksByLocationParams struct {
+	LocationID   uuid.UUID
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetBooksByLocation(ctx context.Context, arg GetBooksByLocationParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksByLocation, arg.LocationID, arg.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Author,
+			&i.Genre,
+			&i.PublicationDate,
+			&i.Barcode,
+			&i.ShelfID,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBooksByLocationAndDecade = `-- name: GetBooksByLocationAndDecade :many
+SELECT books.id, books.created_at, books.updated_at, books.title, books.author, books.genre, books.publication_date, books.barcode, books.shelf_id, books.publication_date_precision, books.bundle_id, books.isbn, books.publisher, books.page_count, books.edition, books.language, books.series, books.series_number, books.search, books.custom_fields, books.position, books.thickness_cm, books.missing_since FROM books
+INNER JOIN shelves
+ON books.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+AND publication_date >= make_date($2::int, 1, 1)
+AND publication_date < make_date($2::int + 10, 1, 1)
+AND books.custom_fields @> $3::jsonb
+ORDER BY publication_date,
+    CASE publication_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title
 `
 
-type GetBooksByLocationRow struct {
-	ID              uuid.UUID
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Title           string
-	Author          string
-	Genre           string
-	PublicationDate time.Time
-	Barcode         string
-	ShelfID         uuid.UUID
-	Search          interface{}
-	ID_2            uuid.UUID
-	CreatedAt_2     time.Time
-	UpdatedAt_2     time.Time
-	Name            string
-	CaseID          uuid.UUID
-	ID_3            uuid.UUID
-	CreatedAt_3     time.Time
-	UpdatedAt_3     time.Time
-	Name_2          string
-	LocationID      uuid.UUID
-	ID_4            uuid.UUID
-	CreatedAt_4     time.Time
-	UpdatedAt_4     time.Time
-	Name_3          string
-	OwnerID         uuid.UUID
+type GetBooksByLocationAndDecadeParams struct {
+	LocationID   uuid.UUID
+	Decade       int32
+	CustomFields json.RawMessage
 }
 
-func (q *Queries) GetBooksByLocation(ctx context.Context, id uuid.UUID) ([]GetBooksByLocationRow, error) {
-	rows, err := q.db.QueryContext(ctx, getBooksByLocation, id)
+func (q *Queries) GetBooksByLocationAndDecade(ctx context.Context, arg GetBooksByLocationAndDecadeParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksByLocationAndDecade, arg.LocationID, arg.Decade, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetBooksByLocationRow
+	var items []Book
 	for rows.Next() {
-		var i GetBooksByLocationRow
+		var i Book
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -214,22 +394,20 @@ func (q *Queries) GetBooksByLocation(ctx context.Context, id uuid.UUID) ([]GetBo
 			&i.PublicationDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
 			&i.Search,
-			&i.ID_2,
-			&i.CreatedAt_2,
-			&i.UpdatedAt_2,
-			&i.Name,
-			&i.CaseID,
-			&i.ID_3,
-			&i.CreatedAt_3,
-			&i.UpdatedAt_3,
-			&i.Name_2,
-			&i.LocationID,
-			&i.ID_4,
-			&i.CreatedAt_4,
-			&i.UpdatedAt_4,
-			&i.Name_3,
-			&i.OwnerID,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -245,11 +423,18 @@ func (q *Queries) GetBooksByLocation(ctx context.Context, id uuid.UUID) ([]GetBo
 }
 
 const getBooksByShelf = `-- name: GetBooksByShelf :many
-SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, search FROM books WHERE shelf_id = $1
+SELECT id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, publication_date_precision, bundle_id, isbn, publisher, page_count, edition, language, series, series_number, search, custom_fields, position, thickness_cm, missing_since FROM books WHERE shelf_id = $1
+AND custom_fields @> $2::jsonb
+ORDER BY position, title
 `
 
-func (q *Queries) GetBooksByShelf(ctx context.Context, shelfID uuid.UUID) ([]Book, error) {
-	rows, err := q.db.QueryContext(ctx, getBooksByShelf, shelfID)
+type GetBooksByShelfParams struct {
+	ShelfID      uuid.UUID
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetBooksByShelf(ctx context.Context, arg GetBooksByShelfParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksByShelf, arg.ShelfID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +452,20 @@ func (q *Queries) GetBooksByShelf(ctx context.Context, shelfID uuid.UUID) ([]Boo
 			&i.PublicationDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -282,52 +480,27 @@ func (q *Queries) GetBooksByShelf(ctx context.Context, shelfID uuid.UUID) ([]Boo
 	return items, nil
 }
 
-const searchBooks = `-- name: SearchBooks :many
-SELECT books.id, books.created_at, books.updated_at, title, author, genre, publication_date, barcode, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM books
+const getBooksForUser = `-- name: GetBooksForUser :many
+SELECT books.id, books.created_at, books.updated_at, books.title, books.author, books.genre, books.publication_date, books.barcode, books.shelf_id, books.publication_date_precision, books.bundle_id, books.isbn, books.publisher, books.page_count, books.edition, books.language, books.series, books.series_number, books.search, books.custom_fields, books.position, books.thickness_cm, books.missing_since FROM books
 INNER JOIN shelves
 ON books.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY books.title
 `
 
-type SearchBooksParams struct {
-	WebsearchToTsquery string
-	ID                 uuid.UUID
-}
-
-type SearchBooksRow struct {
-	ID              uuid.UUID
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Title           string
-	Author          string
-	Genre           string
-	PublicationDate time.Time
-	Barcode         string
-	ShelfID         uuid.UUID
-	Rank            float64
-}
-
-func (q *Queries) SearchBooks(ctx context.Context, arg SearchBooksParams) ([]SearchBooksRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchBooks, arg.WebsearchToTsquery, arg.ID)
+func (q *Queries) GetBooksForUser(ctx context.Context, userID uuid.UUID) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, getBooksForUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchBooksRow
+	var items []Book
 	for rows.Next() {
-		var i SearchBooksRow
+		var i Book
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -338,7 +511,20 @@ func (q *Queries) SearchBooks(ctx context.Context, arg SearchBooksParams) ([]Sea
 			&i.PublicationDate,
 			&i.Barcode,
 			&i.ShelfID,
-			&i.Rank,
+			&i.PublicationDatePrecision,
+			&i.BundleID,
+			&i.Isbn,
+			&i.Publisher,
+			&i.PageCount,
+			&i.Edition,
+			&i.Language,
+			&i.Series,
+			&i.SeriesNumber,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -352,3 +538,163 @@ func (q *Queries) SearchBooks(ctx context.Context, arg SearchBooksParams) ([]Sea
 	}
 	return items, nil
 }
+
+const lockBook = `-- name: LockBook :one
+SELECT updated_at FROM books WHERE id = $1 FOR UPDATE
+`
+
+// LockBook locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockBook(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockBook, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const moveBook = `-- name: MoveBook :exec
+UPDATE books
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2
+`
+
+type MoveBookParams struct {
+	ID      uuid.UUID
+	ShelfID uuid.UUID
+}
+
+func (q *Queries) MoveBook(ctx context.Context, arg MoveBookParams) error {
+	_, err := q.db.ExecContext(ctx, moveBook, arg.ID, arg.ShelfID)
+	return err
+}
+
+const removeBookFromBundle = `-- name: RemoveBookFromBundle :execrows
+UPDATE books
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2
+`
+
+type RemoveBookFromBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+func (q *Queries) RemoveBookFromBundle(ctx context.Context, arg RemoveBookFromBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeBookFromBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setBookMissingSince = `-- name: SetBookMissingSince :exec
+UPDATE books
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1
+`
+
+type SetBookMissingSinceParams struct {
+	ID           uuid.UUID
+	MissingSince sql.NullTime
+}
+
+func (q *Queries) SetBookMissingSince(ctx context.Context, arg SetBookMissingSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setBookMissingSince, arg.ID, arg.MissingSince)
+	return err
+}
+
+const setBookPosition = `-- name: SetBookPosition :exec
+UPDATE books
+SET updated_at = NOW(), position = $2
+WHERE id = $1
+`
+
+type SetBookPositionParams struct {
+	ID       uuid.UUID
+	Position int32
+}
+
+func (q *Queries) SetBookPosition(ctx context.Context, arg SetBookPositionParams) error {
+	_, err := q.db.ExecContext(ctx, setBookPosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateBook = `-- name: UpdateBook :one
+UPDATE books
+SET updated_at = NOW(), title = $2, author = $3, genre = $4, publication_date = $5,
+    publication_date_precision = $6, barcode = $7, shelf_id = $8, isbn = $9, publisher = $10, page_count = $11,
+    edition = $12, language = $13, series = $14, series_number = $15, custom_fields = $16, thickness_cm = $17,
+    position = CASE WHEN shelf_id = $8 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $8) END
+WHERE id = $1
+RETURNING id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id, publication_date_precision, bundle_id, isbn, publisher, page_count, edition, language, series, series_number, search, custom_fields, position, thickness_cm, missing_since
+`
+
+type UpdateBookParams struct {
+	ID                       uuid.UUID
+	Title                    string
+	Author                   string
+	Genre                    string
+	PublicationDate          sql.NullTime
+	PublicationDatePrecision string
+	Barcode                  string
+	ShelfID                  uuid.UUID
+	Isbn                     string
+	Publisher                string
+	PageCount                int32
+	Edition                  string
+	Language                 string
+	Series                   string
+	SeriesNumber             string
+	CustomFields             json.RawMessage
+	ThicknessCm              float64
+}
+
+func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, updateBook,
+		arg.ID,
+		arg.Title,
+		arg.Author,
+		arg.Genre,
+		arg.PublicationDate,
+		arg.PublicationDatePrecision,
+		arg.Barcode,
+		arg.ShelfID,
+		arg.Isbn,
+		arg.Publisher,
+		arg.PageCount,
+		arg.Edition,
+		arg.Language,
+		arg.Series,
+		arg.SeriesNumber,
+		arg.CustomFields,
+		arg.ThicknessCm,
+	)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Author,
+		&i.Genre,
+		&i.PublicationDate,
+		&i.Barcode,
+		&i.ShelfID,
+		&i.PublicationDatePrecision,
+		&i.BundleID,
+		&i.Isbn,
+		&i.Publisher,
+		&i.PageCount,
+		&i.Edition,
+		&i.Language,
+		&i.Series,
+		&i.SeriesNumber,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
+	)
+	return i, err
+}
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -16,7 +17,7 @@ INSERT INTO cases (id, created_at, updated_at, name, location_id)
 VALUES (
     gen_random_uuid(), NOW(), NOW(), $1, $2
 )
-RETURNING id, created_at, updated_at, name, location_id
+RETURNING id, created_at, updated_at, name, location_id, barcode
 `
 
 type CreateCaseParams struct {
@@ -33,12 +34,36 @@ func (q *Queries) CreateCase(ctx context.Context, arg CreateCaseParams) (Case, e
 		&i.UpdatedAt,
 		&i.Name,
 		&i.LocationID,
+		&i.Barcode,
+	)
+	return i, err
+}
+
+const getCaseByBarcode = `-- name: GetCaseByBarcode :one
+SELECT id, created_at, updated_at, name, location_id, barcode FROM cases WHERE location_id = $1 AND barcode = $2
+`
+
+type GetCaseByBarcodeParams struct {
+	LocationID uuid.UUID
+	Barcode    string
+}
+
+func (q *Queries) GetCaseByBarcode(ctx context.Context, arg GetCaseByBarcodeParams) (Case, error) {
+	row := q.db.QueryRowContext(ctx, getCaseByBarcode, arg.LocationID, arg.Barcode)
+	var i Case
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.LocationID,
+		&i.Barcode,
 	)
 	return i, err
 }
 
 const getCaseByID = `-- name: GetCaseByID :one
-SELECT id, created_at, updated_at, name, location_id FROM cases WHERE id = $1
+SELECT id, created_at, updated_at, name, location_id, barcode FROM cases WHERE id = $1
 `
 
 func (q *Queries) GetCaseByID(ctx context.Context, id uuid.UUID) (Case, error) {
@@ -50,6 +75,7 @@ func (q *Queries) GetCaseByID(ctx context.Context, id uuid.UUID) (Case, error) {
 		&i.UpdatedAt,
 		&i.Name,
 		&i.LocationID,
+		&i.Barcode,
 	)
 	return i, err
 }
@@ -74,7 +100,7 @@ func (q *Queries) GetCaseLocation(ctx context.Context, id uuid.UUID) (GetCaseLoc
 }
 
 const getCases = `-- name: GetCases :many
-SELECT id, created_at, updated_at, name, location_id FROM cases
+SELECT id, created_at, updated_at, name, location_id, barcode FROM cases
 `
 
 func (q *Queries) GetCases(ctx context.Context) ([]Case, error) {
@@ -92,6 +118,7 @@ func (q *Queries) GetCases(ctx context.Context) ([]Case, error) {
 			&i.UpdatedAt,
 			&i.Name,
 			&i.LocationID,
+			&i.Barcode,
 		); err != nil {
 			return nil, err
 		}
@@ -107,7 +134,7 @@ func (q *Queries) GetCases(ctx context.Context) ([]Case, error) {
 }
 
 const getCasesByLocation = `-- name: GetCasesByLocation :many
-SELECT id, created_at, updated_at, name, location_id FROM cases WHERE location_id = $1
+SELECT id, created_at, updated_at, name, location_id, barcode FROM cases WHERE location_id = $1
 `
 
 func (q *Queries) GetCasesByLocation(ctx context.Context, locationID uuid.UUID) ([]Case, error) {
@@ -125,6 +152,7 @@ func (q *Queries) GetCasesByLocation(ctx context.Context, locationID uuid.UUID)
 			&i.UpdatedAt,
 			&i.Name,
 			&i.LocationID,
+			&i.Barcode,
 		); err != nil {
 			return nil, err
 		}
@@ -138,3 +166,42 @@ func (q *Queries) GetCasesByLocation(ctx context.Context, locationID uuid.UUID)
 	}
 	return items, nil
 }
+
+const lockCase = `-- name: LockCase :one
+SELECT updated_at FROM cases WHERE id = $1 FOR UPDATE
+`
+
+// LockCase locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockCase(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockCase, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const updateCase = `-- name: UpdateCase :one
+UPDATE cases
+SET updated_at = NOW(), name = $2, barcode = $3
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, location_id, barcode
+`
+
+type UpdateCaseParams struct {
+	ID      uuid.UUID
+	Name    string
+	Barcode string
+}
+
+func (q *Queries) UpdateCase(ctx context.Context, arg UpdateCaseParams) (Case, error) {
+	row := q.db.QueryRowContext(ctx, updateCase, arg.ID, arg.Name, arg.Barcode)
+	var i Case
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.LocationID,
+		&i.Barcode,
+	)
+	return i, err
+}
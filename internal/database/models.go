@@ -6,22 +6,64 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Audit struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	LocationID  uuid.UUID
+	CaseID      uuid.NullUUID
+	ShelfID     uuid.NullUUID
+	StartedBy   uuid.UUID
+	CompletedAt sql.NullTime
+}
+
+type AuditScan struct {
+	ID        uuid.UUID
+	AuditID   uuid.UUID
+	Barcode   string
+	ShelfID   uuid.UUID
+	ScannedAt time.Time
+}
+
 type Book struct {
-	ID              uuid.UUID
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Title           string
-	Author          string
-	Genre           string
-	PublicationDate time.Time
-	Barcode         string
-	ShelfID         uuid.UUID
-	Search          interface{}
+	ID                       uuid.UUID
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	Title                    string
+	Author                   string
+	Genre                    string
+	PublicationDate          sql.NullTime
+	Barcode                  string
+	ShelfID                  uuid.UUID
+	PublicationDatePrecision string
+	BundleID                 uuid.NullUUID
+	Isbn                     string
+	Publisher                string
+	PageCount                int32
+	Edition                  string
+	Language                 string
+	Series                   string
+	SeriesNumber             string
+	Search                   interface{}
+	CustomFields             json.RawMessage
+	Position                 int32
+	ThicknessCm              float64
+	MissingSince             sql.NullTime
+}
+
+type Bundle struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Barcode   string
+	ShelfID   uuid.UUID
 }
 
 type Case struct {
@@ -30,6 +72,73 @@ type Case struct {
 	UpdatedAt  time.Time
 	Name       string
 	LocationID uuid.UUID
+	Barcode    string
+}
+
+type CustomField struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LocationID uuid.UUID
+	ItemType   string
+	Name       string
+	FieldType  string
+	Options    []string
+}
+
+type Episode struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	SeriesID      uuid.UUID
+	SeasonNumber  int32
+	EpisodeNumber int32
+	Title         string
+	ShowID        uuid.NullUUID
+	DiscNumber    int32
+}
+
+type EpisodeWatch struct {
+	UserID    uuid.UUID
+	EpisodeID uuid.UUID
+	WatchedAt time.Time
+}
+
+type Game struct {
+	ID                   uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Title                string
+	GameType             string
+	Platform             string
+	Publisher            string
+	Developer            string
+	Genre                string
+	MinPlayers           int32
+	MaxPlayers           int32
+	PlayTimeMinutes      int32
+	Edition              string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	ShelfID              uuid.UUID
+	Search               interface{}
+	CustomFields         json.RawMessage
+	Position             int32
+	ThicknessCm          float64
+	MissingSince         sql.NullTime
+	BundleID             uuid.NullUUID
+}
+
+type IdempotencyKey struct {
+	UserID       uuid.UUID
+	Key          string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	RequestHash  string
+	StatusCode   sql.NullInt32
+	ContentType  string
+	ResponseBody []byte
 }
 
 type Location struct {
@@ -40,12 +149,43 @@ type Location struct {
 	OwnerID   uuid.UUID
 }
 
+type LocationChange struct {
+	ID         int64
+	Seq        sql.NullInt64
+	Txid       int64
+	LocationID uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Operation  string
+	ChangedAt  time.Time
+}
+
 type LocationInvite struct {
 	LocationID uuid.UUID
 	UserID     uuid.UUID
 	InvitedAt  time.Time
 }
 
+type LocationItem struct {
+	ItemType             string
+	ID                   uuid.UUID
+	Title                string
+	Genre                string
+	Format               string
+	Creator              string
+	ReleaseDate          sql.NullTime
+	Barcode              string
+	ShelfID              uuid.UUID
+	CaseID               uuid.UUID
+	LocationID           uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Position             int32
+	ThicknessCm          float64
+	MissingSince         sql.NullTime
+	ReleaseDatePrecision string
+}
+
 type LocationUser struct {
 	LocationID uuid.UUID
 	UserID     uuid.UUID
@@ -53,33 +193,54 @@ type LocationUser struct {
 }
 
 type Movie struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	ShelfID     uuid.UUID
-	Search      interface{}
-	Format      string
+	ID                   uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Title                string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	Barcode              string
+	ShelfID              uuid.UUID
+	Format               string
+	ReleaseDatePrecision string
+	BundleID             uuid.NullUUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	Search               interface{}
+	CustomFields         json.RawMessage
+	Position             int32
+	ThicknessCm          float64
+	MissingSince         sql.NullTime
 }
 
 type Music struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Artist      string
-	Genre       string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
-	Search      interface{}
+	ID                   uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Title                string
+	Artist               string
+	Genre                string
+	ReleaseDate          sql.NullTime
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	ReleaseDatePrecision string
+	BundleID             uuid.NullUUID
+	Label                string
+	CatalogNumber        string
+	DiscCount            int32
+	Tracklist            json.RawMessage
+	Search               interface{}
+	CustomFields         json.RawMessage
+	Position             int32
+	ThicknessCm          float64
+	MissingSince         sql.NullTime
 }
 
 type RefreshToken struct {
@@ -91,29 +252,104 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime
 }
 
+type ScanDraft struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	SessionID   uuid.UUID
+	ShelfID     uuid.UUID
+	Barcode     string
+	ItemType    string
+	Title       string
+	Creator     string
+	Genre       string
+	ReleaseDate string
+	Source      string
+}
+
+type ScanSession struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LocationID uuid.UUID
+	StartedBy  uuid.UUID
+	ShelfID    uuid.NullUUID
+	ClosedAt   sql.NullTime
+}
+
+type SearchItem struct {
+	ItemType             string
+	ID                   uuid.UUID
+	Title                string
+	Creator              string
+	Genre                string
+	Format               string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Details              string
+	Search               interface{}
+	ShelfID              uuid.UUID
+	ShelfName            string
+	CaseID               uuid.UUID
+	CaseName             string
+	LocationID           uuid.UUID
+}
+
+type Series struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Title      string
+	LocationID uuid.UUID
+}
+
 type Shelf struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Name      string
 	CaseID    uuid.UUID
+	Capacity  sql.NullInt32
+	WidthCm   sql.NullFloat64
+	Barcode   string
 }
 
 type Show struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Season      string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	ShelfID     uuid.UUID
-	Search      interface{}
-	Format      string
+	ID                   uuid.UUID
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Title                string
+	Season               string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	Barcode              string
+	ShelfID              uuid.UUID
+	Format               string
+	ReleaseDatePrecision string
+	BundleID             uuid.NullUUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	Search               interface{}
+	CustomFields         json.RawMessage
+	Position             int32
+	ThicknessCm          float64
+	MissingSince         sql.NullTime
+}
+
+type SmartShelf struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LocationID uuid.UUID
+	Name       string
+	Query      string
+	Filters    json.RawMessage
+	Pinned     bool
 }
 
 type User struct {
@@ -124,3 +360,29 @@ type User struct {
 	Email          string
 	HashedPassword string
 }
+
+type Webhook struct {
+	ID         uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LocationID uuid.UUID
+	Url        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	WebhookID      uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastAttemptAt  sql.NullTime
+	ResponseStatus sql.NullInt32
+	LastError      string
+}
@@ -7,29 +7,60 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addMovieToBundle = `-- name: AddMovieToBundle :execrows
+UPDATE movies
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2)
+`
+
+type AddMovieToBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+// AddMovieToBundle doesn't take an item out of another bundle.
+func (q *Queries) AddMovieToBundle(ctx context.Context, arg AddMovieToBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addMovieToBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createMovie = `-- name: CreateMovie :one
-INSERT INTO movies (id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, format, shelf_id)
+INSERT INTO movies (id, created_at, updated_at, title, genre, actors, writer, director, release_date, release_date_precision, barcode, format, shelf_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $10)
 )
-RETURNING id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format
+RETURNING id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since
 `
 
 type CreateMovieParams struct {
-	Title       string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
+	Title                string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
 }
 
 func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie, error) {
@@ -40,9 +71,17 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie
 		arg.Writer,
 		arg.Director,
 		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
 		arg.Barcode,
 		arg.Format,
 		arg.ShelfID,
+		arg.RuntimeMinutes,
+		arg.ContentRating,
+		arg.DiscRegion,
+		arg.AudioLanguages,
+		arg.SubtitleLanguages,
+		arg.CustomFields,
+		arg.ThicknessCm,
 	)
 	var i Movie
 	err := row.Scan(
@@ -57,39 +96,34 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Movie
 		&i.ReleaseDate,
 		&i.Barcode,
 		&i.ShelfID,
-		&i.Search,
 		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
 
-const getMovieByBarcode = `-- name: GetMovieByBarcode :one
-SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM movies WHERE barcode = $1
+const deleteMovie = `-- name: DeleteMovie :exec
+DELETE FROM movies WHERE id = $1
 `
 
-func (q *Queries) GetMovieByBarcode(ctx context.Context, barcode string) (Movie, error) {
-	row := q.db.QueryRowContext(ctx, getMovieByBarcode, barcode)
-	var i Movie
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Title,
-		&i.Genre,
-		&i.Actors,
-		&i.Writer,
-		&i.Director,
-		&i.ReleaseDate,
-		&i.Barcode,
-		&i.ShelfID,
-		&i.Search,
-		&i.Format,
-	)
-	return i, err
+func (q *Queries) DeleteMovie(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteMovie, id)
+	return err
 }
 
 const getMovieByID = `-- name: GetMovieByID :one
-SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM movies WHERE id = $1
+SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM movies WHERE id = $1
 `
 
 func (q *Queries) GetMovieByID(ctx context.Context, id uuid.UUID) (Movie, error) {
@@ -107,8 +141,19 @@ func (q *Queries) GetMovieByID(ctx context.Context, id uuid.UUID) (Movie, error)
 		&i.ReleaseDate,
 		&i.Barcode,
 		&i.ShelfID,
-		&i.Search,
 		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
@@ -134,12 +179,25 @@ func (q *Queries) GetMovieLocation(ctx context.Context, id uuid.UUID) (GetMovieL
 	return i, err
 }
 
-const getMovies = `-- name: GetMovies :many
-SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM movies
+const getMoviesByBarcodeForUser = `-- name: GetMoviesByBarcodeForUser :many
+SELECT movies.id, movies.created_at, movies.updated_at, movies.title, movies.genre, movies.actors, movies.writer, movies.director, movies.release_date, movies.barcode, movies.shelf_id, movies.format, movies.release_date_precision, movies.bundle_id, movies.runtime_minutes, movies.content_rating, movies.disc_region, movies.audio_languages, movies.subtitle_languages, movies.search, movies.custom_fields, movies.position, movies.thickness_cm, movies.missing_since FROM movies
+INNER JOIN shelves
+ON movies.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE movies.barcode = $1
+AND location_user.user_id = $2
 `
 
-func (q *Queries) GetMovies(ctx context.Context) ([]Movie, error) {
-	rows, err := q.db.QueryContext(ctx, getMovies)
+type GetMoviesByBarcodeForUserParams struct {
+	Barcode string
+	UserID  uuid.UUID
+}
+
+func (q *Queries) GetMoviesByBarcodeForUser(ctx context.Context, arg GetMoviesByBarcodeForUserParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesByBarcodeForUser, arg.Barcode, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,8 +217,72 @@ func (q *Queries) GetMovies(ctx context.Context) ([]Movie, error) {
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMoviesByBundle = `-- name: GetMoviesByBundle :many
+SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM movies WHERE bundle_id = $1
+ORDER BY position, title
+`
+
+func (q *Queries) GetMoviesByBundle(ctx context.Context, bundleID uuid.NullUUID) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesByBundle, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Movie
+	for rows.Next() {
+		var i Movie
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Genre,
+			&i.Actors,
+			&i.Writer,
+			&i.Director,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
 			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -176,7 +298,7 @@ func (q *Queries) GetMovies(ctx context.Context) ([]Movie, error) {
 }
 
 const getMoviesByLocation = `-- name: GetMoviesByLocation :many
-SELECT movies.id, movies.created_at, movies.updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format, shelves.id, shelves.created_at, shelves.updated_at, shelves.name, case_id, cases.id, cases.created_at, cases.updated_at, cases.name, location_id, locations.id, locations.created_at, locations.updated_at, locations.name, owner_id FROM movies
+SELECT movies.id, movies.created_at, movies.updated_at, movies.title, movies.genre, movies.actors, movies.writer, movies.director, movies.release_date, movies.barcode, movies.shelf_id, movies.format, movies.release_date_precision, movies.bundle_id, movies.runtime_minutes, movies.content_rating, movies.disc_region, movies.audio_languages, movies.subtitle_languages, movies.search, movies.custom_fields, movies.position, movies.thickness_cm, movies.missing_since FROM movies
 INNER JOIN shelves
 ON movies.shelf_id = shelves.id
 INNER JOIN cases
@@ -184,48 +306,23 @@ ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
 WHERE locations.id = $1
+AND movies.custom_fields @> $2::jsonb
 `
 
-type GetMoviesByLocationRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	ShelfID     uuid.UUID
-	Search      interface{}
-	Format      string
-	ID_2        uuid.UUID
-	CreatedAt_2 time.Time
-	UpdatedAt_2 time.Time
-	Name        string
-	CaseID      uuid.UUID
-	ID_3        uuid.UUID
-	CreatedAt_3 time.Time
-	UpdatedAt_3 time.Time
-	Name_2      string
-	LocationID  uuid.UUID
-	ID_4        uuid.UUID
-	CreatedAt_4 time.Time
-	UpdatedAt_4 time.Time
-	Name_3      string
-	OwnerID     uuid.UUID
+type GetMoviesByLocationParams struct {
+	LocationID   uuid.UUID
+	CustomFields json.RawMessage
 }
 
-func (q *Queries) GetMoviesByLocation(ctx context.Context, id uuid.UUID) ([]GetMoviesByLocationRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMoviesByLocation, id)
+func (q *Queries) GetMoviesByLocation(ctx context.Context, arg GetMoviesByLocationParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesByLocation, arg.LocationID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMoviesByLocationRow
+	var items []Movie
 	for rows.Next() {
-		var i GetMoviesByLocationRow
+		var i Movie
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -238,23 +335,88 @@ func (q *Queries) GetMoviesByLocation(ctx context.Context, id uuid.UUID) ([]GetM
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMoviesByLocationAndDecade = `-- name: GetMoviesByLocationAndDecade :many
+SELECT movies.id, movies.created_at, movies.updated_at, movies.title, movies.genre, movies.actors, movies.writer, movies.director, movies.release_date, movies.barcode, movies.shelf_id, movies.format, movies.release_date_precision, movies.bundle_id, movies.runtime_minutes, movies.content_rating, movies.disc_region, movies.audio_languages, movies.subtitle_languages, movies.search, movies.custom_fields, movies.position, movies.thickness_cm, movies.missing_since FROM movies
+INNER JOIN shelves
+ON movies.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+AND release_date >= make_date($2::int, 1, 1)
+AND release_date < make_date($2::int + 10, 1, 1)
+AND movies.custom_fields @> $3::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title
+`
+
+type GetMoviesByLocationAndDecadeParams struct {
+	LocationID   uuid.UUID
+	Decade       int32
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetMoviesByLocationAndDecade(ctx context.Context, arg GetMoviesByLocationAndDecadeParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesByLocationAndDecade, arg.LocationID, arg.Decade, arg.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Movie
+	for rows.Next() {
+		var i Movie
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Genre,
+			&i.Actors,
+			&i.Writer,
+			&i.Director,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
 			&i.Format,
-			&i.ID_2,
-			&i.CreatedAt_2,
-			&i.UpdatedAt_2,
-			&i.Name,
-			&i.CaseID,
-			&i.ID_3,
-			&i.CreatedAt_3,
-			&i.UpdatedAt_3,
-			&i.Name_2,
-			&i.LocationID,
-			&i.ID_4,
-			&i.CreatedAt_4,
-			&i.UpdatedAt_4,
-			&i.Name_3,
-			&i.OwnerID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -270,11 +432,18 @@ func (q *Queries) GetMoviesByLocation(ctx context.Context, id uuid.UUID) ([]GetM
 }
 
 const getMoviesByShelf = `-- name: GetMoviesByShelf :many
-SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM movies WHERE shelf_id = $1
+SELECT id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM movies WHERE shelf_id = $1
+AND custom_fields @> $2::jsonb
+ORDER BY position, title
 `
 
-func (q *Queries) GetMoviesByShelf(ctx context.Context, shelfID uuid.UUID) ([]Movie, error) {
-	rows, err := q.db.QueryContext(ctx, getMoviesByShelf, shelfID)
+type GetMoviesByShelfParams struct {
+	ShelfID      uuid.UUID
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetMoviesByShelf(ctx context.Context, arg GetMoviesByShelfParams) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesByShelf, arg.ShelfID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
@@ -294,8 +463,19 @@ func (q *Queries) GetMoviesByShelf(ctx context.Context, shelfID uuid.UUID) ([]Mo
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
-			&i.Search,
 			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -310,55 +490,27 @@ func (q *Queries) GetMoviesByShelf(ctx context.Context, shelfID uuid.UUID) ([]Mo
 	return items, nil
 }
 
-const searchMovies = `-- name: SearchMovies :many
-SELECT movies.id, movies.created_at, movies.updated_at, title, genre, actors, writer, director, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM movies
+const getMoviesForUser = `-- name: GetMoviesForUser :many
+SELECT movies.id, movies.created_at, movies.updated_at, movies.title, movies.genre, movies.actors, movies.writer, movies.director, movies.release_date, movies.barcode, movies.shelf_id, movies.format, movies.release_date_precision, movies.bundle_id, movies.runtime_minutes, movies.content_rating, movies.disc_region, movies.audio_languages, movies.subtitle_languages, movies.search, movies.custom_fields, movies.position, movies.thickness_cm, movies.missing_since FROM movies
 INNER JOIN shelves
 ON movies.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY movies.title
 `
 
-type SearchMoviesParams struct {
-	WebsearchToTsquery string
-	ID                 uuid.UUID
-}
-
-type SearchMoviesRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
-	Rank        float64
-}
-
-func (q *Queries) SearchMovies(ctx context.Context, arg SearchMoviesParams) ([]SearchMoviesRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchMovies, arg.WebsearchToTsquery, arg.ID)
+func (q *Queries) GetMoviesForUser(ctx context.Context, userID uuid.UUID) ([]Movie, error) {
+	rows, err := q.db.QueryContext(ctx, getMoviesForUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchMoviesRow
+	var items []Movie
 	for rows.Next() {
-		var i SearchMoviesRow
+		var i Movie
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -370,9 +522,20 @@ func (q *Queries) SearchMovies(ctx context.Context, arg SearchMoviesParams) ([]S
 			&i.Director,
 			&i.ReleaseDate,
 			&i.Barcode,
-			&i.Format,
 			&i.ShelfID,
-			&i.Rank,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -387,24 +550,115 @@ func (q *Queries) SearchMovies(ctx context.Context, arg SearchMoviesParams) ([]S
 	return items, nil
 }
 
+const lockMovie = `-- name: LockMovie :one
+SELECT updated_at FROM movies WHERE id = $1 FOR UPDATE
+`
+
+// LockMovie locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockMovie(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockMovie, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const moveMovie = `-- name: MoveMovie :exec
+UPDATE movies
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2
+`
+
+type MoveMovieParams struct {
+	ID      uuid.UUID
+	ShelfID uuid.UUID
+}
+
+func (q *Queries) MoveMovie(ctx context.Context, arg MoveMovieParams) error {
+	_, err := q.db.ExecContext(ctx, moveMovie, arg.ID, arg.ShelfID)
+	return err
+}
+
+const removeMovieFromBundle = `-- name: RemoveMovieFromBundle :execrows
+UPDATE movies
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2
+`
+
+type RemoveMovieFromBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+func (q *Queries) RemoveMovieFromBundle(ctx context.Context, arg RemoveMovieFromBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeMovieFromBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setMovieMissingSince = `-- name: SetMovieMissingSince :exec
+UPDATE movies
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1
+`
+
+type SetMovieMissingSinceParams struct {
+	ID           uuid.UUID
+	MissingSince sql.NullTime
+}
+
+func (q *Queries) SetMovieMissingSince(ctx context.Context, arg SetMovieMissingSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setMovieMissingSince, arg.ID, arg.MissingSince)
+	return err
+}
+
+const setMoviePosition = `-- name: SetMoviePosition :exec
+UPDATE movies
+SET updated_at = NOW(), position = $2
+WHERE id = $1
+`
+
+type SetMoviePositionParams struct {
+	ID       uuid.UUID
+	Position int32
+}
+
+func (q *Queries) SetMoviePosition(ctx context.Context, arg SetMoviePositionParams) error {
+	_, err := q.db.ExecContext(ctx, setMoviePosition, arg.ID, arg.Position)
+	return err
+}
+
 const updateMovie = `-- name: UpdateMovie :one
 UPDATE movies
-SET updated_at = NOW(), title = $2, genre = $3, actors = $4, writer = $5, director = $6, release_date = $7, barcode = $8, format = $9, shelf_id = $10
+SET updated_at = NOW(), title = $2, genre = $3, actors = $4, writer = $5, director = $6, release_date = $7, release_date_precision = $8, barcode = $9, format = $10, shelf_id = $11,
+    runtime_minutes = $12, content_rating = $13, disc_region = $14, audio_languages = $15, subtitle_languages = $16, custom_fields = $17, thickness_cm = $18,
+    position = CASE WHEN shelf_id = $11 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $11) END
 WHERE id = $1
-RETURNING id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, search, format
+RETURNING id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since
 `
 
 type UpdateMovieParams struct {
-	ID          uuid.UUID
-	Title       string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
+	ID                   uuid.UUID
+	Title                string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
 }
 
 func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Movie, error) {
@@ -416,9 +670,17 @@ func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Movie
 		arg.Writer,
 		arg.Director,
 		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
 		arg.Barcode,
 		arg.Format,
 		arg.ShelfID,
+		arg.RuntimeMinutes,
+		arg.ContentRating,
+		arg.DiscRegion,
+		arg.AudioLanguages,
+		arg.SubtitleLanguages,
+		arg.CustomFields,
+		arg.ThicknessCm,
 	)
 	var i Movie
 	err := row.Scan(
@@ -433,8 +695,19 @@ func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Movie
 		&i.ReleaseDate,
 		&i.Barcode,
 		&i.ShelfID,
-		&i.Search,
 		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
@@ -7,26 +7,56 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addMusicToBundle = `-- name: AddMusicToBundle :execrows
+UPDATE music
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2)
+`
+
+type AddMusicToBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+// AddMusicToBundle doesn't take an item out of another bundle.
+func (q *Queries) AddMusicToBundle(ctx context.Context, arg AddMusicToBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addMusicToBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createMusic = `-- name: CreateMusic :one
-INSERT INTO music (id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id)
+INSERT INTO music (id, created_at, updated_at, title, artist, genre, release_date, release_date_precision, barcode, format, shelf_id, label, catalog_number, disc_count, tracklist, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, search
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $8)
+) RETURNING id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, release_date_precision, bundle_id, label, catalog_number, disc_count, tracklist, search, custom_fields, position, thickness_cm, missing_since
 `
 
 type CreateMusicParams struct {
-	Title       string
-	Artist      string
-	Genre       string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
+	Title                string
+	Artist               string
+	Genre                string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	Label                string
+	CatalogNumber        string
+	DiscCount            int32
+	Tracklist            json.RawMessage
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
 }
 
 func (q *Queries) CreateMusic(ctx context.Context, arg CreateMusicParams) (Music, error) {
@@ -35,9 +65,16 @@ func (q *Queries) CreateMusic(ctx context.Context, arg CreateMusicParams) (Music
 		arg.Artist,
 		arg.Genre,
 		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
 		arg.Barcode,
 		arg.Format,
 		arg.ShelfID,
+		arg.Label,
+		arg.CatalogNumber,
+		arg.DiscCount,
+		arg.Tracklist,
+		arg.CustomFields,
+		arg.ThicknessCm,
 	)
 	var i Music
 	err := row.Scan(
@@ -51,17 +88,49 @@ func (q *Queries) CreateMusic(ctx context.Context, arg CreateMusicParams) (Music
 		&i.Barcode,
 		&i.Format,
 		&i.ShelfID,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.Label,
+		&i.CatalogNumber,
+		&i.DiscCount,
+		&i.Tracklist,
 		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
 
-const getMusic = `-- name: GetMusic :many
-SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, search FROM music
+const deleteMusic = `-- name: DeleteMusic :exec
+DELETE FROM music WHERE id = $1
 `
 
-func (q *Queries) GetMusic(ctx context.Context) ([]Music, error) {
-	rows, err := q.db.QueryContext(ctx, getMusic)
+func (q *Queries) DeleteMusic(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteMusic, id)
+	return err
+}
+
+const getMusicByBarcodeForUser = `-- name: GetMusicByBarcodeForUser :many
+SELECT music.id, music.created_at, music.updated_at, music.title, music.artist, music.genre, music.release_date, music.barcode, music.format, music.shelf_id, music.release_date_precision, music.bundle_id, music.label, music.catalog_number, music.disc_count, music.tracklist, music.search, music.custom_fields, music.position, music.thickness_cm, music.missing_since FROM music
+INNER JOIN shelves
+ON music.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE music.barcode = $1
+AND location_user.user_id = $2
+`
+
+type GetMusicByBarcodeForUserParams struct {
+	Barcode string
+	UserID  uuid.UUID
+}
+
+func (q *Queries) GetMusicByBarcodeForUser(ctx context.Context, arg GetMusicByBarcodeForUserParams) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicByBarcodeForUser, arg.Barcode, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +149,17 @@ func (q *Queries) GetMusic(ctx context.Context) ([]Music, error) {
 			&i.Barcode,
 			&i.Format,
 			&i.ShelfID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -95,31 +174,58 @@ func (q *Queries) GetMusic(ctx context.Context) ([]Music, error) {
 	return items, nil
 }
 
-const getMusicByBarcode = `-- name: GetMusicByBarcode :one
-SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, search FROM music WHERE barcode = $1
+const getMusicByBundle = `-- name: GetMusicByBundle :many
+SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, release_date_precision, bundle_id, label, catalog_number, disc_count, tracklist, search, custom_fields, position, thickness_cm, missing_since FROM music WHERE bundle_id = $1
+ORDER BY position, title
 `
 
-func (q *Queries) GetMusicByBarcode(ctx context.Context, barcode string) (Music, error) {
-	row := q.db.QueryRowContext(ctx, getMusicByBarcode, barcode)
-	var i Music
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Title,
-		&i.Artist,
-		&i.Genre,
-		&i.ReleaseDate,
-		&i.Barcode,
-		&i.Format,
-		&i.ShelfID,
-		&i.Search,
-	)
-	return i, err
+func (q *Queries) GetMusicByBundle(ctx context.Context, bundleID uuid.NullUUID) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicByBundle, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Music
+	for rows.Next() {
+		var i Music
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Artist,
+			&i.Genre,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.Format,
+			&i.ShelfID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getMusicByID = `-- name: GetMusicByID :one
-SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, search FROM music WHERE id = $1
+SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, release_date_precision, bundle_id, label, catalog_number, disc_count, tracklist, search, custom_fields, position, thickness_cm, missing_since FROM music WHERE id = $1
 `
 
 func (q *Queries) GetMusicByID(ctx context.Context, id uuid.UUID) (Music, error) {
@@ -136,14 +242,23 @@ func (q *Queries) GetMusicByID(ctx context.Context, id uuid.UUID) (Music, error)
 		&i.Barcode,
 		&i.Format,
 		&i.ShelfID,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.Label,
+		&i.CatalogNumber,
+		&i.DiscCount,
+		&i.Tracklist,
 		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
 
 const getMusicByLocation = `-- name: GetMusicByLocation :many
-SELECT music.id, music.created_at, music.updated_at, title, artist, genre, release_date, barcode, format, shelf_id
-FROM music
+SELECT music.id, music.created_at, music.updated_at, music.title, music.artist, music.genre, music.release_date, music.barcode, music.format, music.shelf_id, music.release_date_precision, music.bundle_id, music.label, music.catalog_number, music.disc_count, music.tracklist, music.search, music.custom_fields, music.position, music.thickness_cm, music.missing_since FROM music
 INNER JOIN shelves
 ON music.shelf_id = shelves.id
 INNER JOIN cases
@@ -151,30 +266,23 @@ ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
 WHERE locations.id = $1
+AND music.custom_fields @> $2::jsonb
 `
 
-type GetMusicByLocationRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Artist      string
-	Genre       string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
+type GetMusicByLocationParams struct {
+	LocationID   uuid.UUID
+	CustomFields json.RawMessage
 }
 
-func (q *Queries) GetMusicByLocation(ctx context.Context, id uuid.UUID) ([]GetMusicByLocationRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMusicByLocation, id)
+func (q *Queries) GetMusicByLocation(ctx context.Context, arg GetMusicByLocationParams) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicByLocation, arg.LocationID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMusicByLocationRow
+	var items []Music
 	for rows.Next() {
-		var i GetMusicByLocationRow
+		var i Music
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -186,6 +294,17 @@ func (q *Queries) GetMusicByLocation(ctx context.Context, id uuid.UUID) ([]GetMu
 			&i.Barcode,
 			&i.Format,
 			&i.ShelfID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -200,12 +319,29 @@ func (q *Queries) GetMusicByLocation(ctx context.Context, id uuid.UUID) ([]GetMu
 	return items, nil
 }
 
-const getMusicByShelf = `-- name: GetMusicByShelf :many
-SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, search FROM music WHERE shelf_id = $1
+const getMusicByLocationAndDecade = `-- name: GetMusicByLocationAndDecade :many
+SELECT music.id, music.created_at, music.updated_at, music.title, music.artist, music.genre, music.release_date, music.barcode, music.format, music.shelf_id, music.release_date_precision, music.bundle_id, music.label, music.catalog_number, music.disc_count, music.tracklist, music.search, music.custom_fields, music.position, music.thickness_cm, music.missing_since FROM music
+INNER JOIN shelves
+ON music.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+AND release_date >= make_date($2::int, 1, 1)
+AND release_date < make_date($2::int + 10, 1, 1)
+AND music.custom_fields @> $3::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title
 `
 
-func (q *Queries) GetMusicByShelf(ctx context.Context, shelfID uuid.UUID) ([]Music, error) {
-	rows, err := q.db.QueryContext(ctx, getMusicByShelf, shelfID)
+type GetMusicByLocationAndDecadeParams struct {
+	LocationID   uuid.UUID
+	Decade       int32
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetMusicByLocationAndDecade(ctx context.Context, arg GetMusicByLocationAndDecadeParams) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicByLocationAndDecade, arg.LocationID, arg.Decade, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +360,17 @@ func (q *Queries) GetMusicByShelf(ctx context.Context, shelfID uuid.UUID) ([]Mus
 			&i.Barcode,
 			&i.Format,
 			&i.ShelfID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -239,74 +385,83 @@ func (q *Queries) GetMusicByShelf(ctx context.Context, shelfID uuid.UUID) ([]Mus
 	return items, nil
 }
 
-const getMusicLocation = `-- name: GetMusicLocation :one
-SELECT locations.id, locations.name
-FROM locations
-JOIN cases ON locations.id = cases.location_id
-JOIN shelves ON cases.id = shelves.case_id
-JOIN music ON shelves.id = music.shelf_id
-WHERE music.id = $1
+const getMusicByShelf = `-- name: GetMusicByShelf :many
+SELECT id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, release_date_precision, bundle_id, label, catalog_number, disc_count, tracklist, search, custom_fields, position, thickness_cm, missing_since FROM music WHERE shelf_id = $1
+AND custom_fields @> $2::jsonb
+ORDER BY position, title
 `
 
-type GetMusicLocationRow struct {
-	ID   uuid.UUID
-	Name string
+type GetMusicByShelfParams struct {
+	ShelfID      uuid.UUID
+	CustomFields json.RawMessage
 }
 
-func (q *Queries) GetMusicLocation(ctx context.Context, id uuid.UUID) (GetMusicLocationRow, error) {
-	row := q.db.QueryRowContext(ctx, getMusicLocation, id)
-	var i GetMusicLocationRow
-	err := row.Scan(&i.ID, &i.Name)
-	return i, err
+func (q *Queries) GetMusicByShelf(ctx context.Context, arg GetMusicByShelfParams) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicByShelf, arg.ShelfID, arg.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Music
+	for rows.Next() {
+		var i Music
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Artist,
+			&i.Genre,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.Format,
+			&i.ShelfID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const searchMusic = `-- name: SearchMusic :many
-SELECT music.id, music.created_at, music.updated_at, title, artist, genre, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM music
+const getMusicForUser = `-- name: GetMusicForUser :many
+SELECT music.id, music.created_at, music.updated_at, music.title, music.artist, music.genre, music.release_date, music.barcode, music.format, music.shelf_id, music.release_date_precision, music.bundle_id, music.label, music.catalog_number, music.disc_count, music.tracklist, music.search, music.custom_fields, music.position, music.thickness_cm, music.missing_since FROM music
 INNER JOIN shelves
 ON music.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY music.title
 `
 
-type SearchMusicParams struct {
-	WebsearchToTsquery string
-	ID                 uuid.UUID
-}
-
-type SearchMusicRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Artist      string
-	Genre       string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
-	Rank        float64
-}
-
-func (q *Queries) SearchMusic(ctx context.Context, arg SearchMusicParams) ([]SearchMusicRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchMusic, arg.WebsearchToTsquery, arg.ID)
+func (q *Queries) GetMusicForUser(ctx context.Context, userID uuid.UUID) ([]Music, error) {
+	rows, err := q.db.QueryContext(ctx, getMusicForUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchMusicRow
+	var items []Music
 	for rows.Next() {
-		var i SearchMusicRow
+		var i Music
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -318,7 +473,17 @@ func (q *Queries) SearchMusic(ctx context.Context, arg SearchMusicParams) ([]Sea
 			&i.Barcode,
 			&i.Format,
 			&i.ShelfID,
-			&i.Rank,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.Label,
+			&i.CatalogNumber,
+			&i.DiscCount,
+			&i.Tracklist,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -332,3 +497,178 @@ func (q *Queries) SearchMusic(ctx context.Context, arg SearchMusicParams) ([]Sea
 	}
 	return items, nil
 }
+
+const getMusicLocation = `-- name: GetMusicLocation :one
+SELECT locations.id, locations.name
+FROM locations
+JOIN cases ON locations.id = cases.location_id
+JOIN shelves ON cases.id = shelves.case_id
+JOIN music ON shelves.id = music.shelf_id
+WHERE music.id = $1
+`
+
+type GetMusicLocationRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) GetMusicLocation(ctx context.Context, id uuid.UUID) (GetMusicLocationRow, error) {
+	row := q.db.QueryRowContext(ctx, getMusicLocation, id)
+	var i GetMusicLocationRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const lockMusic = `-- name: LockMusic :one
+SELECT updated_at FROM music WHERE id = $1 FOR UPDATE
+`
+
+// LockMusic locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockMusic(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockMusic, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const moveMusic = `-- name: MoveMusic :exec
+UPDATE music
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2
+`
+
+type MoveMusicParams struct {
+	ID      uuid.UUID
+	ShelfID uuid.UUID
+}
+
+func (q *Queries) MoveMusic(ctx context.Context, arg MoveMusicParams) error {
+	_, err := q.db.ExecContext(ctx, moveMusic, arg.ID, arg.ShelfID)
+	return err
+}
+
+const removeMusicFromBundle = `-- name: RemoveMusicFromBundle :execrows
+UPDATE music
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2
+`
+
+type RemoveMusicFromBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+func (q *Queries) RemoveMusicFromBundle(ctx context.Context, arg RemoveMusicFromBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeMusicFromBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setMusicMissingSince = `-- name: SetMusicMissingSince :exec
+UPDATE music
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1
+`
+
+type SetMusicMissingSinceParams struct {
+	ID           uuid.UUID
+	MissingSince sql.NullTime
+}
+
+func (q *Queries) SetMusicMissingSince(ctx context.Context, arg SetMusicMissingSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setMusicMissingSince, arg.ID, arg.MissingSince)
+	return err
+}
+
+const setMusicPosition = `-- name: SetMusicPosition :exec
+UPDATE music
+SET updated_at = NOW(), position = $2
+WHERE id = $1
+`
+
+type SetMusicPositionParams struct {
+	ID       uuid.UUID
+	Position int32
+}
+
+func (q *Queries) SetMusicPosition(ctx context.Context, arg SetMusicPositionParams) error {
+	_, err := q.db.ExecContext(ctx, setMusicPosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateMusic = `-- name: UpdateMusic :one
+UPDATE music
+SET updated_at = NOW(), title = $2, artist = $3, genre = $4, release_date = $5, release_date_precision = $6,
+    barcode = $7, format = $8, shelf_id = $9, label = $10, catalog_number = $11, disc_count = $12,
+    tracklist = $13, custom_fields = $14, thickness_cm = $15,
+    position = CASE WHEN shelf_id = $9 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $9) END
+WHERE id = $1
+RETURNING id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id, release_date_precision, bundle_id, label, catalog_number, disc_count, tracklist, search, custom_fields, position, thickness_cm, missing_since
+`
+
+type UpdateMusicParams struct {
+	ID                   uuid.UUID
+	Title                string
+	Artist               string
+	Genre                string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	Label                string
+	CatalogNumber        string
+	DiscCount            int32
+	Tracklist            json.RawMessage
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
+}
+
+func (q *Queries) UpdateMusic(ctx context.Context, arg UpdateMusicParams) (Music, error) {
+	row := q.db.QueryRowContext(ctx, updateMusic,
+		arg.ID,
+		arg.Title,
+		arg.Artist,
+		arg.Genre,
+		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
+		arg.Barcode,
+		arg.Format,
+		arg.ShelfID,
+		arg.Label,
+		arg.CatalogNumber,
+		arg.DiscCount,
+		arg.Tracklist,
+		arg.CustomFields,
+		arg.ThicknessCm,
+	)
+	var i Music
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Artist,
+		&i.Genre,
+		&i.ReleaseDate,
+		&i.Barcode,
+		&i.Format,
+		&i.ShelfID,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.Label,
+		&i.CatalogNumber,
+		&i.DiscCount,
+		&i.Tracklist,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
+	)
+	return i, err
+}
@@ -7,25 +7,61 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createShelf = `-- name: CreateShelf :one
-INSERT INTO shelves (id, created_at, updated_at, name, case_id)
+INSERT INTO shelves (id, created_at, updated_at, name, case_id, capacity, width_cm)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4
 )
-RETURNING id, created_at, updated_at, name, case_id
+RETURNING id, created_at, updated_at, name, case_id, capacity, width_cm, barcode
 `
 
 type CreateShelfParams struct {
-	Name   string
-	CaseID uuid.UUID
+	Name     string
+	CaseID   uuid.UUID
+	Capacity sql.NullInt32
+	WidthCm  sql.NullFloat64
 }
 
 func (q *Queries) CreateShelf(ctx context.Context, arg CreateShelfParams) (Shelf, error) {
-	row := q.db.QueryRowContext(ctx, createShelf, arg.Name, arg.CaseID)
+	row := q.db.QueryRowContext(ctx, createShelf,
+		arg.Name,
+		arg.CaseID,
+		arg.Capacity,
+		arg.WidthCm,
+	)
+	var i Shelf
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.CaseID,
+		&i.Capacity,
+		&i.WidthCm,
+		&i.Barcode,
+	)
+	return i, err
+}
+
+const getShelfByBarcode = `-- name: GetShelfByBarcode :one
+SELECT shelves.id, shelves.created_at, shelves.updated_at, shelves.name, shelves.case_id, shelves.capacity, shelves.width_cm, shelves.barcode FROM shelves
+JOIN cases ON shelves.case_id = cases.id
+WHERE cases.location_id = $1 AND shelves.barcode = $2
+`
+
+type GetShelfByBarcodeParams struct {
+	LocationID uuid.UUID
+	Barcode    string
+}
+
+func (q *Queries) GetShelfByBarcode(ctx context.Context, arg GetShelfByBarcodeParams) (Shelf, error) {
+	row := q.db.QueryRowContext(ctx, getShelfByBarcode, arg.LocationID, arg.Barcode)
 	var i Shelf
 	err := row.Scan(
 		&i.ID,
@@ -33,12 +69,15 @@ func (q *Queries) CreateShelf(ctx context.Context, arg CreateShelfParams) (Shelf
 		&i.UpdatedAt,
 		&i.Name,
 		&i.CaseID,
+		&i.Capacity,
+		&i.WidthCm,
+		&i.Barcode,
 	)
 	return i, err
 }
 
 const getShelfByID = `-- name: GetShelfByID :one
-SELECT id, created_at, updated_at, name, case_id FROM shelves WHERE id = $1
+SELECT id, created_at, updated_at, name, case_id, capacity, width_cm, barcode FROM shelves WHERE id = $1
 `
 
 func (q *Queries) GetShelfByID(ctx context.Context, id uuid.UUID) (Shelf, error) {
@@ -50,10 +89,151 @@ func (q *Queries) GetShelfByID(ctx context.Context, id uuid.UUID) (Shelf, error)
 		&i.UpdatedAt,
 		&i.Name,
 		&i.CaseID,
+		&i.Capacity,
+		&i.WidthCm,
+		&i.Barcode,
 	)
 	return i, err
 }
 
+const getShelfItems = `-- name: GetShelfItems :many
+SELECT item_type, id, title, genre, format, creator, release_date, barcode, shelf_id, case_id, location_id, created_at, updated_at, position, thickness_cm, missing_since, release_date_precision FROM location_items WHERE shelf_id = $1
+ORDER BY position, title
+`
+
+func (q *Queries) GetShelfItems(ctx context.Context, shelfID uuid.UUID) ([]LocationItem, error) {
+	rows, err := q.db.QueryContext(ctx, getShelfItems, shelfID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LocationItem
+	for rows.Next() {
+		var i LocationItem
+		if err := rows.Scan(
+			&i.ItemType,
+			&i.ID,
+			&i.Title,
+			&i.Genre,
+			&i.Format,
+			&i.Creator,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
+			&i.CaseID,
+			&i.LocationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+			&i.ReleaseDatePrecision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShelfItemsByReleaseDate = `-- name: GetShelfItemsByReleaseDate :many
+SELECT item_type, id, title, genre, format, creator, release_date, barcode, shelf_id, case_id, location_id, created_at, updated_at, position, thickness_cm, missing_since, release_date_precision FROM location_items WHERE shelf_id = $1
+ORDER BY release_date NULLS LAST, lower(title), title
+`
+
+func (q *Queries) GetShelfItemsByReleaseDate(ctx context.Context, shelfID uuid.UUID) ([]LocationItem, error) {
+	rows, err := q.db.QueryContext(ctx, getShelfItemsByReleaseDate, shelfID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LocationItem
+	for rows.Next() {
+		var i LocationItem
+		if err := rows.Scan(
+			&i.ItemType,
+			&i.ID,
+			&i.Title,
+			&i.Genre,
+			&i.Format,
+			&i.Creator,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
+			&i.CaseID,
+			&i.LocationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+			&i.ReleaseDatePrecision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShelfItemsByTitle = `-- name: GetShelfItemsByTitle :many
+SELECT item_type, id, title, genre, format, creator, release_date, barcode, shelf_id, case_id, location_id, created_at, updated_at, position, thickness_cm, missing_since, release_date_precision FROM location_items WHERE shelf_id = $1
+ORDER BY lower(title), title
+`
+
+func (q *Queries) GetShelfItemsByTitle(ctx context.Context, shelfID uuid.UUID) ([]LocationItem, error) {
+	rows, err := q.db.QueryContext(ctx, getShelfItemsByTitle, shelfID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LocationItem
+	for rows.Next() {
+		var i LocationItem
+		if err := rows.Scan(
+			&i.ItemType,
+			&i.ID,
+			&i.Title,
+			&i.Genre,
+			&i.Format,
+			&i.Creator,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
+			&i.CaseID,
+			&i.LocationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+			&i.ReleaseDatePrecision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getShelfLocation = `-- name: GetShelfLocation :one
 SELECT locations.id, locations.name
 FROM locations
@@ -75,7 +255,7 @@ func (q *Queries) GetShelfLocation(ctx context.Context, id uuid.UUID) (GetShelfL
 }
 
 const getShelves = `-- name: GetShelves :many
-SELECT id, created_at, updated_at, name, case_id FROM shelves
+SELECT id, created_at, updated_at, name, case_id, capacity, width_cm, barcode FROM shelves
 `
 
 func (q *Queries) GetShelves(ctx context.Context) ([]Shelf, error) {
@@ -93,6 +273,9 @@ func (q *Queries) GetShelves(ctx context.Context) ([]Shelf, error) {
 			&i.UpdatedAt,
 			&i.Name,
 			&i.CaseID,
+			&i.Capacity,
+			&i.WidthCm,
+			&i.Barcode,
 		); err != nil {
 			return nil, err
 		}
@@ -108,7 +291,7 @@ func (q *Queries) GetShelves(ctx context.Context) ([]Shelf, error) {
 }
 
 const getShelvesByCase = `-- name: GetShelvesByCase :many
-SELECT id, created_at, updated_at, name, case_id FROM shelves WHERE case_id = $1
+SELECT id, created_at, updated_at, name, case_id, capacity, width_cm, barcode FROM shelves WHERE case_id = $1
 `
 
 func (q *Queries) GetShelvesByCase(ctx context.Context, caseID uuid.UUID) ([]Shelf, error) {
@@ -126,6 +309,105 @@ func (q *Queries) GetShelvesByCase(ctx context.Context, caseID uuid.UUID) ([]She
 			&i.UpdatedAt,
 			&i.Name,
 			&i.CaseID,
+			&i.Capacity,
+			&i.WidthCm,
+			&i.Barcode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShelvesByCaseWithFullness = `-- name: GetShelvesByCaseWithFullness :many
+SELECT shelves.id, shelves.created_at, shelves.updated_at, shelves.name, shelves.case_id, shelves.capacity, shelves.width_cm, shelves.barcode, COUNT(location_items.id) AS item_count, COALESCE(SUM(location_items.thickness_cm), 0)::float8 AS used_width_cm, COUNT(location_items.id) FILTER (WHERE location_items.thickness_cm = 0) AS unmeasured_items
+FROM shelves
+LEFT JOIN location_items ON location_items.shelf_id = shelves.id
+WHERE shelves.case_id = $1
+GROUP BY shelves.id
+`
+
+type GetShelvesByCaseWithFullnessRow struct {
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Name            string
+	CaseID          uuid.UUID
+	Capacity        sql.NullInt32
+	WidthCm         sql.NullFloat64
+	Barcode         string
+	ItemCount       int64
+	UsedWidthCm     float64
+	UnmeasuredItems int64
+}
+
+func (q *Queries) GetShelvesByCaseWithFullness(ctx context.Context, caseID uuid.UUID) ([]GetShelvesByCaseWithFullnessRow, error) {
+	rows, err := q.db.QueryContext(ctx, getShelvesByCaseWithFullness, caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetShelvesByCaseWithFullnessRow
+	for rows.Next() {
+		var i GetShelvesByCaseWithFullnessRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.CaseID,
+			&i.Capacity,
+			&i.WidthCm,
+			&i.Barcode,
+			&i.ItemCount,
+			&i.UsedWidthCm,
+			&i.UnmeasuredItems,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShelvesByLocation = `-- name: GetShelvesByLocation :many
+SELECT shelves.id, shelves.created_at, shelves.updated_at, shelves.name, shelves.case_id, shelves.capacity, shelves.width_cm, shelves.barcode FROM shelves
+JOIN cases ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+ORDER BY shelves.name
+`
+
+func (q *Queries) GetShelvesByLocation(ctx context.Context, locationID uuid.UUID) ([]Shelf, error) {
+	rows, err := q.db.QueryContext(ctx, getShelvesByLocation, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shelf
+	for rows.Next() {
+		var i Shelf
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.CaseID,
+			&i.Capacity,
+			&i.WidthCm,
+			&i.Barcode,
 		); err != nil {
 			return nil, err
 		}
@@ -139,3 +421,52 @@ func (q *Queries) GetShelvesByCase(ctx context.Context, caseID uuid.UUID) ([]She
 	}
 	return items, nil
 }
+
+const lockShelf = `-- name: LockShelf :one
+SELECT updated_at FROM shelves WHERE id = $1 FOR UPDATE
+`
+
+// LockShelf locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockShelf(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockShelf, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const updateShelf = `-- name: UpdateShelf :one
+UPDATE shelves
+SET updated_at = NOW(), name = $2, capacity = $3, width_cm = $4, barcode = $5
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, case_id, capacity, width_cm, barcode
+`
+
+type UpdateShelfParams struct {
+	ID       uuid.UUID
+	Name     string
+	Capacity sql.NullInt32
+	WidthCm  sql.NullFloat64
+	Barcode  string
+}
+
+func (q *Queries) UpdateShelf(ctx context.Context, arg UpdateShelfParams) (Shelf, error) {
+	row := q.db.QueryRowContext(ctx, updateShelf,
+		arg.ID,
+		arg.Name,
+		arg.Capacity,
+		arg.WidthCm,
+		arg.Barcode,
+	)
+	var i Shelf
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.CaseID,
+		&i.Capacity,
+		&i.WidthCm,
+		&i.Barcode,
+	)
+	return i, err
+}
@@ -7,29 +7,60 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addShowToBundle = `-- name: AddShowToBundle :execrows
+UPDATE shows
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2)
+`
+
+type AddShowToBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+// AddShowToBundle doesn't take an item out of another bundle.
+func (q *Queries) AddShowToBundle(ctx context.Context, arg AddShowToBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addShowToBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createShow = `-- name: CreateShow :one
-INSERT INTO shows (id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, format, shelf_id)
+INSERT INTO shows (id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, release_date_precision, barcode, format, shelf_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $11)
+) RETURNING id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since
 `
 
 type CreateShowParams struct {
-	Title       string
-	Season      string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
+	Title                string
+	Season               string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
 }
 
 func (q *Queries) CreateShow(ctx context.Context, arg CreateShowParams) (Show, error) {
@@ -41,9 +72,17 @@ func (q *Queries) CreateShow(ctx context.Context, arg CreateShowParams) (Show, e
 		arg.Writer,
 		arg.Director,
 		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
 		arg.Barcode,
 		arg.Format,
 		arg.ShelfID,
+		arg.RuntimeMinutes,
+		arg.ContentRating,
+		arg.DiscRegion,
+		arg.AudioLanguages,
+		arg.SubtitleLanguages,
+		arg.CustomFields,
+		arg.ThicknessCm,
 	)
 	var i Show
 	err := row.Scan(
@@ -59,40 +98,34 @@ func (q *Queries) CreateShow(ctx context.Context, arg CreateShowParams) (Show, e
 		&i.ReleaseDate,
 		&i.Barcode,
 		&i.ShelfID,
-		&i.Search,
 		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
 
-const getShowByBarcode = `-- name: GetShowByBarcode :one
-SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM shows WHERE barcode = $1
+const deleteShow = `-- name: DeleteShow :exec
+DELETE FROM shows WHERE id = $1
 `
 
-func (q *Queries) GetShowByBarcode(ctx context.Context, barcode string) (Show, error) {
-	row := q.db.QueryRowContext(ctx, getShowByBarcode, barcode)
-	var i Show
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.Title,
-		&i.Season,
-		&i.Genre,
-		&i.Actors,
-		&i.Writer,
-		&i.Director,
-		&i.ReleaseDate,
-		&i.Barcode,
-		&i.ShelfID,
-		&i.Search,
-		&i.Format,
-	)
-	return i, err
+func (q *Queries) DeleteShow(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteShow, id)
+	return err
 }
 
 const getShowByID = `-- name: GetShowByID :one
-SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM shows WHERE id = $1
+SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM shows WHERE id = $1
 `
 
 func (q *Queries) GetShowByID(ctx context.Context, id uuid.UUID) (Show, error) {
@@ -111,8 +144,19 @@ func (q *Queries) GetShowByID(ctx context.Context, id uuid.UUID) (Show, error) {
 		&i.ReleaseDate,
 		&i.Barcode,
 		&i.ShelfID,
-		&i.Search,
 		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
 	)
 	return i, err
 }
@@ -138,12 +182,25 @@ func (q *Queries) GetShowLocation(ctx context.Context, id uuid.UUID) (GetShowLoc
 	return i, err
 }
 
-const getShows = `-- name: GetShows :many
-SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM shows
+const getShowsByBarcodeForUser = `-- name: GetShowsByBarcodeForUser :many
+SELECT shows.id, shows.created_at, shows.updated_at, shows.title, shows.season, shows.genre, shows.actors, shows.writer, shows.director, shows.release_date, shows.barcode, shows.shelf_id, shows.format, shows.release_date_precision, shows.bundle_id, shows.runtime_minutes, shows.content_rating, shows.disc_region, shows.audio_languages, shows.subtitle_languages, shows.search, shows.custom_fields, shows.position, shows.thickness_cm, shows.missing_since FROM shows
+INNER JOIN shelves
+ON shows.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE shows.barcode = $1
+AND location_user.user_id = $2
 `
 
-func (q *Queries) GetShows(ctx context.Context) ([]Show, error) {
-	rows, err := q.db.QueryContext(ctx, getShows)
+type GetShowsByBarcodeForUserParams struct {
+	Barcode string
+	UserID  uuid.UUID
+}
+
+func (q *Queries) GetShowsByBarcodeForUser(ctx context.Context, arg GetShowsByBarcodeForUserParams) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsByBarcodeForUser, arg.Barcode, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -164,8 +221,73 @@ func (q *Queries) GetShows(ctx context.Context) ([]Show, error) {
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShowsByBundle = `-- name: GetShowsByBundle :many
+SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM shows WHERE bundle_id = $1
+ORDER BY position, title
+`
+
+func (q *Queries) GetShowsByBundle(ctx context.Context, bundleID uuid.NullUUID) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsByBundle, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Show
+	for rows.Next() {
+		var i Show
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Season,
+			&i.Genre,
+			&i.Actors,
+			&i.Writer,
+			&i.Director,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
 			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -181,7 +303,7 @@ func (q *Queries) GetShows(ctx context.Context) ([]Show, error) {
 }
 
 const getShowsByLocation = `-- name: GetShowsByLocation :many
-SELECT shows.id, shows.created_at, shows.updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format, shelves.id, shelves.created_at, shelves.updated_at, shelves.name, case_id, cases.id, cases.created_at, cases.updated_at, cases.name, location_id, locations.id, locations.created_at, locations.updated_at, locations.name, owner_id FROM shows
+SELECT shows.id, shows.created_at, shows.updated_at, shows.title, shows.season, shows.genre, shows.actors, shows.writer, shows.director, shows.release_date, shows.barcode, shows.shelf_id, shows.format, shows.release_date_precision, shows.bundle_id, shows.runtime_minutes, shows.content_rating, shows.disc_region, shows.audio_languages, shows.subtitle_languages, shows.search, shows.custom_fields, shows.position, shows.thickness_cm, shows.missing_since FROM shows
 INNER JOIN shelves
 ON shows.shelf_id = shelves.id
 INNER JOIN cases
@@ -189,49 +311,23 @@ ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
 WHERE locations.id = $1
+AND shows.custom_fields @> $2::jsonb
 `
 
-type GetShowsByLocationRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Season      string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	ShelfID     uuid.UUID
-	Search      interface{}
-	Format      string
-	ID_2        uuid.UUID
-	CreatedAt_2 time.Time
-	UpdatedAt_2 time.Time
-	Name        string
-	CaseID      uuid.UUID
-	ID_3        uuid.UUID
-	CreatedAt_3 time.Time
-	UpdatedAt_3 time.Time
-	Name_2      string
-	LocationID  uuid.UUID
-	ID_4        uuid.UUID
-	CreatedAt_4 time.Time
-	UpdatedAt_4 time.Time
-	Name_3      string
-	OwnerID     uuid.UUID
+type GetShowsByLocationParams struct {
+	LocationID   uuid.UUID
+	CustomFields json.RawMessage
 }
 
-func (q *Queries) GetShowsByLocation(ctx context.Context, id uuid.UUID) ([]GetShowsByLocationRow, error) {
-	rows, err := q.db.QueryContext(ctx, getShowsByLocation, id)
+func (q *Queries) GetShowsByLocation(ctx context.Context, arg GetShowsByLocationParams) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsByLocation, arg.LocationID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetShowsByLocationRow
+	var items []Show
 	for rows.Next() {
-		var i GetShowsByLocationRow
+		var i Show
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -245,23 +341,89 @@ func (q *Queries) GetShowsByLocation(ctx context.Context, id uuid.UUID) ([]GetSh
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
 			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShowsByLocationAndDecade = `-- name: GetShowsByLocationAndDecade :many
+SELECT shows.id, shows.created_at, shows.updated_at, shows.title, shows.season, shows.genre, shows.actors, shows.writer, shows.director, shows.release_date, shows.barcode, shows.shelf_id, shows.format, shows.release_date_precision, shows.bundle_id, shows.runtime_minutes, shows.content_rating, shows.disc_region, shows.audio_languages, shows.subtitle_languages, shows.search, shows.custom_fields, shows.position, shows.thickness_cm, shows.missing_since FROM shows
+INNER JOIN shelves
+ON shows.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+AND release_date >= make_date($2::int, 1, 1)
+AND release_date < make_date($2::int + 10, 1, 1)
+AND shows.custom_fields @> $3::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title
+`
+
+type GetShowsByLocationAndDecadeParams struct {
+	LocationID   uuid.UUID
+	Decade       int32
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetShowsByLocationAndDecade(ctx context.Context, arg GetShowsByLocationAndDecadeParams) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsByLocationAndDecade, arg.LocationID, arg.Decade, arg.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Show
+	for rows.Next() {
+		var i Show
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Season,
+			&i.Genre,
+			&i.Actors,
+			&i.Writer,
+			&i.Director,
+			&i.ReleaseDate,
+			&i.Barcode,
+			&i.ShelfID,
 			&i.Format,
-			&i.ID_2,
-			&i.CreatedAt_2,
-			&i.UpdatedAt_2,
-			&i.Name,
-			&i.CaseID,
-			&i.ID_3,
-			&i.CreatedAt_3,
-			&i.UpdatedAt_3,
-			&i.Name_2,
-			&i.LocationID,
-			&i.ID_4,
-			&i.CreatedAt_4,
-			&i.UpdatedAt_4,
-			&i.Name_3,
-			&i.OwnerID,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -277,11 +439,18 @@ func (q *Queries) GetShowsByLocation(ctx context.Context, id uuid.UUID) ([]GetSh
 }
 
 const getShowsByShelf = `-- name: GetShowsByShelf :many
-SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, search, format FROM shows WHERE shelf_id = $1
+SELECT id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since FROM shows WHERE shelf_id = $1
+AND custom_fields @> $2::jsonb
+ORDER BY position, title
 `
 
-func (q *Queries) GetShowsByShelf(ctx context.Context, shelfID uuid.UUID) ([]Show, error) {
-	rows, err := q.db.QueryContext(ctx, getShowsByShelf, shelfID)
+type GetShowsByShelfParams struct {
+	ShelfID      uuid.UUID
+	CustomFields json.RawMessage
+}
+
+func (q *Queries) GetShowsByShelf(ctx context.Context, arg GetShowsByShelfParams) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsByShelf, arg.ShelfID, arg.CustomFields)
 	if err != nil {
 		return nil, err
 	}
@@ -302,8 +471,19 @@ func (q *Queries) GetShowsByShelf(ctx context.Context, shelfID uuid.UUID) ([]Sho
 			&i.ReleaseDate,
 			&i.Barcode,
 			&i.ShelfID,
-			&i.Search,
 			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -318,56 +498,27 @@ func (q *Queries) GetShowsByShelf(ctx context.Context, shelfID uuid.UUID) ([]Sho
 	return items, nil
 }
 
-const searchShows = `-- name: SearchShows :many
-SELECT shows.id, shows.created_at, shows.updated_at, title, season, genre, actors, writer, director, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM shows
+const getShowsForUser = `-- name: GetShowsForUser :many
+SELECT shows.id, shows.created_at, shows.updated_at, shows.title, shows.season, shows.genre, shows.actors, shows.writer, shows.director, shows.release_date, shows.barcode, shows.shelf_id, shows.format, shows.release_date_precision, shows.bundle_id, shows.runtime_minutes, shows.content_rating, shows.disc_region, shows.audio_languages, shows.subtitle_languages, shows.search, shows.custom_fields, shows.position, shows.thickness_cm, shows.missing_since FROM shows
 INNER JOIN shelves
 ON shows.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY shows.title
 `
 
-type SearchShowsParams struct {
-	WebsearchToTsquery string
-	ID                 uuid.UUID
-}
-
-type SearchShowsRow struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Season      string
-	Genre       string
-	Actors      string
-	Writer      string
-	Director    string
-	ReleaseDate time.Time
-	Barcode     string
-	Format      string
-	ShelfID     uuid.UUID
-	Rank        float64
-}
-
-func (q *Queries) SearchShows(ctx context.Context, arg SearchShowsParams) ([]SearchShowsRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchShows, arg.WebsearchToTsquery, arg.ID)
+func (q *Queries) GetShowsForUser(ctx context.Context, userID uuid.UUID) ([]Show, error) {
+	rows, err := q.db.QueryContext(ctx, getShowsForUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchShowsRow
+	var items []Show
 	for rows.Next() {
-		var i SearchShowsRow
+		var i Show
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
@@ -380,9 +531,20 @@ func (q *Queries) SearchShows(ctx context.Context, arg SearchShowsParams) ([]Sea
 			&i.Director,
 			&i.ReleaseDate,
 			&i.Barcode,
-			&i.Format,
 			&i.ShelfID,
-			&i.Rank,
+			&i.Format,
+			&i.ReleaseDatePrecision,
+			&i.BundleID,
+			&i.RuntimeMinutes,
+			&i.ContentRating,
+			&i.DiscRegion,
+			&i.AudioLanguages,
+			&i.SubtitleLanguages,
+			&i.Search,
+			&i.CustomFields,
+			&i.Position,
+			&i.ThicknessCm,
+			&i.MissingSince,
 		); err != nil {
 			return nil, err
 		}
@@ -396,3 +558,170 @@ func (q *Queries) SearchShows(ctx context.Context, arg SearchShowsParams) ([]Sea
 	}
 	return items, nil
 }
+
+const lockShow = `-- name: LockShow :one
+SELECT updated_at FROM shows WHERE id = $1 FOR UPDATE
+`
+
+// LockShow locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockShow(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockShow, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
+const moveShow = `-- name: MoveShow :exec
+UPDATE shows
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2
+`
+
+type MoveShowParams struct {
+	ID      uuid.UUID
+	ShelfID uuid.UUID
+}
+
+func (q *Queries) MoveShow(ctx context.Context, arg MoveShowParams) error {
+	_, err := q.db.ExecContext(ctx, moveShow, arg.ID, arg.ShelfID)
+	return err
+}
+
+const removeShowFromBundle = `-- name: RemoveShowFromBundle :execrows
+UPDATE shows
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2
+`
+
+type RemoveShowFromBundleParams struct {
+	ID       uuid.UUID
+	BundleID uuid.NullUUID
+}
+
+func (q *Queries) RemoveShowFromBundle(ctx context.Context, arg RemoveShowFromBundleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeShowFromBundle, arg.ID, arg.BundleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setShowMissingSince = `-- name: SetShowMissingSince :exec
+UPDATE shows
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1
+`
+
+type SetShowMissingSinceParams struct {
+	ID           uuid.UUID
+	MissingSince sql.NullTime
+}
+
+func (q *Queries) SetShowMissingSince(ctx context.Context, arg SetShowMissingSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setShowMissingSince, arg.ID, arg.MissingSince)
+	return err
+}
+
+const setShowPosition = `-- name: SetShowPosition :exec
+UPDATE shows
+SET updated_at = NOW(), position = $2
+WHERE id = $1
+`
+
+type SetShowPositionParams struct {
+	ID       uuid.UUID
+	Position int32
+}
+
+func (q *Queries) SetShowPosition(ctx context.Context, arg SetShowPositionParams) error {
+	_, err := q.db.ExecContext(ctx, setShowPosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateShow = `-- name: UpdateShow :one
+UPDATE shows
+SET updated_at = NOW(), title = $2, season = $3, genre = $4, actors = $5, writer = $6, director = $7,
+    release_date = $8, release_date_precision = $9, barcode = $10, format = $11, shelf_id = $12,
+    runtime_minutes = $13, content_rating = $14, disc_region = $15, audio_languages = $16,
+    subtitle_languages = $17, custom_fields = $18, thickness_cm = $19,
+    position = CASE WHEN shelf_id = $12 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $12) END
+WHERE id = $1
+RETURNING id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, shelf_id, format, release_date_precision, bundle_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, search, custom_fields, position, thickness_cm, missing_since
+`
+
+type UpdateShowParams struct {
+	ID                   uuid.UUID
+	Title                string
+	Season               string
+	Genre                string
+	Actors               string
+	Writer               string
+	Director             string
+	ReleaseDate          sql.NullTime
+	ReleaseDatePrecision string
+	Barcode              string
+	Format               string
+	ShelfID              uuid.UUID
+	RuntimeMinutes       int32
+	ContentRating        string
+	DiscRegion           string
+	AudioLanguages       string
+	SubtitleLanguages    string
+	CustomFields         json.RawMessage
+	ThicknessCm          float64
+}
+
+func (q *Queries) UpdateShow(ctx context.Context, arg UpdateShowParams) (Show, error) {
+	row := q.db.QueryRowContext(ctx, updateShow,
+		arg.ID,
+		arg.Title,
+		arg.Season,
+		arg.Genre,
+		arg.Actors,
+		arg.Writer,
+		arg.Director,
+		arg.ReleaseDate,
+		arg.ReleaseDatePrecision,
+		arg.Barcode,
+		arg.Format,
+		arg.ShelfID,
+		arg.RuntimeMinutes,
+		arg.ContentRating,
+		arg.DiscRegion,
+		arg.AudioLanguages,
+		arg.SubtitleLanguages,
+		arg.CustomFields,
+		arg.ThicknessCm,
+	)
+	var i Show
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Season,
+		&i.Genre,
+		&i.Actors,
+		&i.Writer,
+		&i.Director,
+		&i.ReleaseDate,
+		&i.Barcode,
+		&i.ShelfID,
+		&i.Format,
+		&i.ReleaseDatePrecision,
+		&i.BundleID,
+		&i.RuntimeMinutes,
+		&i.ContentRating,
+		&i.DiscRegion,
+		&i.AudioLanguages,
+		&i.SubtitleLanguages,
+		&i.Search,
+		&i.CustomFields,
+		&i.Position,
+		&i.ThicknessCm,
+		&i.MissingSince,
+	)
+	return i, err
+}
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -109,6 +110,18 @@ func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
+const lockUser = `-- name: LockUser :one
+SELECT updated_at FROM users WHERE id = $1 FOR UPDATE
+`
+
+// LockUser locks the row until the transaction ends and returns when it was last updated.
+func (q *Queries) LockUser(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, lockUser, id)
+	var updatedAt time.Time
+	err := row.Scan(&updatedAt)
+	return updatedAt, err
+}
+
 const resetUsers = `-- name: ResetUsers :exec
 DELETE FROM users
 `
@@ -0,0 +1,66 @@
+// Package etag derives entity tags from when an entity was last updated, and checks them
+// against the If-Match and If-None-Match headers of conditional requests.
+package etag
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromTime returns the strong ETag of an entity that was last updated at the time. Timestamps
+// are stored to the microsecond, so the tag changes with every update.
+func FromTime(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// FromTimes returns the strong ETag of an entity made up of others, such as a bundle and the
+// items in it, from when each was last updated. The tag changes when any of them is updated,
+// added or removed.
+func FromTimes(updatedAt ...time.Time) string {
+	h := fnv.New64a()
+	var b [8]byte
+	for _, t := range updatedAt {
+		binary.BigEndian.PutUint64(b[:], uint64(t.UnixMicro()))
+		h.Write(b[:])
+	}
+	return `"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+// Match reports whether an If-Match header names the ETag. It uses the strong comparison, so
+// weak tags never match. "*" matches any ETag.
+func Match(header, etag string) bool {
+	for _, tag := range split(header) {
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// NoneMatch reports whether an If-None-Match header names the ETag, meaning the client's copy
+// is current. It uses the weak comparison, so a weak tag matches a strong one with the same
+// value. "*" matches any ETag.
+func NoneMatch(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range split(header) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// split returns the tags in a comma-separated list.
+func split(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,174 @@
+package etag
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromTime(t *testing.T) {
+	updatedAt := time.Date(2025, 3, 2, 10, 21, 44, 102934000, time.UTC)
+
+	tag := FromTime(updatedAt)
+	if tag[0] != '"' || tag[len(tag)-1] != '"' {
+		t.Fatalf("FromTime() = %s, want a quoted tag", tag)
+	}
+	if got := FromTime(updatedAt.In(time.FixedZone("EST", -5*60*60))); got != tag {
+		t.Errorf("FromTime() in another zone = %s, want %s", got, tag)
+	}
+	if got := FromTime(updatedAt.Add(time.Microsecond)); got == tag {
+		t.Errorf("FromTime() a microsecond later = %s, want a different tag", got)
+	}
+}
+
+func TestFromTimes(t *testing.T) {
+	first := time.Date(2025, 3, 2, 10, 21, 44, 102934000, time.UTC)
+	second := first.Add(time.Hour)
+	tag := FromTimes(first, second)
+
+	tests := []struct {
+		name      string
+		updatedAt []time.Time
+	}{
+		{
+			name:      "One updated",
+			updatedAt: []time.Time{first, second.Add(time.Microsecond)},
+		},
+		{
+			name:      "One added",
+			updatedAt: []time.Time{first, second, first},
+		},
+		{
+			name:      "One removed",
+			updatedAt: []time.Time{first},
+		},
+		{
+			name:      "None",
+			updatedAt: nil,
+		},
+	}
+
+	if got := FromTimes(first.In(time.FixedZone("EST", -5*60*60)), second); got != tag {
+		t.Errorf("FromTimes() in another zone = %s, want %s", got, tag)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromTimes(tt.updatedAt...); got == tag {
+				t.Errorf("FromTimes() = %s, want a different tag than %s", got, tag)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{
+			name:   "Same tag",
+			header: `"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Different tag",
+			header: `"abd"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+		{
+			name:   "One of a list",
+			header: `"xyz", "abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Any tag",
+			header: `*`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Weak tag never matches",
+			header: `W/"abc"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+		{
+			name:   "Unquoted tag",
+			header: `abc`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+		{
+			name:   "Empty header",
+			header: ``,
+			etag:   `"abc"`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.header, tt.etag); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.header, tt.etag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{
+			name:   "Same tag",
+			header: `"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Different tag",
+			header: `"abd"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+		{
+			name:   "One of a list",
+			header: `"xyz",W/"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Weak tag matches",
+			header: `W/"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Any tag",
+			header: `*`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		{
+			name:   "Empty header",
+			header: ``,
+			etag:   `"abc"`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NoneMatch(tt.header, tt.etag); got != tt.want {
+				t.Errorf("NoneMatch(%q, %q) = %v, want %v", tt.header, tt.etag, got, tt.want)
+			}
+		})
+	}
+}
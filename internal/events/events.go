@@ -0,0 +1,101 @@
+// Package events fans out a location's events to the clients watching it, such as the
+// Server-Sent Events streams of the web app.
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// bufferSize is how many messages a subscriber can fall behind before messages are dropped.
+const bufferSize = 32
+
+// Message is one event, as it's sent to subscribers. Data is the event's JSON.
+type Message struct {
+	ID   string
+	Type string
+	Data []byte
+}
+
+// Broker passes the messages published to a topic, such as a location's ID, to each of the
+// topic's subscribers. Publishing never blocks: a subscriber that falls too far behind misses
+// messages rather than holding up the others.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan Message]struct{}
+}
+
+// NewBroker returns a broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subscribers: map[uuid.UUID]map[chan Message]struct{}{}}
+}
+
+// Subscribe returns a channel of the messages published to the topic from now on. The
+// returned function unsubscribes and closes the channel; it must be called once the
+// subscriber is done.
+func (b *Broker) Subscribe(topic uuid.UUID) (<-chan Message, func()) {
+	ch := make(chan Message, bufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = map[chan Message]struct{}{}
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[topic], ch)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends a message to the topic's subscribers.
+func (b *Broker) Publish(topic uuid.UUID, msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// Subscribers returns how many subscribers the topic has.
+func (b *Broker) Subscribers(topic uuid.UUID) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers[topic])
+}
+
+// WriteSSE writes a message in the Server-Sent Events format. Data spanning several lines is
+// sent as several data fields, which clients join back together.
+func WriteSSE(w io.Writer, msg Message) error {
+	var buf bytes.Buffer
+	if msg.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", msg.ID)
+	}
+	if msg.Type != "" {
+		fmt.Fprintf(&buf, "event: %s\n", msg.Type)
+	}
+	for _, line := range bytes.Split(msg.Data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", bytes.TrimSuffix(line, []byte("\r")))
+	}
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
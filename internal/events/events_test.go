@@ -0,0 +1,108 @@
+package events
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker()
+	topic := uuid.New()
+	other := uuid.New()
+
+	first, unsubscribeFirst := broker.Subscribe(topic)
+	second, unsubscribeSecond := broker.Subscribe(topic)
+	elsewhere, unsubscribeElsewhere := broker.Subscribe(other)
+	defer unsubscribeSecond()
+	defer unsubscribeElsewhere()
+
+	if got := broker.Subscribers(topic); got != 2 {
+		t.Fatalf("Subscribers() = %d, want 2", got)
+	}
+
+	msg := Message{ID: "1", Type: "item.created", Data: []byte(`{}`)}
+	broker.Publish(topic, msg)
+
+	for name, ch := range map[string]<-chan Message{"first": first, "second": second} {
+		select {
+		case got := <-ch:
+			if got.ID != msg.ID || got.Type != msg.Type {
+				t.Errorf("%s subscriber got %+v, want %+v", name, got, msg)
+			}
+		default:
+			t.Errorf("%s subscriber got nothing", name)
+		}
+	}
+
+	select {
+	case got := <-elsewhere:
+		t.Errorf("subscriber to another topic got %+v", got)
+	default:
+	}
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	if _, ok := <-first; ok {
+		t.Errorf("channel is still open after unsubscribing")
+	}
+	if got := broker.Subscribers(topic); got != 1 {
+		t.Errorf("Subscribers() = %d after unsubscribing, want 1", got)
+	}
+
+	// Publishing after unsubscribing mustn't send on the closed channel.
+	broker.Publish(topic, msg)
+}
+
+func TestBrokerSlowSubscriber(t *testing.T) {
+	broker := NewBroker()
+	topic := uuid.New()
+
+	ch, unsubscribe := broker.Subscribe(topic)
+	defer unsubscribe()
+
+	for i := 0; i < bufferSize+10; i++ {
+		broker.Publish(topic, Message{Type: "item.updated"})
+	}
+
+	if got := len(ch); got != bufferSize {
+		t.Errorf("subscriber has %d messages waiting, want %d", got, bufferSize)
+	}
+}
+
+func TestWriteSSE(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{
+			name: "Event",
+			msg:  Message{ID: "abc", Type: "item.created", Data: []byte(`{"id":"1"}`)},
+			want: "id: abc\nevent: item.created\ndata: {\"id\":\"1\"}\n\n",
+		},
+		{
+			name: "No ID or type",
+			msg:  Message{Data: []byte("hello")},
+			want: "data: hello\n\n",
+		},
+		{
+			name: "Multiple lines",
+			msg:  Message{Type: "activity", Data: []byte("<li>\r\n  Moved\n</li>")},
+			want: "event: activity\ndata: <li>\ndata:   Moved\ndata: </li>\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSSE(&buf, tt.msg); err != nil {
+				t.Fatalf("WriteSSE() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteSSE() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Package labels renders printable labels for cases and shelves. Each label has the name of
+// the case or shelf, where it is, its barcode, and a QR code linking to it in the web app.
+package labels
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf/internal/qrcode"
+	"github.com/google/uuid"
+)
+
+type Label struct {
+	Title    string
+	Subtitle string
+	Barcode  string
+	// Link is encoded in the QR code.
+	Link string
+}
+
+// A Layout is a sheet of labels. Every length is in Unit, which is "in" or "mm". Top and Left
+// are the position of the first label, and the pitches are the distance from the start of one
+// label to the start of the next.
+type Layout struct {
+	Name        string
+	Description string
+	Unit        string
+	PageWidth   float64
+	PageHeight  float64
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	Top         float64
+	Left        float64
+	ColumnPitch float64
+	RowPitch    float64
+}
+
+func (l Layout) LabelsPerPage() int {
+	return l.Columns * l.Rows
+}
+
+const DefaultLayout = "avery5160"
+
+var Layouts = []Layout{
+	{
+		Name:        "avery5160",
+		Description: "Avery 5160, US Letter, 30 labels of 2 5/8 x 1 in",
+		Unit:        "in",
+		PageWidth:   8.5,
+		PageHeight:  11,
+		Columns:     3,
+		Rows:        10,
+		LabelWidth:  2.625,
+		LabelHeight: 1,
+		Top:         0.5,
+		Left:        0.1875,
+		ColumnPitch: 2.75,
+		RowPitch:    1,
+	},
+	{
+		Name:        "avery5163",
+		Description: "Avery 5163, US Letter, 10 labels of 4 x 2 in",
+		Unit:        "in",
+		PageWidth:   8.5,
+		PageHeight:  11,
+		Columns:     2,
+		Rows:        5,
+		LabelWidth:  4,
+		LabelHeight: 2,
+		Top:         0.5,
+		Left:        0.15625,
+		ColumnPitch: 4.1875,
+		RowPitch:    2,
+	},
+	{
+		Name:        "l7160",
+		Description: "Avery L7160, A4, 21 labels of 63.5 x 38.1 mm",
+		Unit:        "mm",
+		PageWidth:   210,
+		PageHeight:  297,
+		Columns:     3,
+		Rows:        7,
+		LabelWidth:  63.5,
+		LabelHeight: 38.1,
+		Top:         15.15,
+		Left:        7.25,
+		ColumnPitch: 66.04,
+		RowPitch:    38.1,
+	},
+	{
+		Name:        "l7163",
+		Description: "Avery L7163, A4, 14 labels of 99.1 x 38.1 mm",
+		Unit:        "mm",
+		PageWidth:   210,
+		PageHeight:  297,
+		Columns:     2,
+		Rows:        7,
+		LabelWidth:  99.1,
+		LabelHeight: 38.1,
+		Top:         15.15,
+		Left:        4.65,
+		ColumnPitch: 101.6,
+		RowPitch:    38.1,
+	},
+}
+
+func LookupLayout(name string) (Layout, bool) {
+	for _, layout := range Layouts {
+		if layout.Name == name {
+			return layout, true
+		}
+	}
+	return Layout{}, false
+}
+
+// The label is drawn in units of a hundredth of its height.
+const (
+	labelHeight = 100.0
+	padding     = 6.0
+	quietZone   = 4 // Modules of space around the QR code.
+	// Text narrower than this doesn't fit next to the QR code, so square labels only have the code.
+	minTextWidth = 40.0
+)
+
+// SVG draws a label of the given size, in "in" or "mm".
+func SVG(label Label, width, height float64, unit string) (string, error) {
+	code, err := qrcode.Encode(label.Link)
+	if err != nil {
+		return "", err
+	}
+
+	viewWidth := labelHeight * width / height
+	qrSide := labelHeight - 2*padding
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%s%s" height="%s%s" viewBox="0 0 %s %s">`,
+		formatFloat(width), unit, formatFloat(height), unit, formatFloat(viewWidth), formatFloat(labelHeight))
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="#fff"/>`)
+
+	moduleSize := qrSide / float64(code.Size+2*quietZone)
+	fmt.Fprintf(&sb, `<path fill="#000" shape-rendering="crispEdges" transform="translate(%s %s) scale(%s)" d="%s"/>`,
+		formatFloat(padding), formatFloat(padding), formatFloat(moduleSize), qrPath(code))
+
+	textX := padding + qrSide + padding
+	textWidth := viewWidth - textX - padding
+	if textWidth >= minTextWidth {
+		writeText(&sb, label.Title, textX, 32, 20, textWidth, `font-family="sans-serif" font-weight="bold"`)
+		writeText(&sb, label.Subtitle, textX, 54, 13, textWidth, `font-family="sans-serif"`)
+		writeText(&sb, label.Barcode, textX, 84, 13, textWidth, `font-family="monospace"`)
+	}
+
+	sb.WriteString(`</svg>`)
+	return sb.String(), nil
+}
+
+// qrPath draws the dark modules as one path, merging runs of dark modules in each row.
+func qrPath(code *qrcode.Code) string {
+	var sb strings.Builder
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; {
+			if !code.Dark(x, y) {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < code.Size && code.Dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&sb, "M%d %dh%dv1h-%dz", x+quietZone, y+quietZone, run, run)
+			x += run
+		}
+	}
+	return sb.String()
+}
+
+// writeText writes a line of text, squeezing it into the width if it's likely to be too long.
+// Glyphs are assumed to be about 0.6 of the font size wide, since the text can't be measured.
+func writeText(sb *strings.Builder, text string, x, y, fontSize, width float64, attributes string) {
+	if text == "" {
+		return
+	}
+
+	fit := ""
+	if float64(len([]rune(text)))*fontSize*0.6 > width {
+		fit = fmt.Sprintf(` textLength="%s" lengthAdjust="spacingAndGlyphs"`, formatFloat(width))
+	}
+	fmt.Fprintf(sb, `<text x="%s" y="%s" font-size="%s" %s%s>%s</text>`,
+		formatFloat(x), formatFloat(y), formatFloat(fontSize), attributes, fit, html.EscapeString(text))
+}
+
+// Sheet lays the labels out on pages for printing, as an HTML document. The first skip
+// positions are left empty, so that a partly used sheet can be printed on.
+func Sheet(layout Layout, labels []Label, skip int) (string, error) {
+	unit := func(v float64) string {
+		return formatFloat(v) + layout.Unit
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(layout.Description))
+	sb.WriteString("<style>\n")
+	fmt.Fprintf(&sb, "@page { size: %s %s; margin: 0; }\n", unit(layout.PageWidth), unit(layout.PageHeight))
+	sb.WriteString("body { margin: 0; }\n")
+	fmt.Fprintf(&sb, ".page { position: relative; width: %s; height: %s; overflow: hidden; break-after: page; }\n", unit(layout.PageWidth), unit(layout.PageHeight))
+	fmt.Fprintf(&sb, ".label { position: absolute; width: %s; height: %s; }\n", unit(layout.LabelWidth), unit(layout.LabelHeight))
+	sb.WriteString(".label svg { display: block; width: 100%; height: 100%; }\n")
+	sb.WriteString("</style>\n</head>\n<body>\n")
+
+	perPage := layout.LabelsPerPage()
+	skip = max(skip%perPage, 0)
+	for i, label := range labels {
+		position := i + skip
+		if position%perPage == 0 || i == 0 {
+			if i > 0 {
+				sb.WriteString("</div>\n")
+			}
+			sb.WriteString("<div class=\"page\">\n")
+		}
+
+		svg, err := SVG(label, layout.LabelWidth, layout.LabelHeight, layout.Unit)
+		if err != nil {
+			return "", fmt.Errorf("label %q: %w", label.Title, err)
+		}
+
+		onPage := position % perPage
+		column, row := onPage%layout.Columns, onPage/layout.Columns
+		fmt.Fprintf(&sb, "<div class=\"label\" style=\"left: %s; top: %s\">%s</div>\n",
+			unit(layout.Left+float64(column)*layout.ColumnPitch), unit(layout.Top+float64(row)*layout.RowPitch), svg)
+	}
+	if len(labels) > 0 {
+		sb.WriteString("</div>\n")
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String(), nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ShelfLink and CaseLink are the web app pages for a shelf and a case. appURL is the address
+// of the web app, without a trailing slash.
+func ShelfLink(appURL string, shelfID uuid.UUID) string {
+	return appURL + "/shelves/" + shelfID.String()
+}
+
+func CaseLink(appURL string, caseID uuid.UUID) string {
+	return appURL + "/cases/" + caseID.String()
+}
+
+// ParseLink reads a link made by ShelfLink or CaseLink, such as the contents of a scanned
+// label's QR code. kind is "shelf" or "case". The host isn't checked, so links keep working
+// if the web app moves.
+func ParseLink(link string) (kind string, id uuid.UUID, ok bool) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme == "" {
+		return "", uuid.Nil, false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 {
+		return "", uuid.Nil, false
+	}
+
+	id, err = uuid.Parse(parts[len(parts)-1])
+	if err != nil {
+		return "", uuid.Nil, false
+	}
+
+	switch parts[len(parts)-2] {
+	case "shelves":
+		return "shelf", id, true
+	case "cases":
+		return "case", id, true
+	}
+	return "", uuid.Nil, false
+}
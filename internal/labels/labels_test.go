@@ -0,0 +1,203 @@
+package labels
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLayoutsFitOnPage(t *testing.T) {
+	for _, layout := range Layouts {
+		t.Run(layout.Name, func(t *testing.T) {
+			right := layout.Left + float64(layout.Columns-1)*layout.ColumnPitch + layout.LabelWidth
+			bottom := layout.Top + float64(layout.Rows-1)*layout.RowPitch + layout.LabelHeight
+			if right > layout.PageWidth {
+				t.Errorf("labels end at %v, past the page width of %v", right, layout.PageWidth)
+			}
+			if bottom > layout.PageHeight {
+				t.Errorf("labels end at %v, past the page height of %v", bottom, layout.PageHeight)
+			}
+			if layout.ColumnPitch < layout.LabelWidth || layout.RowPitch < layout.LabelHeight {
+				t.Errorf("labels overlap")
+			}
+		})
+	}
+
+	if _, ok := LookupLayout(DefaultLayout); !ok {
+		t.Errorf("default layout %q doesn't exist", DefaultLayout)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	label := Label{
+		Title:    "Top <Shelf>",
+		Subtitle: "Home · Living Room",
+		Barcode:  "DSS-8E21D4A90C",
+		Link:     "https://shelf.example.com/shelves/d41dc884-7f5b-4c3e-b05c-b7f5cbad8d22",
+	}
+
+	tests := []struct {
+		name       string
+		width      float64
+		height     float64
+		unit       string
+		wantText   bool
+		wantPrefix string
+	}{
+		{
+			name:       "Address label",
+			width:      2.625,
+			height:     1,
+			unit:       "in",
+			wantText:   true,
+			wantPrefix: `<svg xmlns="http://www.w3.org/2000/svg" width="2.625in" height="1in" viewBox="0 0 262.5 100">`,
+		},
+		{
+			name:       "Square label",
+			width:      25,
+			height:     25,
+			unit:       "mm",
+			wantText:   false,
+			wantPrefix: `<svg xmlns="http://www.w3.org/2000/svg" width="25mm" height="25mm" viewBox="0 0 100 100">`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svg, err := SVG(label, tc.width, tc.height, tc.unit)
+			if err != nil {
+				t.Fatalf("SVG: %v", err)
+			}
+			if !strings.HasPrefix(svg, tc.wantPrefix) {
+				t.Errorf("SVG starts %q, want %q", svg[:min(len(svg), len(tc.wantPrefix))], tc.wantPrefix)
+			}
+			if !strings.Contains(svg, "<path ") {
+				t.Errorf("SVG has no QR code")
+			}
+			if got := strings.Contains(svg, "Top &lt;Shelf&gt;"); got != tc.wantText {
+				t.Errorf("SVG has escaped title = %v, want %v", got, tc.wantText)
+			}
+			if strings.Contains(svg, "<Shelf>") {
+				t.Errorf("SVG has unescaped title")
+			}
+		})
+	}
+}
+
+func TestSheet(t *testing.T) {
+	layout, _ := LookupLayout("avery5163")
+	label := Label{Title: "Shelf", Link: "https://shelf.example.com/shelves/d41dc884-7f5b-4c3e-b05c-b7f5cbad8d22"}
+
+	tests := []struct {
+		name      string
+		labels    int
+		skip      int
+		wantPages int
+		wantFirst string
+	}{
+		{
+			name:      "No labels",
+			labels:    0,
+			wantPages: 0,
+		},
+		{
+			name:      "One page",
+			labels:    10,
+			wantPages: 1,
+			wantFirst: `style="left: 0.15625in; top: 0.5in"`,
+		},
+		{
+			name:      "Two pages",
+			labels:    11,
+			wantPages: 2,
+		},
+		{
+			name:      "Skip used labels",
+			labels:    2,
+			skip:      3,
+			wantPages: 1,
+			wantFirst: `style="left: 4.34375in; top: 2.5in"`,
+		},
+		{
+			name:      "Skip onto a second page",
+			labels:    8,
+			skip:      3,
+			wantPages: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			labels := make([]Label, tc.labels)
+			for i := range labels {
+				labels[i] = label
+			}
+
+			sheet, err := Sheet(layout, labels, tc.skip)
+			if err != nil {
+				t.Fatalf("Sheet: %v", err)
+			}
+			if got := strings.Count(sheet, `<div class="page">`); got != tc.wantPages {
+				t.Errorf("pages = %d, want %d", got, tc.wantPages)
+			}
+			if got := strings.Count(sheet, `<div class="label"`); got != tc.labels {
+				t.Errorf("labels = %d, want %d", got, tc.labels)
+			}
+			if tc.wantFirst != "" && !strings.Contains(sheet, `<div class="label" `+tc.wantFirst) {
+				t.Errorf("first label isn't at %s", tc.wantFirst)
+			}
+		})
+	}
+}
+
+func TestParseLink(t *testing.T) {
+	id := uuid.MustParse("d41dc884-7f5b-4c3e-b05c-b7f5cbad8d22")
+
+	tests := []struct {
+		name     string
+		link     string
+		wantKind string
+		wantOK   bool
+	}{
+		{
+			name:     "Shelf link",
+			link:     ShelfLink("https://shelf.example.com", id),
+			wantKind: "shelf",
+			wantOK:   true,
+		},
+		{
+			name:     "Case link on another host",
+			link:     CaseLink("http://localhost:8080/digitalshelf", id),
+			wantKind: "case",
+			wantOK:   true,
+		},
+		{
+			name: "Barcode",
+			link: "DSS-8E21D4A90C",
+		},
+		{
+			name: "Other page",
+			link: "https://shelf.example.com/movies/" + id.String(),
+		},
+		{
+			name: "Not an ID",
+			link: "https://shelf.example.com/shelves/top",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, got, ok := ParseLink(tc.link)
+			if ok != tc.wantOK {
+				t.Fatalf("ParseLink(%q) ok = %v, want %v", tc.link, ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if kind != tc.wantKind || got != id {
+				t.Errorf("ParseLink(%q) = %s %s, want %s %s", tc.link, kind, got, tc.wantKind, id)
+			}
+		})
+	}
+}
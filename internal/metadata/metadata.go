@@ -0,0 +1,169 @@
+// Package metadata looks up the details of an item from its barcode, so that new items
+// don't have to be typed in by hand.
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var ErrNotFound = errors.New("no metadata found for barcode")
+
+// Result is what's known about a barcode. ItemType is the singular name of a media type,
+// e.g. "book". ReleaseDate is formatted as YYYY, YYYY-MM or YYYY-MM-DD, or empty if unknown.
+// Source names where the details came from.
+type Result struct {
+	ItemType    string
+	Title       string
+	Creator     string
+	Genre       string
+	ReleaseDate string
+	Source      string
+}
+
+type Provider interface {
+	// Lookup returns ErrNotFound if the provider knows nothing about the barcode.
+	Lookup(ctx context.Context, barcode string) (Result, error)
+}
+
+// Chain tries each provider in turn and returns the first result found.
+type Chain []Provider
+
+func (c Chain) Lookup(ctx context.Context, barcode string) (Result, error) {
+	for _, p := range c {
+		result, err := p.Lookup(ctx, barcode)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return result, err
+	}
+	return Result{}, ErrNotFound
+}
+
+const OpenLibraryURL = "https://openlibrary.org"
+
+// OpenLibrary looks up books by ISBN. Barcodes that aren't ISBNs are never found.
+type OpenLibrary struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func (o OpenLibrary) Lookup(ctx context.Context, barcode string) (Result, error) {
+	isbn, ok := NormalizeISBN(barcode)
+	if !ok {
+		return Result{}, ErrNotFound
+	}
+
+	baseURL := o.BaseURL
+	if baseURL == "" {
+		baseURL = OpenLibraryURL
+	}
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	query := url.Values{}
+	query.Set("bibkeys", "ISBN:"+isbn)
+	query.Set("format", "json")
+	query.Set("jscmd", "data")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("open library lookup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("open library lookup: unexpected status %s", resp.Status)
+	}
+
+	var books map[string]struct {
+		Title   string `json:"title"`
+		Authors []struct {
+			Name string `json:"name"`
+		} `json:"authors"`
+		PublishDate string `json:"publish_date"`
+		Subjects    []struct {
+			Name string `json:"name"`
+		} `json:"subjects"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&books)
+	if err != nil {
+		return Result{}, fmt.Errorf("open library lookup: %w", err)
+	}
+
+	book, ok := books["ISBN:"+isbn]
+	if !ok || book.Title == "" {
+		return Result{}, ErrNotFound
+	}
+
+	result := Result{
+		ItemType:    "book",
+		Title:       book.Title,
+		ReleaseDate: yearPattern.FindString(book.PublishDate),
+		Source:      "openlibrary",
+	}
+	if len(book.Authors) > 0 {
+		result.Creator = book.Authors[0].Name
+	}
+	if len(book.Subjects) > 0 {
+		result.Genre = book.Subjects[0].Name
+	}
+	return result, nil
+}
+
+// Open Library publish dates are free text, such as "September 21, 1937", so only the year is used.
+var yearPattern = regexp.MustCompile(`\b(1[0-9]|20)[0-9]{2}\b`)
+
+// NormalizeISBN strips the hyphens and spaces from an ISBN-10 or ISBN-13 and checks its
+// check digit. Barcodes that aren't ISBNs, such as UPCs, aren't valid.
+func NormalizeISBN(barcode string) (string, bool) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(barcode))
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, c := range isbn {
+			var digit int
+			switch {
+			case c >= '0' && c <= '9':
+				digit = int(c - '0')
+			case c == 'X' && i == 9:
+				digit = 10
+			default:
+				return "", false
+			}
+			sum += digit * (10 - i)
+		}
+		return isbn, sum%11 == 0
+	case 13:
+		if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+			return "", false
+		}
+		sum := 0
+		for i, c := range isbn {
+			if c < '0' || c > '9' {
+				return "", false
+			}
+			digit := int(c - '0')
+			if i%2 == 1 {
+				digit *= 3
+			}
+			sum += digit
+		}
+		return isbn, sum%10 == 0
+	}
+	return "", false
+}
@@ -0,0 +1,181 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		barcode string
+		want    string
+		wantOK  bool
+	}{
+		{
+			name:    "ISBN-13",
+			barcode: "9780547928227",
+			want:    "9780547928227",
+			wantOK:  true,
+		},
+		{
+			name:    "ISBN-13 with hyphens",
+			barcode: "978-0-547-92822-7",
+			want:    "9780547928227",
+			wantOK:  true,
+		},
+		{
+			name:    "ISBN-10 ending in X",
+			barcode: "0-8044-2957-x",
+			want:    "080442957X",
+			wantOK:  true,
+		},
+		{
+			name:    "Bad check digit",
+			barcode: "9780547928228",
+			wantOK:  false,
+		},
+		{
+			name:    "UPC",
+			barcode: "883929106465",
+			wantOK:  false,
+		},
+		{
+			name:    "EAN that isn't a book",
+			barcode: "5012345678900",
+			wantOK:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := NormalizeISBN(tc.barcode)
+			if ok != tc.wantOK {
+				t.Fatalf("NormalizeISBN(%q) ok = %v, want %v", tc.barcode, ok, tc.wantOK)
+			}
+			if ok && got != tc.want {
+				t.Errorf("NormalizeISBN(%q) = %q, want %q", tc.barcode, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpenLibraryLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/books" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("bibkeys") != "ISBN:9780547928227" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`{"ISBN:9780547928227": {
+			"title": "The Hobbit",
+			"authors": [{"name": "J.R.R. Tolkien"}],
+			"publish_date": "September 18, 2012",
+			"subjects": [{"name": "Fantasy"}, {"name": "Dragons"}]
+		}}`))
+	}))
+	defer server.Close()
+
+	provider := OpenLibrary{BaseURL: server.URL, Client: server.Client()}
+
+	tests := []struct {
+		name    string
+		barcode string
+		want    Result
+		wantErr error
+	}{
+		{
+			name:    "Found",
+			barcode: "978-0547928227",
+			want: Result{
+				ItemType:    "book",
+				Title:       "The Hobbit",
+				Creator:     "J.R.R. Tolkien",
+				Genre:       "Fantasy",
+				ReleaseDate: "2012",
+				Source:      "openlibrary",
+			},
+		},
+		{
+			name:    "Unknown ISBN",
+			barcode: "9780261103344",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "Not an ISBN",
+			barcode: "883929106465",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := provider.Lookup(context.Background(), tc.barcode)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Lookup(%q) error = %v, want %v", tc.barcode, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Lookup(%q) = %+v, want %+v", tc.barcode, got, tc.want)
+			}
+		})
+	}
+}
+
+type fakeProvider struct {
+	result Result
+	err    error
+}
+
+func (f fakeProvider) Lookup(ctx context.Context, barcode string) (Result, error) {
+	return f.result, f.err
+}
+
+func TestChain(t *testing.T) {
+	found := Result{Title: "Alien", Source: "second"}
+	failure := errors.New("lookup failed")
+
+	tests := []struct {
+		name    string
+		chain   Chain
+		want    Result
+		wantErr error
+	}{
+		{
+			name:  "Skips providers that don't find the barcode",
+			chain: Chain{fakeProvider{err: ErrNotFound}, fakeProvider{result: found}},
+			want:  found,
+		},
+		{
+			name:    "Stops at an error",
+			chain:   Chain{fakeProvider{err: failure}, fakeProvider{result: found}},
+			wantErr: failure,
+		},
+		{
+			name:    "Nothing found",
+			chain:   Chain{fakeProvider{err: ErrNotFound}},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "Empty chain",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.chain.Lookup(context.Background(), "024543617907")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Lookup error = %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Lookup = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package partialdate
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Precision string
+
+const (
+	PrecisionUnknown Precision = "unknown"
+	PrecisionYear    Precision = "year"
+	PrecisionMonth   Precision = "month"
+	PrecisionDay     Precision = "day"
+)
+
+var ErrInvalidDate = errors.New("date must be formatted as YYYY, YYYY-MM or YYYY-MM-DD")
+
+// Date is a calendar date that may only be known to the year or month.
+// The zero value is an unknown date, which is encoded as JSON null.
+type Date struct {
+	Time      time.Time
+	Precision Precision
+	Valid     bool
+}
+
+func New(t time.Time, precision Precision) Date {
+	y, m, d := t.Date()
+	switch precision {
+	case PrecisionYear:
+		m, d = time.January, 1
+	case PrecisionMonth:
+		d = 1
+	case PrecisionDay:
+	default:
+		return Date{}
+	}
+	return Date{
+		Time:      time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+		Precision: precision,
+		Valid:     true,
+	}
+}
+
+func Parse(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+
+	layouts := []struct {
+		layout    string
+		precision Precision
+	}{
+		{"2006", PrecisionYear},
+		{"2006-01", PrecisionMonth},
+		{"2006-01-02", PrecisionDay},
+		{time.RFC3339, PrecisionDay},
+	}
+	for _, l := range layouts {
+		t, err := time.Parse(l.layout, s)
+		if err == nil {
+			return New(t, l.precision), nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+}
+
+// FromNullTime builds a Date from a nullable database column and the
+// precision stored alongside it.
+func FromNullTime(t sql.NullTime, precision string) Date {
+	if !t.Valid {
+		return Date{}
+	}
+	return New(t.Time, Precision(precision))
+}
+
+func (d Date) NullTime() sql.NullTime {
+	if !d.Valid {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: d.Time, Valid: true}
+}
+
+func (d Date) PrecisionString() string {
+	if !d.Valid {
+		return string(PrecisionUnknown)
+	}
+	return string(d.Precision)
+}
+
+func (d Date) String() string {
+	if !d.Valid {
+		return ""
+	}
+	switch d.Precision {
+	case PrecisionYear:
+		return d.Time.Format("2006")
+	case PrecisionMonth:
+		return d.Time.Format("2006-01")
+	default:
+		return d.Time.Format("2006-01-02")
+	}
+}
+
+// Decade returns the first year of the decade the date falls in.
+func (d Date) Decade() (int, bool) {
+	if !d.Valid {
+		return 0, false
+	}
+	year := d.Time.Year()
+	return year - year%10, true
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalidDate
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Compare orders dates chronologically. Unknown dates sort last, and a less
+// precise date sorts before a more precise one starting on the same day.
+func Compare(a, b Date) int {
+	switch {
+	case !a.Valid && !b.Valid:
+		return 0
+	case !a.Valid:
+		return 1
+	case !b.Valid:
+		return -1
+	}
+
+	if c := a.Time.Compare(b.Time); c != 0 {
+		return c
+	}
+	return precisionRank(a.Precision) - precisionRank(b.Precision)
+}
+
+func precisionRank(p Precision) int {
+	switch p {
+	case PrecisionYear:
+		return 0
+	case PrecisionMonth:
+		return 1
+	default:
+		return 2
+	}
+}
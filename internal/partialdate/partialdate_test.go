@@ -0,0 +1,208 @@
+package partialdate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantString    string
+		wantPrecision Precision
+		wantValid     bool
+		wantErr       bool
+	}{
+		{
+			name:          "Year only",
+			input:         "1977",
+			wantString:    "1977",
+			wantPrecision: PrecisionYear,
+			wantValid:     true,
+		},
+		{
+			name:          "Year and month",
+			input:         "1977-05",
+			wantString:    "1977-05",
+			wantPrecision: PrecisionMonth,
+			wantValid:     true,
+		},
+		{
+			name:          "Full date",
+			input:         "1977-05-25",
+			wantString:    "1977-05-25",
+			wantPrecision: PrecisionDay,
+			wantValid:     true,
+		},
+		{
+			name:          "RFC3339 timestamp",
+			input:         "2024-03-01T00:00:00Z",
+			wantString:    "2024-03-01",
+			wantPrecision: PrecisionDay,
+			wantValid:     true,
+		},
+		{
+			name:      "Empty string is unknown",
+			input:     "",
+			wantValid: false,
+		},
+		{
+			name:    "Invalid month",
+			input:   "1977-13",
+			wantErr: true,
+		},
+		{
+			name:    "Not a date",
+			input:   "summer 1977",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Valid != tt.wantValid {
+				t.Errorf("Parse() valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if got.String() != tt.wantString {
+				t.Errorf("Parse() string = %v, want %v", got.String(), tt.wantString)
+			}
+			if tt.wantValid && got.Precision != tt.wantPrecision {
+				t.Errorf("Parse() precision = %v, want %v", got.Precision, tt.wantPrecision)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type item struct {
+		ReleaseDate Date `json:"release_date"`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "Year only",
+			input: `{"release_date":"1977"}`,
+			want:  `{"release_date":"1977"}`,
+		},
+		{
+			name:  "Year and month",
+			input: `{"release_date":"1977-05"}`,
+			want:  `{"release_date":"1977-05"}`,
+		},
+		{
+			name:  "Null",
+			input: `{"release_date":null}`,
+			want:  `{"release_date":null}`,
+		},
+		{
+			name:  "Missing",
+			input: `{}`,
+			want:  `{"release_date":null}`,
+		},
+		{
+			name:  "Legacy timestamp",
+			input: `{"release_date":"2024-03-01T00:00:00Z"}`,
+			want:  `{"release_date":"2024-03-01"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i item
+			if err := json.Unmarshal([]byte(tt.input), &i); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got, err := json.Marshal(i)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONRejectsNumbers(t *testing.T) {
+	var d Date
+	if err := json.Unmarshal([]byte(`1977`), &d); err == nil {
+		t.Errorf("UnmarshalJSON() expected error for bare number")
+	}
+}
+
+func TestFromNullTime(t *testing.T) {
+	d := New(time.Date(1977, time.May, 25, 0, 0, 0, 0, time.UTC), PrecisionMonth)
+	got := FromNullTime(d.NullTime(), d.PrecisionString())
+	if got != d {
+		t.Errorf("FromNullTime() = %v, want %v", got, d)
+	}
+
+	unknown := FromNullTime(Date{}.NullTime(), Date{}.PrecisionString())
+	if unknown.Valid {
+		t.Errorf("FromNullTime() expected unknown date, got %v", unknown)
+	}
+}
+
+func TestDecade(t *testing.T) {
+	d, _ := Parse("1979")
+	decade, ok := d.Decade()
+	if !ok || decade != 1970 {
+		t.Errorf("Decade() = %v, %v, want 1970, true", decade, ok)
+	}
+
+	if _, ok := (Date{}).Decade(); ok {
+		t.Errorf("Decade() expected unknown date to have no decade")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	year, _ := Parse("1977")
+	month, _ := Parse("1977-01")
+	day, _ := Parse("1977-01-01")
+	later, _ := Parse("1980")
+
+	tests := []struct {
+		name string
+		a    Date
+		b    Date
+		want int
+	}{
+		{"Earlier first", year, later, -1},
+		{"Later second", later, year, 1},
+		{"Year before month on same day", year, month, -1},
+		{"Month before day on same day", month, day, -1},
+		{"Unknown sorts last", Date{}, year, 1},
+		{"Known before unknown", year, Date{}, -1},
+		{"Both unknown", Date{}, Date{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.a, tt.b)
+			if sign(got) != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
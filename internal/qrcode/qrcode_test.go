@@ -0,0 +1,370 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatBits(t *testing.T) {
+	// From the format information table in the QR code specification.
+	tests := []struct {
+		mask int
+		want int
+	}{
+		{mask: 0, want: 0b101010000010010},
+		{mask: 1, want: 0b101000100100101},
+		{mask: 4, want: 0b100010111111001},
+		{mask: 6, want: 0b100111110010111},
+		{mask: 7, want: 0b100101010100000},
+	}
+
+	for _, tc := range tests {
+		if got := formatBits(tc.mask); got != tc.want {
+			t.Errorf("formatBits(%d) = %015b, want %015b", tc.mask, got, tc.want)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	// From the version information table in the QR code specification.
+	tests := []struct {
+		version int
+		want    int
+	}{
+		{version: 7, want: 0x07C94},
+		{version: 8, want: 0x085BC},
+		{version: 40, want: 0x28C69},
+	}
+
+	for _, tc := range tests {
+		if got := versionBits(tc.version); got != tc.want {
+			t.Errorf("versionBits(%d) = %#05x, want %#05x", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// "HELLO WORLD" at version 1-M, from the worked example in the Thonky QR code tutorial.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(10))
+	if !bytes.Equal(got, want) {
+		t.Errorf("reedSolomonRemainder = %v, want %v", got, want)
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	tests := []struct {
+		version int
+		want    []int
+	}{
+		{version: 1, want: nil},
+		{version: 2, want: []int{6, 18}},
+		{version: 7, want: []int{6, 22, 38}},
+		{version: 15, want: []int{6, 26, 48, 70}},
+		{version: 32, want: []int{6, 34, 60, 86, 112, 138}},
+		{version: 40, want: []int{6, 30, 58, 86, 114, 142, 170}},
+	}
+
+	for _, tc := range tests {
+		got := alignmentPatternPositions(tc.version)
+		if len(got) != len(tc.want) {
+			t.Fatalf("alignmentPatternPositions(%d) = %v, want %v", tc.version, got, tc.want)
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("alignmentPatternPositions(%d) = %v, want %v", tc.version, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		wantVersion int
+		wantErr     error
+	}{
+		{
+			name:        "Short text",
+			text:        "DSS-8E21D4A90C",
+			wantVersion: 1,
+		},
+		{
+			name:        "Shelf link",
+			text:        "https://shelf.example.com/shelves/d41dc884-7f5b-4c3e-b05c-b7f5cbad8d22",
+			wantVersion: 5,
+		},
+		{
+			name:        "Version information",
+			text:        strings.Repeat("a", 120),
+			wantVersion: 7,
+		},
+		{
+			name:        "Sixteen bit character count",
+			text:        strings.Repeat("b", 280),
+			wantVersion: 12,
+		},
+		{
+			name:        "Largest version",
+			text:        strings.Repeat("c", 2331),
+			wantVersion: 40,
+		},
+		{
+			name:    "Too long",
+			text:    strings.Repeat("d", 2332),
+			wantErr: ErrTooLong,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Encode(tc.text)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Encode error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if c.Version != tc.wantVersion {
+				t.Errorf("Version = %d, want %d", c.Version, tc.wantVersion)
+			}
+			if c.Size != c.Version*4+17 {
+				t.Errorf("Size = %d, want %d", c.Size, c.Version*4+17)
+			}
+
+			got, err := decode(c)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if got != tc.text {
+				t.Errorf("decoded %q, want %q", got, tc.text)
+			}
+		})
+	}
+}
+
+func TestEncodeReference(t *testing.T) {
+	// Module matrices from github.com/skip2/go-qrcode at level M, without the quiet zone. They
+	// match rsc.io/qr/coding given the same version and mask, so they don't depend on one
+	// encoder's mask choice being right.
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "Version 1",
+			text: "digitalshelf",
+			want: []string{
+				"#######.....#.#######",
+				"#.....#..#.##.#.....#",
+				"#.###.#.##..#.#.###.#",
+				"#.###.#.###...#.###.#",
+				"#.###.#.#.###.#.###.#",
+				"#.....#.#...#.#.....#",
+				"#######.#.#.#.#######",
+				"........#..##........",
+				"#.#####.....#.#####..",
+				".##.....##..#...#...#",
+				"#.##.###.###.##..###.",
+				"##.#...####..#.#.##..",
+				"#..##.##.#.#..#.#..#.",
+				"........#...#.#####.#",
+				"#######..##.#....#.#.",
+				"#.....#.#....#...##..",
+				"#.###.#.##.##.##...##",
+				"#.###.#.#....#.##....",
+				"#.###.#.#..###...##..",
+				"#.....#..##.##.#.##..",
+				"#######.####..#..#.#.",
+			},
+		},
+		{
+			name: "Version 3 with an alignment pattern",
+			text: "https://example.com/shelves/86a210c7",
+			want: []string{
+				"#######.###.##.#..###.#######",
+				"#.....#.#..###..###.#.#.....#",
+				"#.###.#...#....#..##..#.###.#",
+				"#.###.#.#..##.####.#..#.###.#",
+				"#.###.#...#...##...#..#.###.#",
+				"#.....#..#.#.#....###.#.....#",
+				"#######.#.#.#.#.#.#.#.#######",
+				"........#...#..#.#.##........",
+				"#.##.###.#.###..#####.#..#.##",
+				".#.##..#.##..##.#..##.###...#",
+				"#.#.###.###..#....#.......##.",
+				"..###...##..#...#...#.###...#",
+				".##..####.#...####.#.....##..",
+				".#.##...####..##.#.##.#...###",
+				"...#.##..#...#....####.#..###",
+				".##........##.##....##..#..#.",
+				"#..#.####.###.#.#.####.###.#.",
+				".##.##.#.#.#..###.#.#..#.###.",
+				"#...#######..###.##.#...#.#..",
+				".....#..##..####.###.#.##.#..",
+				".#.#..#.#...###..#..#######..",
+				"........#...#....##.#...#####",
+				"#######.#.#...#.#.###.#.##.#.",
+				"#.....#.##..###.#.#.#...##..#",
+				"#.###.#..##.#..#.##.#####.#..",
+				"#.###.#.##..#..##.##....##..#",
+				"#.###.#.#..##.#.#..#...#..#.#",
+				"#.....#..#....#.#.#.#.####.#.",
+				"#######.#..###..#..##.#....#.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Encode(tc.text)
+			if err != nil {
+				t.Fatalf("Encode error = %v", err)
+			}
+			if c.Size != len(tc.want) {
+				t.Fatalf("Size = %d, want %d", c.Size, len(tc.want))
+			}
+
+			for y, row := range tc.want {
+				var got strings.Builder
+				for x := 0; x < c.Size; x++ {
+					if c.Dark(x, y) {
+						got.WriteByte('#')
+					} else {
+						got.WriteByte('.')
+					}
+				}
+				if got.String() != row {
+					t.Errorf("row %d = %s, want %s", y, got.String(), row)
+				}
+			}
+		})
+	}
+}
+
+// decode reads a code back, checking its format information and error correction, so that
+// the encoder can be tested without an image decoder.
+func decode(c *Code) (string, error) {
+	// The format information next to the top left finder pattern, read independently of drawFormatBits.
+	var format int
+	positions := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}}
+	for i, p := range positions {
+		if c.Dark(p[0], p[1]) {
+			format |= 1 << i
+		}
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		return "", errors.New("format information isn't level M")
+	}
+
+	// Unmask the data modules by drawing the same mask over them again.
+	work := newCode(c.Version)
+	work.drawFunctionPatterns()
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			work.modules[y][x] = c.Dark(x, y)
+		}
+	}
+	work.applyMask(mask)
+
+	var raw []byte
+	var current byte
+	n := 0
+	for right := work.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < work.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = work.size - 1 - vert
+				}
+				if work.isFunction[y][x] {
+					continue
+				}
+				current <<= 1
+				if work.modules[y][x] {
+					current |= 1
+				}
+				n++
+				if n%8 == 0 {
+					raw = append(raw, current)
+					current = 0
+				}
+			}
+		}
+	}
+
+	// De-interleave the blocks, and check that every block is a multiple of the generator.
+	numBlocks := numErrorCorrectionBlocks[c.Version]
+	eccLen := eccCodewordsPerBlock[c.Version]
+	rawCodewords := numRawDataModules(c.Version) / 8
+	raw = raw[:rawCodewords]
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortBlockLen+1; i++ {
+		for j := range blocks {
+			if i == shortBlockLen-eccLen && j < numShortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+
+	var data []byte
+	divisor := reedSolomonDivisor(eccLen)
+	for _, block := range blocks {
+		dataLen := len(block) - eccLen
+		remainder := reedSolomonRemainder(block[:dataLen], divisor)
+		if !bytes.Equal(remainder, block[dataLen:]) {
+			return "", errors.New("error correction doesn't match")
+		}
+		data = append(data, block[:dataLen]...)
+	}
+
+	var bits bitBuffer
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	read := func(pos, length int) int {
+		v := 0
+		for i := pos; i < pos+length; i++ {
+			v <<= 1
+			if bits[i] {
+				v |= 1
+			}
+		}
+		return v
+	}
+
+	if read(0, 4) != 0x4 {
+		return "", errors.New("not byte mode")
+	}
+	count := read(4, countBits(c.Version))
+	start := 4 + countBits(c.Version)
+	text := make([]byte, count)
+	for i := range text {
+		text[i] = byte(read(start+i*8, 8))
+	}
+	return string(text), nil
+}
@@ -0,0 +1,86 @@
+package search
+
+import (
+	"cmp"
+	"html"
+	"slices"
+	"strings"
+)
+
+// The facets search results are counted and filtered by.
+const (
+	FacetType   = "type"
+	FacetGenre  = "genre"
+	FacetFormat = "format"
+	FacetDecade = "decade"
+	FacetShelf  = "shelf_id"
+)
+
+var FacetNames = []string{FacetType, FacetGenre, FacetFormat, FacetDecade, FacetShelf}
+
+type Count struct {
+	Value string
+	Count int
+}
+
+// Facet returns the results that match the filters, along with how many results have each value
+// of each facet. A result matches when, for each facet with a filter, its value is one of the
+// filter's values. A facet's counts are of the results that match the filters of the other
+// facets, so choosing one of a facet's values leaves that many results. values gives a
+// result's value of each facet, and an empty value is neither counted nor matched.
+func Facet[T any](results []T, values func(T) map[string]string, filters map[string][]string) ([]T, map[string][]Count) {
+	matches := []T{}
+	tallies := map[string]map[string]int{}
+	for _, name := range FacetNames {
+		tallies[name] = map[string]int{}
+	}
+
+	for _, result := range results {
+		resultValues := values(result)
+
+		failed := []string{}
+		for _, name := range FacetNames {
+			wanted := filters[name]
+			if len(wanted) > 0 && (resultValues[name] == "" || !slices.Contains(wanted, resultValues[name])) {
+				failed = append(failed, name)
+			}
+		}
+
+		switch len(failed) {
+		case 0:
+			matches = append(matches, result)
+			for _, name := range FacetNames {
+				if value := resultValues[name]; value != "" {
+					tallies[name][value]++
+				}
+			}
+		case 1:
+			// Only the facet's own filter leaves the result out, so it counts towards that facet.
+			if value := resultValues[failed[0]]; value != "" {
+				tallies[failed[0]][value]++
+			}
+		}
+	}
+
+	counts := map[string][]Count{}
+	for name, tally := range tallies {
+		counts[name] = []Count{}
+		for value, count := range tally {
+			counts[name] = append(counts[name], Count{Value: value, Count: count})
+		}
+		slices.SortFunc(counts[name], func(a, b Count) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Value, b.Value))
+		})
+	}
+
+	return matches, counts
+}
+
+// Highlight makes a field's ts_headline safe to show as HTML, keeping the <mark> tags around the
+// words that matched. matched is false if none did.
+func Highlight(headline string) (snippet string, matched bool) {
+	snippet = html.EscapeString(headline)
+	snippet = strings.ReplaceAll(snippet, "&lt;mark&gt;", "<mark>")
+	snippet = strings.ReplaceAll(snippet, "&lt;/mark&gt;", "</mark>")
+	return snippet, strings.Contains(snippet, "<mark>")
+}
@@ -0,0 +1,137 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	title  string
+	values map[string]string
+}
+
+func testValues(item testItem) map[string]string {
+	return item.values
+}
+
+func TestFacet(t *testing.T) {
+	items := []testItem{
+		{title: "Dune", values: map[string]string{FacetType: "movie", FacetGenre: "Sci-fi", FacetFormat: "Blu-ray", FacetDecade: "2020"}},
+		{title: "Alien", values: map[string]string{FacetType: "movie", FacetGenre: "Sci-fi", FacetFormat: "DVD", FacetDecade: "1970"}},
+		{title: "Heat", values: map[string]string{FacetType: "movie", FacetGenre: "Crime", FacetFormat: "DVD", FacetDecade: "1990"}},
+		{title: "Dune Messiah", values: map[string]string{FacetType: "book", FacetGenre: "Sci-fi"}},
+	}
+
+	tests := []struct {
+		name        string
+		filters     map[string][]string
+		wantTitles  []string
+		wantGenre   []Count
+		wantType    []Count
+		wantDecades int
+	}{
+		{
+			name:        "No filters",
+			filters:     nil,
+			wantTitles:  []string{"Dune", "Alien", "Heat", "Dune Messiah"},
+			wantGenre:   []Count{{"Sci-fi", 3}, {"Crime", 1}},
+			wantType:    []Count{{"movie", 3}, {"book", 1}},
+			wantDecades: 3,
+		},
+		{
+			name:        "A filter doesn't narrow its own facet",
+			filters:     map[string][]string{FacetGenre: {"Crime"}},
+			wantTitles:  []string{"Heat"},
+			wantGenre:   []Count{{"Sci-fi", 3}, {"Crime", 1}},
+			wantType:    []Count{{"movie", 1}},
+			wantDecades: 1,
+		},
+		{
+			name:        "Filters of other facets narrow the counts",
+			filters:     map[string][]string{FacetGenre: {"Sci-fi"}, FacetType: {"movie"}},
+			wantTitles:  []string{"Dune", "Alien"},
+			wantGenre:   []Count{{"Sci-fi", 2}, {"Crime", 1}},
+			wantType:    []Count{{"movie", 2}, {"book", 1}},
+			wantDecades: 2,
+		},
+		{
+			name:        "Any of a filter's values match",
+			filters:     map[string][]string{FacetFormat: {"DVD", "Blu-ray"}},
+			wantTitles:  []string{"Dune", "Alien", "Heat"},
+			wantGenre:   []Count{{"Sci-fi", 2}, {"Crime", 1}},
+			wantType:    []Count{{"movie", 3}},
+			wantDecades: 3,
+		},
+		{
+			name:        "Empty values don't match",
+			filters:     map[string][]string{FacetDecade: {""}},
+			wantTitles:  []string{},
+			wantGenre:   []Count{},
+			wantType:    []Count{},
+			wantDecades: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, counts := Facet(items, testValues, tc.filters)
+
+			titles := []string{}
+			for _, item := range matches {
+				titles = append(titles, item.title)
+			}
+			if !reflect.DeepEqual(titles, tc.wantTitles) {
+				t.Errorf("matches = %v, want %v", titles, tc.wantTitles)
+			}
+			if !reflect.DeepEqual(counts[FacetGenre], tc.wantGenre) {
+				t.Errorf("genre counts = %v, want %v", counts[FacetGenre], tc.wantGenre)
+			}
+			if !reflect.DeepEqual(counts[FacetType], tc.wantType) {
+				t.Errorf("type counts = %v, want %v", counts[FacetType], tc.wantType)
+			}
+			if len(counts[FacetDecade]) != tc.wantDecades {
+				t.Errorf("got %d decades, want %d", len(counts[FacetDecade]), tc.wantDecades)
+			}
+			if counts[FacetShelf] == nil {
+				t.Errorf("shelf counts are nil, want an empty list")
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name        string
+		headline    string
+		wantSnippet string
+		wantMatched bool
+	}{
+		{
+			name:        "Match",
+			headline:    "The <mark>Godfather</mark> Part II",
+			wantSnippet: "The <mark>Godfather</mark> Part II",
+			wantMatched: true,
+		},
+		{
+			name:        "No match",
+			headline:    "Heat",
+			wantSnippet: "Heat",
+			wantMatched: false,
+		},
+		{
+			name:        "Other markup is escaped",
+			headline:    "<b>Tom</b> & <mark>Jerry</mark>",
+			wantSnippet: "&lt;b&gt;Tom&lt;/b&gt; &amp; <mark>Jerry</mark>",
+			wantMatched: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			snippet, matched := Highlight(tc.headline)
+			if snippet != tc.wantSnippet || matched != tc.wantMatched {
+				t.Errorf("Highlight(%q) = %q, %v, want %q, %v", tc.headline, snippet, matched, tc.wantSnippet, tc.wantMatched)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Package search holds the parts of item search that are done outside of the database.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PrefixQuery turns the words of a search into a tsquery that matches words starting with
+// each of them, so that "godf par" becomes "godf:* & par:*". Everything but letters and digits
+// is dropped, so the result is always valid tsquery syntax. It's empty if there are no words.
+func PrefixQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for i := range words {
+		words[i] = strings.ToLower(words[i]) + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,50 @@
+package search
+
+import "testing"
+
+func TestPrefixQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "One word",
+			input: "godf",
+			want:  "godf:*",
+		},
+		{
+			name:  "Several words",
+			input: "Godf  Par",
+			want:  "godf:* & par:*",
+		},
+		{
+			name:  "tsquery syntax is dropped",
+			input: "star & !wars:* | (trek)",
+			want:  "star:* & wars:* & trek:*",
+		},
+		{
+			name:  "Punctuation splits words",
+			input: "Don't",
+			want:  "don:* & t:*",
+		},
+		{
+			name:  "Letters outside ASCII",
+			input: "Amélie",
+			want:  "amélie:*",
+		},
+		{
+			name:  "No words",
+			input: " -- ",
+			want:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PrefixQuery(tc.input); got != tc.want {
+				t.Errorf("PrefixQuery(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package webhooks signs and sends the events that are posted to a location's webhooks.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Each delivery is sent with these headers. The signature is "sha256=" followed by the hex
+// HMAC-SHA256 of the body, keyed with the webhook's secret.
+const (
+	SignatureHeader = "X-DigitalShelf-Signature"
+	EventHeader     = "X-DigitalShelf-Event"
+	DeliveryHeader  = "X-DigitalShelf-Delivery"
+)
+
+const (
+	ItemCreated   = "item.created"
+	ItemUpdated   = "item.updated"
+	ItemMoved     = "item.moved"
+	ItemDeleted   = "item.deleted"
+	CaseCreated   = "case.created"
+	CaseUpdated   = "case.updated"
+	ShelfCreated  = "shelf.created"
+	ShelfUpdated  = "shelf.updated"
+	MemberAdded   = "member.added"
+	MemberRemoved = "member.removed"
+	// Ping is only sent when a webhook is tested.
+	Ping = "ping"
+)
+
+// EventTypes are the events a webhook can subscribe to.
+var EventTypes = []string{
+	ItemCreated, ItemUpdated, ItemMoved, ItemDeleted,
+	CaseCreated, CaseUpdated,
+	ShelfCreated, ShelfUpdated,
+	MemberAdded, MemberRemoved,
+}
+
+// Event is the body of every delivery. Data depends on the type of event.
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	LocationID uuid.UUID `json:"location_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// Subscribed reports whether a webhook with the event types gets the event. A webhook with no
+// event types gets every event.
+func Subscribed(eventTypes []string, eventType string) bool {
+	return len(eventTypes) == 0 || slices.Contains(eventTypes, eventType)
+}
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature made by Sign, for receivers of webhooks.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Deliver posts an event's payload to a webhook. Any 2xx response is a success. The status of
+// the response is returned even when it isn't a success, and is 0 if there was no response.
+func Deliver(ctx context.Context, client *http.Client, url, secret, eventType string, deliveryID uuid.UUID, payload []byte) (int, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DigitalShelf-Webhooks")
+	req.Header.Set(SignatureHeader, Sign(secret, payload))
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID.String())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// MaxAttempts is how many times a delivery is tried before it's given up on.
+const MaxAttempts = 8
+
+const (
+	firstRetry = 30 * time.Second
+	maxBackoff = time.Hour
+)
+
+// Backoff is how long to wait before trying a delivery again after it has failed the given
+// number of times. It doubles with each failure, from 30 seconds up to an hour.
+func Backoff(failures int) time.Duration {
+	if failures < 1 {
+		return 0
+	}
+	backoff := firstRetry
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,159 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSign(t *testing.T) {
+	// Worked out with: printf '%s' '{"type":"ping"}' | openssl dgst -sha256 -hmac secret
+	got := Sign("secret", []byte(`{"type":"ping"}`))
+	want := "sha256=ef9c85680c299afa94246e60325ec1a8fc10a7fdcc17ff2600c19a3cd7dac2c5"
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+
+	if !Verify("secret", []byte(`{"type":"ping"}`), got) {
+		t.Errorf("Verify() rejected its own signature")
+	}
+	if Verify("other", []byte(`{"type":"ping"}`), got) {
+		t.Errorf("Verify() accepted a signature made with another secret")
+	}
+	if Verify("secret", []byte(`{"type":"pong"}`), got) {
+		t.Errorf("Verify() accepted a signature of another body")
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			status:     http.StatusOK,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "No content",
+			status:     http.StatusNoContent,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "Server error",
+			status:     http.StatusInternalServerError,
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+		{
+			name:       "Other statuses are failures",
+			status:     http.StatusNotModified,
+			wantStatus: http.StatusNotModified,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deliveryID := uuid.New()
+			payload := []byte(`{"type":"item.created"}`)
+
+			var gotBody []byte
+			var gotHeaders http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = io.ReadAll(r.Body)
+				gotHeaders = r.Header.Clone()
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			status, err := Deliver(context.Background(), server.Client(), server.URL, "secret", ItemCreated, deliveryID, payload)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Deliver() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if status != tc.wantStatus {
+				t.Errorf("Deliver() status = %d, want %d", status, tc.wantStatus)
+			}
+
+			if string(gotBody) != string(payload) {
+				t.Errorf("body = %s, want %s", gotBody, payload)
+			}
+			if !Verify("secret", gotBody, gotHeaders.Get(SignatureHeader)) {
+				t.Errorf("signature %q doesn't match the body", gotHeaders.Get(SignatureHeader))
+			}
+			if got := gotHeaders.Get(EventHeader); got != ItemCreated {
+				t.Errorf("event header = %q, want %q", got, ItemCreated)
+			}
+			if got := gotHeaders.Get(DeliveryHeader); got != deliveryID.String() {
+				t.Errorf("delivery header = %q, want %q", got, deliveryID)
+			}
+			if got := gotHeaders.Get("Content-Type"); got != "application/json" {
+				t.Errorf("content type = %q, want application/json", got)
+			}
+		})
+	}
+}
+
+func TestDeliverUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	status, err := Deliver(context.Background(), nil, url, "secret", Ping, uuid.New(), []byte(`{}`))
+	if err == nil {
+		t.Fatalf("Deliver() to a closed server succeeded")
+	}
+	if status != 0 {
+		t.Errorf("Deliver() status = %d, want 0", status)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 1, want: 30 * time.Second},
+		{failures: 2, want: time.Minute},
+		{failures: 3, want: 2 * time.Minute},
+		{failures: 7, want: 32 * time.Minute},
+		{failures: 8, want: time.Hour},
+		{failures: 100, want: time.Hour},
+	}
+
+	for _, tc := range tests {
+		if got := Backoff(tc.failures); got != tc.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tc.failures, got, tc.want)
+		}
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventTypes []string
+		eventType  string
+		want       bool
+	}{
+		{name: "No event types gets everything", eventTypes: nil, eventType: ItemMoved, want: true},
+		{name: "Subscribed", eventTypes: []string{ItemCreated, ItemMoved}, eventType: ItemMoved, want: true},
+		{name: "Not subscribed", eventTypes: []string{ItemCreated}, eventType: MemberAdded, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Subscribed(tc.eventTypes, tc.eventType); got != tc.want {
+				t.Errorf("Subscribed(%v, %q) = %v, want %v", tc.eventTypes, tc.eventType, got, tc.want)
+			}
+		})
+	}
+}
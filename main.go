@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/events"
+	"github.com/Rodabaugh/digitalshelf/internal/metadata"
 	"github.com/joho/godotenv"
 
 	_ "github.com/lib/pq"
@@ -17,7 +20,18 @@ import (
 type apiConfig struct {
 	platform  string
 	db        *database.Queries
+	dbConn    *sql.DB
 	jwtSecret string
+	metadata  metadata.Provider
+	appURL    string
+	// webhookClient sends webhook deliveries.
+	webhookClient *http.Client
+	// broker passes events to the event streams watching each location.
+	broker *events.Broker
+	// notifyEvents sends events through Postgres, so every server's streams get them.
+	notifyEvents bool
+	// idempotencyWindow is how long the response to a request with an Idempotency-Key is kept.
+	idempotencyWindow time.Duration
 }
 
 func main() {
@@ -48,6 +62,39 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is not set")
 	}
 
+	// Looking up new items online is off unless a lookup service is chosen.
+	var metadataProvider metadata.Provider
+	switch lookup := os.Getenv("METADATA_LOOKUP"); lookup {
+	case "":
+	case "openlibrary":
+		metadataProvider = metadata.OpenLibrary{Client: &http.Client{Timeout: 10 * time.Second}}
+	default:
+		log.Fatalf("METADATA_LOOKUP must be empty or openlibrary, not %s", lookup)
+	}
+
+	// Labels link to the web app at APP_URL, or at the address they were requested from.
+	appURL := os.Getenv("APP_URL")
+
+	// Events only reach the streams of this server unless they're fanned out through Postgres.
+	var notifyEvents bool
+	switch fanout := os.Getenv("EVENT_FANOUT"); fanout {
+	case "":
+	case "postgres":
+		notifyEvents = true
+	default:
+		log.Fatalf("EVENT_FANOUT must be empty or postgres, not %s", fanout)
+	}
+
+	// Responses to create requests with an Idempotency-Key are replayed to retries for a day,
+	// unless IDEMPOTENCY_WINDOW is set to another duration, such as 1h.
+	idempotencyWindow := defaultIdempotencyWindow
+	if window := os.Getenv("IDEMPOTENCY_WINDOW"); window != "" {
+		idempotencyWindow, err = time.ParseDuration(window)
+		if err != nil || idempotencyWindow < time.Second {
+			log.Fatalf("IDEMPOTENCY_WINDOW must be a duration of at least 1s, not %s", window)
+		}
+	}
+
 	dbConn, err := sql.Open("postgres", dbURL)
 	if err != nil {
 		log.Fatalf("Error opening database: %s", err)
@@ -55,98 +102,33 @@ func main() {
 	dbQueries := database.New(dbConn)
 
 	apiCfg := apiConfig{
-		platform: platform,
-		db:       dbQueries,
+		platform:          platform,
+		db:                dbQueries,
+		dbConn:            dbConn,
+		jwtSecret:         jwtSecret,
+		metadata:          metadataProvider,
+		appURL:            appURL,
+		webhookClient:     &http.Client{Timeout: 10 * time.Second},
+		broker:            events.NewBroker(),
+		notifyEvents:      notifyEvents,
+		idempotencyWindow: idempotencyWindow,
 	}
 
-	mux := http.NewServeMux()
-
-	mux.HandleFunc("GET /admin/healthz", readinessEndpoint)
-
-	mux.HandleFunc("GET /", apiCfg.webApp)
-	mux.HandleFunc("GET /users/{user_id}/locations", apiCfg.appGetUserLocations)
-	mux.HandleFunc("POST /locations", apiCfg.appCreateLocation)
-
-	mux.HandleFunc("GET /login", func(w http.ResponseWriter, r *http.Request) {
-		Login(false).Render(r.Context(), w)
-	})
-	
-	mux.HandleFunc("GET /register", func(w http.ResponseWriter, r *http.Request) {
-		Register().Render(r.Context(), w)
-	})
-	
-	mux.HandleFunc("POST /api/users", apiCfg.handlerUsersCreate)
-	mux.HandleFunc("PUT /api/users", apiCfg.handlerUsersUpdate)
-	mux.HandleFunc("POST /api/login", apiCfg.handlerLogin)
-	mux.HandleFunc("POST /api/refresh", apiCfg.handlerRefresh)
-	mux.HandleFunc("POST /api/revoke", apiCfg.handlerRevoke)
-	mux.HandleFunc("POST /api/revoke-all", apiCfg.handlerRevokeSessions)
-
-	mux.HandleFunc("POST /api/locations", apiCfg.handlerLocationsCreate)
-	mux.HandleFunc("POST /api/cases", apiCfg.handlerCasesCreate)
-	mux.HandleFunc("GET /api/cases", apiCfg.handlerCaseGet)
-	mux.HandleFunc("POST /api/shelves", apiCfg.handlerShelfCreate)
-	mux.HandleFunc("GET /api/shelves", apiCfg.handlerShelvesGet)
-	mux.HandleFunc("POST /api/movies", apiCfg.handlerMovieCreate)
-	mux.HandleFunc("GET /api/movies", apiCfg.handlerMoviesGet)
-	mux.HandleFunc("PUT /api/movies/{movie_id}", apiCfg.handlerMoviesUpdate)
-	mux.HandleFunc("POST /api/shows", apiCfg.handlerShowCreate)
-	mux.HandleFunc("GET /api/shows", apiCfg.handlerShowsGet)
-	mux.HandleFunc("POST /api/books", apiCfg.handlerBookCreate)
-	mux.HandleFunc("GET /api/books", apiCfg.handlerBooksGet)
-	mux.HandleFunc("POST /api/music", apiCfg.handlerMusicCreate)
-	mux.HandleFunc("GET /api/music", apiCfg.handlerMusicGet)
-
-	mux.HandleFunc("GET /api/users", apiCfg.handlerUsersGet)
-	mux.HandleFunc("GET /api/users/{user_id}", apiCfg.handlerUserGetByID)
-	mux.HandleFunc("GET /api/users/{user_id}/locations", apiCfg.handlerGetUserLocations)
-	mux.HandleFunc("GET /api/users/{user_id}/invites", apiCfg.handlerGetUserInvites)
-	mux.HandleFunc("GET /api/locations", apiCfg.handlerLocationsGet)
-	mux.HandleFunc("GET /api/locations/{location_id}", apiCfg.handlerLocationsGetByID)
-	mux.HandleFunc("GET /api/locations/{location_id}/members", apiCfg.handlerGetLocationMembers)
-	mux.HandleFunc("GET /api/locations/{location_id}/invites", apiCfg.handlerGetLocationInvites)
-	mux.HandleFunc("GET /api/locations/{location_id}/cases", apiCfg.handlerCasesGetByLocation)
-	mux.HandleFunc("GET /api/locations/{location_id}/movies", apiCfg.handlerMoviesGetByLocation)
-	mux.HandleFunc("GET /api/locations/{location_id}/shows", apiCfg.handlerShowsGetByLocation)
-	mux.HandleFunc("GET /api/locations/{location_id}/books", apiCfg.handlerBooksGetByLocation)
-	mux.HandleFunc("GET /api/locations/{location_id}/music", apiCfg.handlerMusicGetByLocation)
-	mux.HandleFunc("GET /api/cases/{case_id}", apiCfg.handlerCaseGetByID)
-	mux.HandleFunc("GET /api/cases/{case_id}/shelves", apiCfg.handlerShelvesGetByCase)
-	mux.HandleFunc("GET /api/shelves/{shelf_id}", apiCfg.handlerShelfGetByID)
-	mux.HandleFunc("GET /api/shelves/{shelf_id}/movies", apiCfg.handlerMoviesGetByShelf)
-	mux.HandleFunc("GET /api/movies/{movie_id}", apiCfg.handlerMovieGetByID)
-	mux.HandleFunc("GET /api/shelves/{shelf_id}/shows", apiCfg.handlerShowsGetByShelf)
-	mux.HandleFunc("GET /api/shows/{show_id}", apiCfg.handlerShowGetByID)
-	mux.HandleFunc("GET /api/shelves/{shelf_id}/books", apiCfg.handlerBooksGetByShelf)
-	mux.HandleFunc("GET /api/books/{book_id}", apiCfg.handlerBookGetByID)
-	mux.HandleFunc("GET /api/shelves/{shelf_id}/music", apiCfg.handlerMusicGetByShelf)
-	mux.HandleFunc("GET /api/music/{music_id}", apiCfg.handlerMusicGetByID)
-
-	mux.HandleFunc("DELETE /api/locations/{location_id}/members/{user_id}", apiCfg.handlerRemoveLocationMember)
-	mux.HandleFunc("POST /api/locations/{location_id}/members", apiCfg.handlerAddLocationMember)
-	mux.HandleFunc("DELETE /api/locations/{location_id}/invites/{user_id}", apiCfg.handlerRemoveLocationInvite)
-	mux.HandleFunc("POST /api/locations/{location_id}/invites", apiCfg.handlerAddLocationInvite)
-
-	mux.HandleFunc("GET /api/search/users", apiCfg.handlerUsersGetByEmail)
-	mux.HandleFunc("GET /api/search/locations/", apiCfg.handlerLocationsGetByOwner)
-	mux.HandleFunc("GET /api/search/movie_barcodes/{barcode}", apiCfg.handlerGetMovieByBarcode)
-	mux.HandleFunc("GET /api/search/movies", apiCfg.handlerSearchMovies)
-	mux.HandleFunc("GET /api/search/show_barcodes/{barcode}", apiCfg.handlerGetShowByBarcode)
-	mux.HandleFunc("GET /api/search/shows", apiCfg.handlerSearchShows)
-	mux.HandleFunc("GET /api/search/book_barcodes/{barcode}", apiCfg.handlerGetBookByBarcode)
-	mux.HandleFunc("GET /api/search/books", apiCfg.handlerSearchBooks)
-	mux.HandleFunc("GET /api/search/music_barcodes/{barcode}", apiCfg.handlerGetMusicByBarcode)
-	mux.HandleFunc("GET /api/search/music", apiCfg.handlerSearchMusic)
-
-	mux.HandleFunc("POST /admin/reset", apiCfg.handlerReset)
+	handler := apiCfg.routes()
 
 	server := &http.Server{
 		Addr:         ":" + port,
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
+	go apiCfg.deliverWebhooks(context.Background(), 10*time.Second)
+	go apiCfg.deleteExpiredIdempotencyKeys(context.Background(), time.Hour)
+	if notifyEvents {
+		go apiCfg.listenForEvents(context.Background(), dbURL)
+	}
+
 	log.Printf("Serving DigitalShelf backend on port: %s\n", port)
 	log.Fatal(server.ListenAndServe())
 }
@@ -0,0 +1,140 @@
+package main
+
+import "net/http"
+
+// routes returns the handler that serves the web app and the API.
+func (cfg *apiConfig) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /admin/healthz", readinessEndpoint)
+
+	mux.HandleFunc("GET /", cfg.webApp)
+	mux.HandleFunc("GET /users/{user_id}/locations", cfg.appGetUserLocations)
+	mux.HandleFunc("POST /locations", cfg.appCreateLocation)
+	mux.HandleFunc("GET /shelves/{shelf_id}", cfg.appGetShelf)
+	mux.HandleFunc("GET /cases/{case_id}", cfg.appGetCase)
+	mux.HandleFunc("GET /smart_shelves/{smart_shelf_id}", cfg.appGetSmartShelf)
+	mux.HandleFunc("GET /locations/{location_id}/events", cfg.appLocationEvents)
+
+	mux.HandleFunc("GET /login", func(w http.ResponseWriter, r *http.Request) {
+		Login(false).Render(r.Context(), w)
+	})
+
+	mux.HandleFunc("GET /register", func(w http.ResponseWriter, r *http.Request) {
+		Register().Render(r.Context(), w)
+	})
+
+	mux.HandleFunc("POST /api/users", cfg.handlerUsersCreate)
+	mux.HandleFunc("PUT /api/users", cfg.handlerUsersUpdate)
+	mux.HandleFunc("POST /api/login", cfg.handlerLogin)
+	mux.HandleFunc("POST /api/refresh", cfg.handlerRefresh)
+	mux.HandleFunc("POST /api/revoke", cfg.handlerRevoke)
+	mux.HandleFunc("POST /api/revoke-all", cfg.handlerRevokeSessions)
+
+	mux.HandleFunc("POST /api/locations", cfg.idempotent(cfg.handlerLocationsCreate))
+	mux.HandleFunc("POST /api/cases", cfg.idempotent(cfg.handlerCasesCreate))
+	mux.HandleFunc("GET /api/cases", cfg.handlerCaseGet)
+	mux.HandleFunc("POST /api/shelves", cfg.idempotent(cfg.handlerShelfCreate))
+	mux.HandleFunc("POST /api/items:batch", cfg.idempotent(cfg.handlerItemsBatchCreate))
+	mux.HandleFunc("POST /api/items:move", cfg.handlerItemsBatchMove)
+	mux.HandleFunc("GET /api/shelves", cfg.handlerShelvesGet)
+
+	registerItemRoutes(mux, cfg)
+
+	mux.HandleFunc("POST /api/bundles", cfg.idempotent(cfg.handlerBundleCreate))
+	mux.HandleFunc("PUT /api/bundles/{bundle_id}", cfg.handlerBundleMove)
+	mux.HandleFunc("DELETE /api/bundles/{bundle_id}", cfg.handlerBundleDelete)
+	mux.HandleFunc("POST /api/bundles/{bundle_id}/items", cfg.handlerBundleAddItem)
+	mux.HandleFunc("DELETE /api/bundles/{bundle_id}/items/{item_type}/{item_id}", cfg.handlerBundleRemoveItem)
+	mux.HandleFunc("POST /api/series", cfg.idempotent(cfg.handlerSeriesCreate))
+	mux.HandleFunc("POST /api/series/{series_id}/episodes", cfg.idempotent(cfg.handlerEpisodeCreate))
+	mux.HandleFunc("POST /api/episodes/{episode_id}/watched", cfg.handlerEpisodeMarkWatched)
+	mux.HandleFunc("DELETE /api/episodes/{episode_id}/watched", cfg.handlerEpisodeUnmarkWatched)
+
+	mux.HandleFunc("GET /api/users", cfg.handlerUsersGet)
+	mux.HandleFunc("GET /api/users/{user_id}", cfg.handlerUserGetByID)
+	mux.HandleFunc("GET /api/users/{user_id}/locations", cfg.handlerGetUserLocations)
+	mux.HandleFunc("GET /api/users/{user_id}/invites", cfg.handlerGetUserInvites)
+	mux.HandleFunc("GET /api/users/{user_id}/next_episodes", cfg.handlerGetUserNextEpisodes)
+	mux.HandleFunc("GET /api/users/{user_id}/search", cfg.handlerUserSearch)
+	mux.HandleFunc("GET /api/locations", cfg.handlerLocationsGet)
+	mux.HandleFunc("GET /api/locations/{location_id}", cfg.handlerLocationsGetByID)
+	mux.HandleFunc("GET /api/locations/{location_id}/members", cfg.handlerGetLocationMembers)
+	mux.HandleFunc("GET /api/locations/{location_id}/invites", cfg.handlerGetLocationInvites)
+	mux.HandleFunc("GET /api/locations/{location_id}/cases", cfg.handlerCasesGetByLocation)
+	mux.HandleFunc("GET /api/locations/{location_id}/bundles", cfg.handlerBundlesGetByLocation)
+	mux.HandleFunc("GET /api/locations/{location_id}/series", cfg.handlerSeriesGetByLocation)
+	mux.HandleFunc("GET /api/locations/{location_id}/stats", cfg.handlerLocationStats)
+	mux.HandleFunc("GET /api/cases/{case_id}", cfg.handlerCaseGetByID)
+	mux.HandleFunc("GET /api/cases/{case_id}/shelves", cfg.handlerShelvesGetByCase)
+	mux.HandleFunc("GET /api/shelves/{shelf_id}", cfg.handlerShelfGetByID)
+	mux.HandleFunc("GET /api/shelves/{shelf_id}/bundles", cfg.handlerBundlesGetByShelf)
+	mux.HandleFunc("PUT /api/shelves/{shelf_id}", cfg.handlerShelfUpdate)
+	mux.HandleFunc("GET /api/shelves/{shelf_id}/items", cfg.handlerShelfItemsGet)
+	mux.HandleFunc("PUT /api/shelves/{shelf_id}/order", cfg.handlerShelfReorder)
+	mux.HandleFunc("POST /api/shelves/{shelf_id}/sort", cfg.handlerShelfSort)
+	mux.HandleFunc("GET /api/bundles/{bundle_id}", cfg.handlerBundleGetByID)
+	mux.HandleFunc("GET /api/shows/{show_id}/episodes", cfg.handlerEpisodesGetByShow)
+	mux.HandleFunc("GET /api/series/{series_id}", cfg.handlerSeriesGetByID)
+	mux.HandleFunc("GET /api/series/{series_id}/episodes", cfg.handlerEpisodesGetBySeries)
+
+	mux.HandleFunc("DELETE /api/locations/{location_id}/members/{user_id}", cfg.handlerRemoveLocationMember)
+	mux.HandleFunc("POST /api/locations/{location_id}/members", cfg.handlerAddLocationMember)
+	mux.HandleFunc("DELETE /api/locations/{location_id}/invites/{user_id}", cfg.handlerRemoveLocationInvite)
+	mux.HandleFunc("POST /api/locations/{location_id}/invites", cfg.idempotent(cfg.handlerAddLocationInvite))
+	mux.HandleFunc("POST /api/locations/{location_id}/custom_fields", cfg.idempotent(cfg.handlerCustomFieldCreate))
+	mux.HandleFunc("GET /api/locations/{location_id}/custom_fields", cfg.handlerCustomFieldsGetByLocation)
+	mux.HandleFunc("DELETE /api/locations/{location_id}/custom_fields/{field_id}", cfg.handlerCustomFieldDelete)
+
+	mux.HandleFunc("POST /api/audits", cfg.idempotent(cfg.handlerAuditCreate))
+	mux.HandleFunc("GET /api/audits/{audit_id}", cfg.handlerAuditGetByID)
+	mux.HandleFunc("GET /api/locations/{location_id}/audits", cfg.handlerAuditsGetByLocation)
+	mux.HandleFunc("POST /api/audits/{audit_id}/scans", cfg.handlerAuditScan)
+	mux.HandleFunc("GET /api/audits/{audit_id}/report", cfg.handlerAuditReport)
+	mux.HandleFunc("POST /api/audits/{audit_id}/relocate", cfg.handlerAuditRelocate)
+	mux.HandleFunc("POST /api/audits/{audit_id}/mark_missing", cfg.handlerAuditMarkMissing)
+	mux.HandleFunc("POST /api/audits/{audit_id}/complete", cfg.handlerAuditComplete)
+
+	mux.HandleFunc("PUT /api/cases/{case_id}", cfg.handlerCaseUpdate)
+	mux.HandleFunc("POST /api/locations/{location_id}/scan_sessions", cfg.idempotent(cfg.handlerScanSessionCreate))
+	mux.HandleFunc("GET /api/scan_sessions/{session_id}", cfg.handlerScanSessionGetByID)
+	mux.HandleFunc("POST /api/scan_sessions/{session_id}/scans", cfg.handlerScanSessionScan)
+	mux.HandleFunc("POST /api/scan_sessions/{session_id}/close", cfg.handlerScanSessionClose)
+	mux.HandleFunc("GET /api/scan_sessions/{session_id}/drafts", cfg.handlerScanDraftsGetBySession)
+	mux.HandleFunc("DELETE /api/scan_drafts/{draft_id}", cfg.handlerScanDraftDelete)
+
+	mux.HandleFunc("GET /api/shelves/{shelf_id}/label", cfg.handlerShelfLabel)
+	mux.HandleFunc("GET /api/cases/{case_id}/label", cfg.handlerCaseLabel)
+	mux.HandleFunc("GET /api/locations/{location_id}/labels", cfg.handlerLocationLabels)
+
+	mux.HandleFunc("GET /api/locations/{location_id}/search", cfg.handlerLocationSearch)
+	mux.HandleFunc("GET /api/locations/{location_id}/autocomplete", cfg.handlerLocationAutocomplete)
+
+	mux.HandleFunc("GET /api/locations/{location_id}/shelves", cfg.handlerLocationShelvesGet)
+	mux.HandleFunc("POST /api/locations/{location_id}/smart_shelves", cfg.idempotent(cfg.handlerSmartShelfCreate))
+	mux.HandleFunc("GET /api/locations/{location_id}/smart_shelves", cfg.handlerSmartShelvesGetByLocation)
+	mux.HandleFunc("GET /api/smart_shelves/{smart_shelf_id}", cfg.handlerSmartShelfGet)
+	mux.HandleFunc("PUT /api/smart_shelves/{smart_shelf_id}", cfg.handlerSmartShelfUpdate)
+	mux.HandleFunc("DELETE /api/smart_shelves/{smart_shelf_id}", cfg.handlerSmartShelfDelete)
+	mux.HandleFunc("GET /api/smart_shelves/{smart_shelf_id}/items", cfg.handlerSmartShelfItems)
+
+	mux.HandleFunc("GET /api/locations/{location_id}/events", cfg.handlerLocationEvents)
+	mux.HandleFunc("GET /api/locations/{location_id}/changes", cfg.handlerLocationChanges)
+	mux.HandleFunc("POST /api/locations/{location_id}/changes", cfg.handlerLocationApplyChanges)
+
+	mux.HandleFunc("POST /api/locations/{location_id}/webhooks", cfg.idempotent(cfg.handlerWebhookCreate))
+	mux.HandleFunc("GET /api/locations/{location_id}/webhooks", cfg.handlerWebhooksGetByLocation)
+	mux.HandleFunc("PUT /api/webhooks/{webhook_id}", cfg.handlerWebhookUpdate)
+	mux.HandleFunc("DELETE /api/webhooks/{webhook_id}", cfg.handlerWebhookDelete)
+	mux.HandleFunc("GET /api/webhooks/{webhook_id}/deliveries", cfg.handlerWebhookDeliveries)
+	mux.HandleFunc("POST /api/webhooks/{webhook_id}/test", cfg.handlerWebhookTest)
+
+	mux.HandleFunc("GET /api/search/users", cfg.handlerUsersGetByEmail)
+	mux.HandleFunc("GET /api/search/locations/", cfg.handlerLocationsGetByOwner)
+	mux.HandleFunc("GET /api/search/bundle_barcodes/{barcode}", cfg.handlerGetBundlesByBarcode)
+	mux.HandleFunc("GET /api/search/barcodes/{barcode}", cfg.handlerBarcodeLookup)
+
+	mux.HandleFunc("POST /admin/reset", cfg.handlerReset)
+
+	return cfg.flagInvalidTokens(mux)
+}
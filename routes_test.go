@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf/client"
+	"github.com/Rodabaugh/digitalshelf/internal/auth"
+	"github.com/Rodabaugh/digitalshelf/internal/database"
+	"github.com/Rodabaugh/digitalshelf/internal/events"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// contentTypes records the Content-Type of each response it passes on.
+type contentTypes struct {
+	last string
+}
+
+func (c *contentTypes) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil {
+		c.last = resp.Header.Get("Content-Type")
+	}
+	return resp, err
+}
+
+// newTestServer serves the real mux, with a database that can't be reached, so that every
+// request that gets as far as the database fails.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	dbConn, err := sql.Open("postgres", "host=127.0.0.1 port=1 sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { dbConn.Close() })
+
+	cfg := &apiConfig{
+		platform:          "dev",
+		db:                database.New(dbConn),
+		dbConn:            dbConn,
+		jwtSecret:         "secret",
+		webhookClient:     &http.Client{Timeout: time.Second},
+		broker:            events.NewBroker(),
+		idempotencyWindow: time.Hour,
+	}
+	server := httptest.NewServer(cfg.routes())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientHealthz(t *testing.T) {
+	server := newTestServer(t)
+
+	if err := client.New(server.URL).Admin.Healthz(context.Background()); err != nil {
+		t.Errorf("Healthz() error = %v", err)
+	}
+}
+
+func TestClientUnauthorized(t *testing.T) {
+	server := newTestServer(t)
+	c := client.New(server.URL)
+
+	tests := []struct {
+		name string
+		call func(context.Context) error
+	}{
+		{"Locations.Get", func(ctx context.Context) error {
+			_, err := c.Locations.Get(ctx, uuid.New())
+			return err
+		}},
+		{"Games.List", func(ctx context.Context) error {
+			_, err := c.Games.List(ctx)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(context.Background())
+			var apiErr *client.Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want an *client.Error", err)
+			}
+			if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message == "" {
+				t.Errorf("error = %v, want a 401 with a message", apiErr)
+			}
+		})
+	}
+}
+
+func TestInvalidTokenHeader(t *testing.T) {
+	server := newTestServer(t)
+	expired, err := auth.MakeJWT(uuid.New(), "secret", -time.Minute)
+	if err != nil {
+		t.Fatalf("MakeJWT() error = %v", err)
+	}
+	wrongSecret, err := auth.MakeJWT(uuid.New(), "rotated", time.Hour)
+	if err != nil {
+		t.Fatalf("MakeJWT() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+	}{
+		{name: "Expired token", authorization: "Bearer " + expired, want: `Bearer error="invalid_token"`},
+		{name: "Token signed with another secret", authorization: "Bearer " + wrongSecret, want: `Bearer error="invalid_token"`},
+		{name: "No token", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/api/locations/"+uuid.NewString(), nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+			if got := resp.Header.Get("WWW-Authenticate"); got != tt.want {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClientRoutes checks that each client method reaches a handler of the real mux, rather
+// than the mux's own 404 and 405 responses or the web app, by checking that it gets a JSON
+// error back.
+func TestClientRoutes(t *testing.T) {
+	server := newTestServer(t)
+	token, err := auth.MakeJWT(uuid.New(), "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("MakeJWT() error = %v", err)
+	}
+	transport := &contentTypes{}
+	c := client.New(server.URL, client.WithHTTPClient(&http.Client{Transport: transport}), client.WithTokens(token, ""))
+	id := uuid.New()
+	ref := client.ItemRef{ItemType: "movie", ItemID: uuid.New()}
+
+	tests := []struct {
+		name string
+		call func(context.Context) error
+	}{
+		{"Login", func(ctx context.Context) error {
+			_, err := c.Login(ctx, "user@example.com", "password")
+			return err
+		}},
+		{"Users.Get", func(ctx context.Context) error {
+			_, err := c.Users.Get(ctx, id)
+			return err
+		}},
+		{"Users.Locations", func(ctx context.Context) error {
+			_, err := c.Users.Locations(ctx, id)
+			return err
+		}},
+		{"Users.Invites", func(ctx context.Context) error {
+			_, err := c.Users.Invites(ctx, id)
+			return err
+		}},
+		{"Locations.Create", func(ctx context.Context) error {
+			_, err := c.Locations.Create(ctx, "Home", id)
+			return err
+		}},
+		{"Locations.Get", func(ctx context.Context) error {
+			_, err := c.Locations.Get(ctx, id)
+			return err
+		}},
+		{"Locations.Stats", func(ctx context.Context) error {
+			_, err := c.Locations.Stats(ctx, id)
+			return err
+		}},
+		{"Locations.Members", func(ctx context.Context) error {
+			_, err := c.Locations.Members(ctx, id)
+			return err
+		}},
+		{"Locations.Invite", func(ctx context.Context) error {
+			_, err := c.Locations.Invite(ctx, id, id)
+			return err
+		}},
+		{"Locations.RemoveInvite", func(ctx context.Context) error {
+			return c.Locations.RemoveInvite(ctx, id, id)
+		}},
+		{"Cases.Get", func(ctx context.Context) error {
+			_, err := c.Cases.Get(ctx, id)
+			return err
+		}},
+		{"Cases.ListByLocation", func(ctx context.Context) error {
+			_, err := c.Cases.ListByLocation(ctx, id)
+			return err
+		}},
+		{"Shelves.Get", func(ctx context.Context) error {
+			_, err := c.Shelves.Get(ctx, id)
+			return err
+		}},
+		{"Shelves.ListByCase", func(ctx context.Context) error {
+			_, err := c.Shelves.ListByCase(ctx, id)
+			return err
+		}},
+		{"Shelves.Items", func(ctx context.Context) error {
+			_, err := c.Shelves.Items(ctx, id)
+			return err
+		}},
+		{"Shelves.Reorder", func(ctx context.Context) error {
+			_, err := c.Shelves.Reorder(ctx, id, []client.ItemRef{ref})
+			return err
+		}},
+		{"Movies.Get", func(ctx context.Context) error {
+			_, err := c.Movies.Get(ctx, id)
+			return err
+		}},
+		{"Music.ListByLocation", func(ctx context.Context) error {
+			_, err := c.Music.ListByLocation(ctx, id, client.ListOptions{Decade: 1980})
+			return err
+		}},
+		{"Books.ListByBarcode", func(ctx context.Context) error {
+			_, err := c.Books.ListByBarcode(ctx, "9780000000000")
+			return err
+		}},
+		{"Games.List", func(ctx context.Context) error {
+			_, err := c.Games.List(ctx)
+			return err
+		}},
+		{"Games.Delete", func(ctx context.Context) error {
+			return c.Games.Delete(ctx, id)
+		}},
+		{"Batch.Move", func(ctx context.Context) error {
+			_, err := c.Batch.Move(ctx, id, []client.ItemRef{ref})
+			return err
+		}},
+		{"Bundles.Get", func(ctx context.Context) error {
+			_, err := c.Bundles.Get(ctx, id)
+			return err
+		}},
+		{"Bundles.RemoveItem", func(ctx context.Context) error {
+			return c.Bundles.RemoveItem(ctx, id, ref)
+		}},
+		{"Series.Episodes", func(ctx context.Context) error {
+			_, err := c.Series.Episodes(ctx, id)
+			return err
+		}},
+		{"Series.MarkWatched", func(ctx context.Context) error {
+			return c.Series.MarkWatched(ctx, id)
+		}},
+		{"CustomFields.List", func(ctx context.Context) error {
+			_, err := c.CustomFields.List(ctx, id, "")
+			return err
+		}},
+		{"Audits.Report", func(ctx context.Context) error {
+			_, err := c.Audits.Report(ctx, id)
+			return err
+		}},
+		{"ScanSessions.Drafts", func(ctx context.Context) error {
+			_, err := c.ScanSessions.Drafts(ctx, id)
+			return err
+		}},
+		{"Labels.Shelf", func(ctx context.Context) error {
+			_, err := c.Labels.Shelf(ctx, id, client.LabelOptions{})
+			return err
+		}},
+		{"Search.Location", func(ctx context.Context) error {
+			_, err := c.Search.Location(ctx, id, "alien", nil)
+			return err
+		}},
+		{"Search.Barcode", func(ctx context.Context) error {
+			_, err := c.Search.Barcode(ctx, "9780000000000", uuid.Nil)
+			return err
+		}},
+		{"SmartShelves.Items", func(ctx context.Context) error {
+			_, err := c.SmartShelves.Items(ctx, id)
+			return err
+		}},
+		{"Webhooks.Deliveries", func(ctx context.Context) error {
+			_, err := c.Webhooks.Deliveries(ctx, id, 10)
+			return err
+		}},
+		{"Sync.Changes", func(ctx context.Context) error {
+			_, err := c.Sync.Changes(ctx, id, 0, 0)
+			return err
+		}},
+		{"Sync.Events", func(ctx context.Context) error {
+			_, err := c.Sync.Events(ctx, id)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			transport.last = ""
+			err := tt.call(ctx)
+			var apiErr *client.Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want a *client.Error", err)
+			}
+			if transport.last != "application/json" || apiErr.Message == "" {
+				t.Errorf("got %d with Content-Type %q and message %q, want a JSON error from a handler", apiErr.StatusCode, transport.last, apiErr.Message)
+			}
+		})
+	}
+}
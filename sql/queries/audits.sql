@@ -0,0 +1,109 @@
+-- name: CreateAudit :one
+INSERT INTO audits (id, created_at, updated_at, location_id, case_id, shelf_id, started_by)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetAuditByID :one
+SELECT * FROM audits WHERE id = $1;
+
+-- name: GetAuditsByLocation :many
+SELECT * FROM audits WHERE location_id = $1
+ORDER BY created_at DESC;
+
+-- name: CompleteAudit :one
+UPDATE audits
+SET updated_at = NOW(), completed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateAuditScan :one
+INSERT INTO audit_scans (id, audit_id, barcode, shelf_id, scanned_at)
+VALUES (
+    gen_random_uuid(), $1, $2, $3, NOW()
+)
+RETURNING *;
+
+-- name: CountAuditScans :one
+SELECT COUNT(*) FROM audit_scans WHERE audit_id = $1;
+
+-- name: GetAuditItems :many
+-- GetAuditItems returns the items in the audit's scope that can be scanned. Items without a
+-- barcode can't be, so they're left out rather than reported missing on every audit.
+SELECT location_items.* FROM location_items
+INNER JOIN audits ON location_items.location_id = audits.location_id
+WHERE audits.id = $1
+AND (audits.case_id IS NULL OR location_items.case_id = audits.case_id)
+AND (audits.shelf_id IS NULL OR location_items.shelf_id = audits.shelf_id)
+AND location_items.barcode <> ''
+ORDER BY location_items.shelf_id, location_items.position, location_items.title;
+
+-- name: GetAuditFoundItems :many
+-- GetAuditFoundItems matches each scan to one copy of an item with the scanned barcode. Copies
+-- recorded on the scanned shelf are matched first, so a copy that's where it belongs isn't
+-- reported as misplaced because another copy was scanned somewhere else. The scans left over
+-- match the remaining copies, which are misplaced, most recently scanned shelf first.
+WITH scans AS (
+    SELECT audit_scans.barcode, audit_scans.shelf_id, COUNT(*) AS scans, MAX(audit_scans.scanned_at) AS scanned_at
+    FROM audit_scans
+    WHERE audit_scans.audit_id = $1
+    GROUP BY audit_scans.barcode, audit_scans.shelf_id
+),
+copies AS (
+    SELECT items.item_type, items.id, items.barcode, items.shelf_id,
+        ROW_NUMBER() OVER (PARTITION BY items.barcode, items.shelf_id ORDER BY items.position, items.id) AS shelf_rank
+    FROM location_items AS items
+    INNER JOIN audits ON items.location_id = audits.location_id
+    WHERE audits.id = $1
+    AND items.barcode IN (SELECT scans.barcode FROM scans)
+),
+in_place AS (
+    SELECT copies.item_type, copies.id, copies.shelf_id AS found_shelf_id
+    FROM copies
+    INNER JOIN scans ON scans.barcode = copies.barcode AND scans.shelf_id = copies.shelf_id
+    WHERE copies.shelf_rank <= scans.scans
+),
+open_scans AS (
+    SELECT scans.barcode, scans.shelf_id,
+        ROW_NUMBER() OVER (PARTITION BY scans.barcode ORDER BY scans.scanned_at DESC, scans.shelf_id, slot) AS slot_rank
+    FROM scans
+    CROSS JOIN LATERAL generate_series(1, scans.scans - (
+        SELECT COUNT(*) FROM copies WHERE copies.barcode = scans.barcode AND copies.shelf_id = scans.shelf_id
+    )) AS slot
+),
+open_copies AS (
+    SELECT copies.item_type, copies.id, copies.barcode,
+        ROW_NUMBER() OVER (PARTITION BY copies.barcode ORDER BY copies.shelf_id, copies.shelf_rank) AS copy_rank
+    FROM copies
+    WHERE NOT EXISTS (
+        SELECT 1 FROM in_place WHERE in_place.item_type = copies.item_type AND in_place.id = copies.id
+    )
+),
+matches AS (
+    SELECT in_place.item_type, in_place.id, in_place.found_shelf_id FROM in_place
+    UNION ALL
+    SELECT open_copies.item_type, open_copies.id, open_scans.shelf_id AS found_shelf_id
+    FROM open_copies
+    INNER JOIN open_scans ON open_scans.barcode = open_copies.barcode AND open_scans.slot_rank = open_copies.copy_rank
+)
+SELECT location_items.*,
+    recorded.name AS recorded_shelf_name, found.id AS found_shelf_id, found.name AS found_shelf_name
+FROM matches
+INNER JOIN location_items ON location_items.item_type = matches.item_type AND location_items.id = matches.id
+INNER JOIN shelves AS recorded ON location_items.shelf_id = recorded.id
+INNER JOIN shelves AS found ON matches.found_shelf_id = found.id
+ORDER BY location_items.item_type, location_items.id;
+
+-- name: GetAuditUnknownBarcodes :many
+SELECT audit_scans.barcode, audit_scans.shelf_id, shelves.name AS shelf_name, COUNT(*) AS scans
+FROM audit_scans
+INNER JOIN audits ON audit_scans.audit_id = audits.id
+INNER JOIN shelves ON audit_scans.shelf_id = shelves.id
+WHERE audit_scans.audit_id = $1
+AND NOT EXISTS (
+    SELECT 1 FROM location_items
+    WHERE location_items.location_id = audits.location_id AND location_items.barcode = audit_scans.barcode
+)
+GROUP BY audit_scans.barcode, audit_scans.shelf_id, shelves.name
+ORDER BY shelves.name, audit_scans.barcode;
\ No newline at end of file
@@ -1,30 +1,64 @@
 -- name: CreateBook :one
-INSERT INTO books (id, created_at, updated_at, title, author, genre, publication_date, barcode, shelf_id)
+INSERT INTO books (id, created_at, updated_at, title, author, genre, publication_date, publication_date_precision, barcode, shelf_id, isbn, publisher, page_count, edition, language, series, series_number, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $7)
 ) RETURNING *;
 
--- name: GetBooks :many
-SELECT * FROM books;
+-- name: GetBooksForUser :many
+SELECT books.* FROM books
+INNER JOIN shelves
+ON books.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY books.title;
 
 -- name: GetBooksByShelf :many
-SELECT * FROM books WHERE shelf_id = $1;
+SELECT * FROM books WHERE shelf_id = @shelf_id
+AND custom_fields @> @custom_fields::jsonb
+ORDER BY position, title;
 
 -- name: GetBookByID :one
 SELECT * FROM books WHERE id = $1;
 
--- name: GetBookByBarcode :one
-SELECT * FROM books WHERE barcode = $1;
+-- name: GetBooksByBarcodeForUser :many
+SELECT books.* FROM books
+INNER JOIN shelves
+ON books.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE books.barcode = $1
+AND location_user.user_id = $2;
 
 -- name: GetBooksByLocation :many
-SELECT * FROM books
+SELECT books.* FROM books
 INNER JOIN shelves
 ON books.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
-WHERE locations.id = $1;
+WHERE locations.id = @location_id
+AND books.custom_fields @> @custom_fields::jsonb;
+
+-- name: GetBooksByLocationAndDecade :many
+SELECT books.* FROM books
+INNER JOIN shelves
+ON books.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = @location_id
+AND publication_date >= make_date(@decade::int, 1, 1)
+AND publication_date < make_date(@decade::int + 10, 1, 1)
+AND books.custom_fields @> @custom_fields::jsonb
+ORDER BY publication_date,
+    CASE publication_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title;
 
 -- name: GetBookLocation :one
 SELECT locations.id, locations.name
@@ -34,20 +68,50 @@ JOIN shelves ON cases.id = shelves.case_id
 JOIN books ON shelves.id = books.shelf_id
 WHERE books.id = $1;
 
--- name: SearchBooks :many
-SELECT books.id, books.created_at, books.updated_at, title, author, genre, publication_date, barcode, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM books
-INNER JOIN shelves
-ON books.shelf_id = shelves.id
-INNER JOIN cases
-ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC;
\ No newline at end of file
+-- name: GetBooksByBundle :many
+SELECT * FROM books WHERE bundle_id = $1
+ORDER BY position, title;
+
+-- name: AddBookToBundle :execrows
+-- AddBookToBundle doesn't take an item out of another bundle.
+UPDATE books
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2);
+
+-- name: RemoveBookFromBundle :execrows
+UPDATE books
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2;
+
+-- name: UpdateBook :one
+UPDATE books
+SET updated_at = NOW(), title = $2, author = $3, genre = $4, publication_date = $5,
+    publication_date_precision = $6, barcode = $7, shelf_id = $8, isbn = $9, publisher = $10, page_count = $11,
+    edition = $12, language = $13, series = $14, series_number = $15, custom_fields = $16, thickness_cm = $17,
+    position = CASE WHEN shelf_id = $8 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $8) END
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBook :exec
+DELETE FROM books WHERE id = $1;
+
+-- name: SetBookPosition :exec
+UPDATE books
+SET updated_at = NOW(), position = $2
+WHERE id = $1;
+
+-- name: MoveBook :exec
+UPDATE books
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2;
+
+-- name: SetBookMissingSince :exec
+UPDATE books
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1;
+
+-- name: LockBook :one
+-- LockBook locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM books WHERE id = $1 FOR UPDATE;
\ No newline at end of file
@@ -0,0 +1,52 @@
+-- name: CreateBundle :one
+INSERT INTO bundles (id, created_at, updated_at, name, barcode, shelf_id)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetBundleByID :one
+SELECT * FROM bundles WHERE id = $1;
+
+-- name: GetBundlesByShelf :many
+SELECT * FROM bundles WHERE shelf_id = $1;
+
+-- name: GetBundlesByLocation :many
+SELECT bundles.* FROM bundles
+INNER JOIN shelves
+ON bundles.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = $1;
+
+-- name: GetBundlesByBarcodeForUser :many
+SELECT bundles.* FROM bundles
+INNER JOIN shelves
+ON bundles.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE bundles.barcode = $1
+AND location_user.user_id = $2;
+
+-- name: GetBundleLocation :one
+SELECT locations.id, locations.name
+FROM locations
+JOIN cases ON locations.id = cases.location_id
+JOIN shelves ON cases.id = shelves.case_id
+JOIN bundles ON shelves.id = bundles.shelf_id
+WHERE bundles.id = $1;
+
+-- name: UpdateBundleShelf :one
+UPDATE bundles
+SET updated_at = NOW(), shelf_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: LockBundle :one
+-- LockBundle locks the row until the transaction ends and returns it.
+SELECT * FROM bundles WHERE id = $1 FOR UPDATE;
+
+-- name: DeleteBundle :exec
+DELETE FROM bundles WHERE id = $1;
@@ -18,4 +18,17 @@ SELECT * FROM cases WHERE id = $1;
 SELECT locations.id, locations.name
 FROM locations
 JOIN cases ON locations.id = cases.location_id
-WHERE cases.id = $1;
\ No newline at end of file
+WHERE cases.id = $1;
+
+-- name: GetCaseByBarcode :one
+SELECT * FROM cases WHERE location_id = $1 AND barcode = $2;
+
+-- name: UpdateCase :one
+UPDATE cases
+SET updated_at = NOW(), name = $2, barcode = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: LockCase :one
+-- LockCase locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM cases WHERE id = $1 FOR UPDATE;
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- name: LockLocationChangeSequencer :exec
+-- LockLocationChangeSequencer makes transactions that give changes their cursors run one at a time.
+SELECT pg_advisory_xact_lock(hashtext('location_changes'));
+
+-- name: SequenceLocationChanges :exec
+-- SequenceLocationChanges gives cursors to the changes of transactions older than every
+-- transaction that's still running. Changes of transactions that rolled back are never seen.
+UPDATE location_changes
+SET seq = nextval('location_change_seq')
+WHERE id IN (
+    SELECT id FROM location_changes
+    WHERE seq IS NULL
+    AND txid < txid_snapshot_xmin(txid_current_snapshot())
+);
+
+-- name: GetLocationChanges :many
+-- GetLocationChanges returns the latest change to each entity at a location since the cursor, in
+-- cursor order.
+SELECT location_changes.* FROM location_changes
+WHERE location_changes.location_id = @location_id
+AND location_changes.seq > @since::bigint
+AND NOT EXISTS (
+    SELECT 1 FROM location_changes AS later
+    WHERE later.location_id = location_changes.location_id
+    AND later.entity_type = location_changes.entity_type
+    AND later.entity_id = location_changes.entity_id
+    AND later.id > location_changes.id
+)
+ORDER BY location_changes.seq
+LIMIT @row_limit;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- name: CreateCustomField :one
+INSERT INTO custom_fields (id, created_at, updated_at, location_id, item_type, name, field_type, options)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetCustomFieldByID :one
+SELECT * FROM custom_fields WHERE id = $1;
+
+-- name: GetCustomFieldsByLocation :many
+SELECT * FROM custom_fields WHERE location_id = $1
+ORDER BY item_type, name;
+
+-- name: GetCustomFieldsByLocationAndItemType :many
+SELECT * FROM custom_fields WHERE location_id = $1 AND item_type = $2
+ORDER BY name;
+
+-- name: DeleteCustomField :exec
+DELETE FROM custom_fields WHERE id = $1;
\ No newline at end of file
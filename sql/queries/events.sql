@@ -0,0 +1,3 @@
+-- name: NotifyLocationEvent :exec
+-- NotifyLocationEvent sends an event to every server listening on the location_events channel.
+SELECT pg_notify('location_events', @payload::text);
\ No newline at end of file
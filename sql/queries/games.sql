@@ -0,0 +1,118 @@
+-- name: CreateGame :one
+INSERT INTO games (id, created_at, updated_at, title, game_type, platform, publisher, developer, genre, min_players, max_players, play_time_minutes, edition, release_date, release_date_precision, barcode, shelf_id, custom_fields, thickness_cm, position)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $14)
+)
+RETURNING *;
+
+-- name: GetGamesForUser :many
+SELECT games.* FROM games
+INNER JOIN shelves
+ON games.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY games.title;
+
+-- name: GetGamesByShelf :many
+SELECT * FROM games WHERE shelf_id = @shelf_id
+AND custom_fields @> @custom_fields::jsonb
+ORDER BY position, title;
+
+-- name: GetGameByID :one
+SELECT * FROM games WHERE id = $1;
+
+-- name: GetGamesByBarcodeForUser :many
+SELECT games.* FROM games
+INNER JOIN shelves
+ON games.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE games.barcode = $1
+AND location_user.user_id = $2;
+
+-- name: GetGamesByLocation :many
+SELECT games.* FROM games
+INNER JOIN shelves
+ON games.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN locations
+ON cases.location_id = locations.id
+WHERE locations.id = @location_id
+AND games.custom_fields @> @custom_fields::jsonb;
+
+-- name: GetGamesByLocationAndDecade :many
+SELECT games.* FROM games
+INNER JOIN shelves
+ON games.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = @location_id
+AND release_date >= make_date(@decade::int, 1, 1)
+AND release_date < make_date(@decade::int + 10, 1, 1)
+AND games.custom_fields @> @custom_fields::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title;
+
+-- name: GetGameLocation :one
+SELECT locations.id, locations.name
+FROM locations
+JOIN cases ON locations.id = cases.location_id
+JOIN shelves ON cases.id = shelves.case_id
+JOIN games ON shelves.id = games.shelf_id
+WHERE games.id = $1;
+
+-- name: UpdateGame :one
+UPDATE games
+SET updated_at = NOW(), title = $2, game_type = $3, platform = $4, publisher = $5, developer = $6, genre = $7,
+    min_players = $8, max_players = $9, play_time_minutes = $10, edition = $11, release_date = $12,
+    release_date_precision = $13, barcode = $14, shelf_id = $15, custom_fields = $16, thickness_cm = $17,
+    position = CASE WHEN shelf_id = $15 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $15) END
+WHERE id = $1
+RETURNING *;
+
+-- name: GetGamesByBundle :many
+SELECT * FROM games WHERE bundle_id = $1
+ORDER BY position, title;
+
+-- name: AddGameToBundle :execrows
+-- AddGameToBundle doesn't take an item out of another bundle.
+UPDATE games
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2);
+
+-- name: RemoveGameFromBundle :execrows
+UPDATE games
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2;
+
+-- name: DeleteGame :exec
+DELETE FROM games WHERE id = $1;
+
+-- name: SetGamePosition :exec
+UPDATE games
+SET updated_at = NOW(), position = $2
+WHERE id = $1;
+
+-- name: MoveGame :exec
+UPDATE games
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2;
+
+-- name: SetGameMissingSince :exec
+UPDATE games
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1;
+
+-- name: LockGame :one
+-- LockGame locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM games WHERE id = $1 FOR UPDATE;
\ No newline at end of file
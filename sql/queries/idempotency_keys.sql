@@ -0,0 +1,30 @@
+-- name: ClaimIdempotencyKey :one
+-- ClaimIdempotencyKey records a key for a request that's about to be handled. A key that has
+-- expired, or whose request was abandoned before it was answered, is claimed again. Nothing is
+-- returned if the key is in use.
+INSERT INTO idempotency_keys (user_id, key, created_at, expires_at, request_hash)
+VALUES (@user_id, @key, NOW(), NOW() + (@window_seconds::bigint * INTERVAL '1 second'), @request_hash)
+ON CONFLICT (user_id, key) DO UPDATE
+SET created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, request_hash = EXCLUDED.request_hash,
+    status_code = NULL, content_type = '', response_body = NULL
+WHERE idempotency_keys.expires_at < NOW()
+OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < NOW() - INTERVAL '1 minute')
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: SaveIdempotentResponse :exec
+UPDATE idempotency_keys
+SET status_code = $3, content_type = $4, response_body = $5
+WHERE user_id = $1 AND key = $2;
+
+-- name: ReleaseIdempotencyKey :exec
+-- ReleaseIdempotencyKey frees a key whose request didn't create anything, so it can be retried.
+DELETE FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE expires_at < NOW();
\ No newline at end of file
@@ -1,31 +1,65 @@
 -- name: CreateMovie :one
-INSERT INTO movies (id, created_at, updated_at, title, genre, actors, writer, director, release_date, barcode, format, shelf_id)
+INSERT INTO movies (id, created_at, updated_at, title, genre, actors, writer, director, release_date, release_date_precision, barcode, format, shelf_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $10)
 )
 RETURNING *;
 
--- name: GetMovies :many
-SELECT * FROM movies;
+-- name: GetMoviesForUser :many
+SELECT movies.* FROM movies
+INNER JOIN shelves
+ON movies.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY movies.title;
 
 -- name: GetMoviesByShelf :many
-SELECT * FROM movies WHERE shelf_id = $1;
+SELECT * FROM movies WHERE shelf_id = @shelf_id
+AND custom_fields @> @custom_fields::jsonb
+ORDER BY position, title;
 
 -- name: GetMovieByID :one
 SELECT * FROM movies WHERE id = $1;
 
--- name: GetMovieByBarcode :one
-SELECT * FROM movies WHERE barcode = $1;
+-- name: GetMoviesByBarcodeForUser :many
+SELECT movies.* FROM movies
+INNER JOIN shelves
+ON movies.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE movies.barcode = $1
+AND location_user.user_id = $2;
 
 -- name: GetMoviesByLocation :many
-SELECT * FROM movies
+SELECT movies.* FROM movies
 INNER JOIN shelves
 ON movies.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
-WHERE locations.id = $1;
+WHERE locations.id = @location_id
+AND movies.custom_fields @> @custom_fields::jsonb;
+
+-- name: GetMoviesByLocationAndDecade :many
+SELECT movies.* FROM movies
+INNER JOIN shelves
+ON movies.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = @location_id
+AND release_date >= make_date(@decade::int, 1, 1)
+AND release_date < make_date(@decade::int + 10, 1, 1)
+AND movies.custom_fields @> @custom_fields::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title;
 
 -- name: GetMovieLocation :one
 SELECT locations.id, locations.name
@@ -35,26 +69,49 @@ JOIN shelves ON cases.id = shelves.case_id
 JOIN movies ON shelves.id = movies.shelf_id
 WHERE movies.id = $1;
 
--- name: SearchMovies :many
-SELECT movies.id, movies.created_at, movies.updated_at, title, genre, actors, writer, director, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM movies
-INNER JOIN shelves
-ON movies.shelf_id = shelves.id
-INNER JOIN cases
-ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC;
-
 -- name: UpdateMovie :one
 UPDATE movies
-SET updated_at = NOW(), title = $2, genre = $3, actors = $4, writer = $5, director = $6, release_date = $7, barcode = $8, format = $9, shelf_id = $10
+SET updated_at = NOW(), title = $2, genre = $3, actors = $4, writer = $5, director = $6, release_date = $7, release_date_precision = $8, barcode = $9, format = $10, shelf_id = $11,
+    runtime_minutes = $12, content_rating = $13, disc_region = $14, audio_languages = $15, subtitle_languages = $16, custom_fields = $17, thickness_cm = $18,
+    position = CASE WHEN shelf_id = $11 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $11) END
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetMoviesByBundle :many
+SELECT * FROM movies WHERE bundle_id = $1
+ORDER BY position, title;
+
+-- name: AddMovieToBundle :execrows
+-- AddMovieToBundle doesn't take an item out of another bundle.
+UPDATE movies
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2);
+
+-- name: RemoveMovieFromBundle :execrows
+UPDATE movies
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2;
+
+-- name: DeleteMovie :exec
+DELETE FROM movies WHERE id = $1;
+
+-- name: SetMoviePosition :exec
+UPDATE movies
+SET updated_at = NOW(), position = $2
+WHERE id = $1;
+
+-- name: MoveMovie :exec
+UPDATE movies
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2;
+
+-- name: SetMovieMissingSince :exec
+UPDATE movies
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1;
+
+-- name: LockMovie :one
+-- LockMovie locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM movies WHERE id = $1 FOR UPDATE;
\ No newline at end of file
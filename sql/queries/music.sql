@@ -1,31 +1,64 @@
 -- name: CreateMusic :one
-INSERT INTO music (id, created_at, updated_at, title, artist, genre, release_date, barcode, format, shelf_id)
+INSERT INTO music (id, created_at, updated_at, title, artist, genre, release_date, release_date_precision, barcode, format, shelf_id, label, catalog_number, disc_count, tracklist, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $8)
 ) RETURNING *;
 
--- name: GetMusic :many
-SELECT * FROM music;
+-- name: GetMusicForUser :many
+SELECT music.* FROM music
+INNER JOIN shelves
+ON music.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY music.title;
 
 -- name: GetMusicByShelf :many
-SELECT * FROM music WHERE shelf_id = $1;
+SELECT * FROM music WHERE shelf_id = @shelf_id
+AND custom_fields @> @custom_fields::jsonb
+ORDER BY position, title;
 
 -- name: GetMusicByID :one
 SELECT * FROM music WHERE id = $1;
 
--- name: GetMusicByBarcode :one
-SELECT * FROM music WHERE barcode = $1;
+-- name: GetMusicByBarcodeForUser :many
+SELECT music.* FROM music
+INNER JOIN shelves
+ON music.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE music.barcode = $1
+AND location_user.user_id = $2;
 
 -- name: GetMusicByLocation :many
-SELECT music.id, music.created_at, music.updated_at, title, artist, genre, release_date, barcode, format, shelf_id
-FROM music
+SELECT music.* FROM music
 INNER JOIN shelves
 ON music.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
-WHERE locations.id = $1;
+WHERE locations.id = @location_id
+AND music.custom_fields @> @custom_fields::jsonb;
+
+-- name: GetMusicByLocationAndDecade :many
+SELECT music.* FROM music
+INNER JOIN shelves
+ON music.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = @location_id
+AND release_date >= make_date(@decade::int, 1, 1)
+AND release_date < make_date(@decade::int + 10, 1, 1)
+AND music.custom_fields @> @custom_fields::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title;
 
 -- name: GetMusicLocation :one
 SELECT locations.id, locations.name
@@ -35,20 +68,50 @@ JOIN shelves ON cases.id = shelves.case_id
 JOIN music ON shelves.id = music.shelf_id
 WHERE music.id = $1;
 
--- name: SearchMusic :many
-SELECT music.id, music.created_at, music.updated_at, title, artist, genre, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM music
-INNER JOIN shelves
-ON music.shelf_id = shelves.id
-INNER JOIN cases
-ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC;
\ No newline at end of file
+-- name: GetMusicByBundle :many
+SELECT * FROM music WHERE bundle_id = $1
+ORDER BY position, title;
+
+-- name: AddMusicToBundle :execrows
+-- AddMusicToBundle doesn't take an item out of another bundle.
+UPDATE music
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2);
+
+-- name: RemoveMusicFromBundle :execrows
+UPDATE music
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2;
+
+-- name: UpdateMusic :one
+UPDATE music
+SET updated_at = NOW(), title = $2, artist = $3, genre = $4, release_date = $5, release_date_precision = $6,
+    barcode = $7, format = $8, shelf_id = $9, label = $10, catalog_number = $11, disc_count = $12,
+    tracklist = $13, custom_fields = $14, thickness_cm = $15,
+    position = CASE WHEN shelf_id = $9 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $9) END
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteMusic :exec
+DELETE FROM music WHERE id = $1;
+
+-- name: SetMusicPosition :exec
+UPDATE music
+SET updated_at = NOW(), position = $2
+WHERE id = $1;
+
+-- name: MoveMusic :exec
+UPDATE music
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2;
+
+-- name: SetMusicMissingSince :exec
+UPDATE music
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1;
+
+-- name: LockMusic :one
+-- LockMusic locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM music WHERE id = $1 FOR UPDATE;
\ No newline at end of file
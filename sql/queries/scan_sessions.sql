@@ -0,0 +1,53 @@
+-- name: CreateScanSession :one
+INSERT INTO scan_sessions (id, created_at, updated_at, location_id, started_by, shelf_id)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetScanSessionByID :one
+SELECT * FROM scan_sessions WHERE id = $1;
+
+-- name: SetScanSessionShelf :one
+UPDATE scan_sessions
+SET updated_at = NOW(), shelf_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CloseScanSession :one
+UPDATE scan_sessions
+SET updated_at = NOW(), closed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateScanDraft :one
+INSERT INTO scan_drafts (id, created_at, session_id, shelf_id, barcode, item_type, title, creator, genre, release_date, source)
+VALUES (
+    gen_random_uuid(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetScanDraftsBySession :many
+SELECT * FROM scan_drafts WHERE session_id = $1
+ORDER BY created_at;
+
+-- name: GetScanDraftLocation :one
+SELECT scan_sessions.location_id
+FROM scan_drafts
+JOIN scan_sessions ON scan_drafts.session_id = scan_sessions.id
+WHERE scan_drafts.id = $1;
+
+-- name: DeleteScanDraft :exec
+DELETE FROM scan_drafts WHERE id = $1;
+
+-- name: GetLocationItemsByBarcode :many
+SELECT * FROM location_items
+WHERE location_id = $1 AND barcode = $2
+ORDER BY created_at, id;
+
+-- name: GetMemberItemByBarcode :one
+SELECT location_items.* FROM location_items
+JOIN location_user ON location_items.location_id = location_user.location_id
+WHERE location_user.user_id = $1 AND location_items.barcode = $2
+ORDER BY location_items.updated_at DESC
+LIMIT 1;
\ No newline at end of file
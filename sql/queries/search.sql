@@ -0,0 +1,84 @@
+-- name: GetLocationAutocomplete :many
+-- Titles that start with the text come first, then titles with a word that starts with it,
+-- then titles that are only similar to it.
+SELECT item_type, id, title, creator FROM location_items
+WHERE location_id = @location_id
+AND (starts_with(lower(title), lower(@query::text))
+    OR position(' ' || lower(@query::text) IN lower(title)) > 0
+    OR word_similarity(@query::text, title) >= 0.4)
+ORDER BY starts_with(lower(title), lower(@query::text)) DESC,
+    position(' ' || lower(@query::text) IN lower(title)) > 0 DESC,
+    word_similarity(@query::text, title) DESC,
+    title
+LIMIT @max_results::int;
+
+-- name: SearchLocationItems :many
+-- Searches every media type at a location in the same way as each type's own search. The
+-- headlines are the fields with the words that matched between <mark> and </mark>, and are
+-- the field unchanged if nothing in it matched.
+-- An empty query matches every item, for smart shelves that only filter.
+SELECT item_type, id, title, creator, genre, format, release_date, release_date_precision,
+    shelf_id, shelf_name, case_id, case_name, location_id,
+    (ts_rank(search, websearch_to_tsquery('english', @query::text))
+        + ts_rank(search, websearch_to_tsquery('simple', @query::text))
+        + ts_rank(search, to_tsquery('simple', @prefix_query::text))
+        + GREATEST(word_similarity(@query::text, title), word_similarity(@query::text, creator)))::real AS rank,
+    ts_headline('simple', title, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS title_headline,
+    ts_headline('simple', creator, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS creator_headline,
+    ts_headline('simple', genre, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS genre_headline,
+    ts_headline('simple', details, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'MaxWords=12, MinWords=4, StartSel=<mark>, StopSel=</mark>')::text AS details_headline
+FROM search_items
+WHERE location_id = @location_id
+AND (@query::text = ''
+    OR search @@ websearch_to_tsquery('english', @query::text)
+    OR search @@ websearch_to_tsquery('simple', @query::text)
+    OR search @@ to_tsquery('simple', @prefix_query::text)
+    OR word_similarity(@query::text, title) >= 0.4
+    OR word_similarity(@query::text, creator) >= 0.4)
+ORDER BY rank DESC, title;
+
+-- name: SearchUserItems :many
+-- Searches every media type at all of the user's locations, in the same way as SearchLocationItems.
+SELECT item_type, id, title, creator, genre, format, release_date, release_date_precision,
+    shelf_id, shelf_name, case_id, case_name, search_items.location_id,
+    (ts_rank(search, websearch_to_tsquery('english', @query::text))
+        + ts_rank(search, websearch_to_tsquery('simple', @query::text))
+        + ts_rank(search, to_tsquery('simple', @prefix_query::text))
+        + GREATEST(word_similarity(@query::text, title), word_similarity(@query::text, creator)))::real AS rank,
+    ts_headline('simple', title, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS title_headline,
+    ts_headline('simple', creator, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS creator_headline,
+    ts_headline('simple', genre, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'HighlightAll=true, StartSel=<mark>, StopSel=</mark>')::text AS genre_headline,
+    ts_headline('simple', details, websearch_to_tsquery('simple', @query::text) || to_tsquery('simple', @prefix_query::text),
+        'MaxWords=12, MinWords=4, StartSel=<mark>, StopSel=</mark>')::text AS details_headline
+FROM search_items
+INNER JOIN location_user ON search_items.location_id = location_user.location_id
+WHERE location_user.user_id = @user_id
+AND (search @@ websearch_to_tsquery('english', @query::text)
+    OR search @@ websearch_to_tsquery('simple', @query::text)
+    OR search @@ to_tsquery('simple', @prefix_query::text)
+    OR word_similarity(@query::text, title) >= 0.4
+    OR word_similarity(@query::text, creator) >= 0.4)
+ORDER BY rank DESC, title;
+
+-- name: GetMemberItemsByBarcode :many
+-- Items of every media type with the barcode, at the locations the user is a member of.
+SELECT location_items.item_type, location_items.id, location_items.title, location_items.creator,
+    location_items.format, location_items.barcode, location_items.missing_since,
+    locations.id AS location_id, locations.name AS location_name,
+    cases.id AS case_id, cases.name AS case_name,
+    shelves.id AS shelf_id, shelves.name AS shelf_name
+FROM location_items
+INNER JOIN location_user ON location_items.location_id = location_user.location_id
+INNER JOIN shelves ON location_items.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+INNER JOIN locations ON cases.location_id = locations.id
+WHERE location_user.user_id = @user_id
+AND location_items.barcode = @barcode
+ORDER BY locations.name, cases.name, shelves.name, location_items.title;
\ No newline at end of file
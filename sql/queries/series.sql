@@ -0,0 +1,84 @@
+-- name: CreateSeries :one
+INSERT INTO series (id, created_at, updated_at, title, location_id)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2
+)
+RETURNING *;
+
+-- name: GetSeriesByID :one
+SELECT * FROM series WHERE id = $1;
+
+-- name: GetSeriesByLocation :many
+SELECT * FROM series WHERE location_id = $1
+ORDER BY title;
+
+-- name: CreateEpisode :one
+INSERT INTO episodes (id, created_at, updated_at, series_id, season_number, episode_number, title, show_id, disc_number)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetEpisodeByID :one
+SELECT * FROM episodes WHERE id = $1;
+
+-- name: GetEpisodeLocation :one
+SELECT locations.id, locations.name
+FROM locations
+JOIN series ON locations.id = series.location_id
+JOIN episodes ON series.id = episodes.series_id
+WHERE episodes.id = $1;
+
+-- name: GetEpisodesBySeriesForUser :many
+SELECT episodes.*, episode_watches.watched_at
+FROM episodes
+LEFT JOIN episode_watches
+ON episodes.id = episode_watches.episode_id
+AND episode_watches.user_id = @user_id
+WHERE episodes.series_id = @series_id
+ORDER BY episodes.season_number, episodes.episode_number;
+
+-- name: GetEpisodesByShowForUser :many
+SELECT episodes.*, episode_watches.watched_at
+FROM episodes
+LEFT JOIN episode_watches
+ON episodes.id = episode_watches.episode_id
+AND episode_watches.user_id = @user_id
+WHERE episodes.show_id = @show_id
+ORDER BY episodes.season_number, episodes.episode_number;
+
+-- name: MarkEpisodeWatched :exec
+INSERT INTO episode_watches (user_id, episode_id, watched_at)
+VALUES (
+    $1, $2, NOW()
+)
+ON CONFLICT (user_id, episode_id) DO NOTHING;
+
+-- name: UnmarkEpisodeWatched :exec
+DELETE FROM episode_watches
+WHERE user_id = $1 AND episode_id = $2;
+
+-- name: GetNextEpisodesForUser :many
+SELECT DISTINCT ON (episodes.series_id) episodes.*, series.title AS series_title, shelves.id AS shelf_id, shelves.name AS shelf_name
+FROM episodes
+INNER JOIN series
+ON episodes.series_id = series.id
+INNER JOIN location_user
+ON series.location_id = location_user.location_id
+AND location_user.user_id = @user_id
+LEFT JOIN shows
+ON episodes.show_id = shows.id
+LEFT JOIN shelves
+ON shows.shelf_id = shelves.id
+WHERE episodes.series_id IN (
+    SELECT watched.series_id FROM episodes AS watched
+    INNER JOIN episode_watches
+    ON watched.id = episode_watches.episode_id
+    WHERE episode_watches.user_id = @user_id
+)
+AND NOT EXISTS (
+    SELECT 1 FROM episode_watches
+    WHERE episode_watches.episode_id = episodes.id
+    AND episode_watches.user_id = @user_id
+)
+ORDER BY episodes.series_id, episodes.season_number, episodes.episode_number;
\ No newline at end of file
@@ -1,7 +1,7 @@
 -- name: CreateShelf :one
-INSERT INTO shelves (id, created_at, updated_at, name, case_id)
+INSERT INTO shelves (id, created_at, updated_at, name, case_id, capacity, width_cm)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4
 )
 RETURNING *;
 
@@ -11,12 +11,54 @@ SELECT * FROM shelves;
 -- name: GetShelvesByCase :many
 SELECT * FROM shelves WHERE case_id = $1;
 
+-- name: GetShelvesByCaseWithFullness :many
+SELECT shelves.*, COUNT(location_items.id) AS item_count,
+    COALESCE(SUM(location_items.thickness_cm), 0)::float8 AS used_width_cm,
+    COUNT(location_items.id) FILTER (WHERE location_items.thickness_cm = 0) AS unmeasured_items
+FROM shelves
+LEFT JOIN location_items ON location_items.shelf_id = shelves.id
+WHERE shelves.case_id = $1
+GROUP BY shelves.id;
+
+-- name: GetShelvesByLocation :many
+SELECT shelves.* FROM shelves
+JOIN cases ON shelves.case_id = cases.id
+WHERE cases.location_id = $1
+ORDER BY shelves.name;
+
 -- name: GetShelfByID :one
 SELECT * FROM shelves WHERE id = $1;
 
+-- name: GetShelfByBarcode :one
+SELECT shelves.* FROM shelves
+JOIN cases ON shelves.case_id = cases.id
+WHERE cases.location_id = $1 AND shelves.barcode = $2;
+
 -- name: GetShelfLocation :one
 SELECT locations.id, locations.name
 FROM locations
 JOIN cases ON locations.id = cases.location_id
 JOIN shelves ON cases.id = shelves.case_id
-WHERE shelves.id = $1;
\ No newline at end of file
+WHERE shelves.id = $1;
+
+-- name: UpdateShelf :one
+UPDATE shelves
+SET updated_at = NOW(), name = $2, capacity = $3, width_cm = $4, barcode = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: GetShelfItems :many
+SELECT * FROM location_items WHERE shelf_id = $1
+ORDER BY position, title;
+
+-- name: GetShelfItemsByTitle :many
+SELECT * FROM location_items WHERE shelf_id = $1
+ORDER BY lower(title), title;
+
+-- name: GetShelfItemsByReleaseDate :many
+SELECT * FROM location_items WHERE shelf_id = $1
+ORDER BY release_date NULLS LAST, lower(title), title;
+
+-- name: LockShelf :one
+-- LockShelf locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM shelves WHERE id = $1 FOR UPDATE;
\ No newline at end of file
@@ -1,30 +1,64 @@
 -- name: CreateShow :one
-INSERT INTO shows (id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, barcode, format, shelf_id)
+INSERT INTO shows (id, created_at, updated_at, title, season, genre, actors, writer, director, release_date, release_date_precision, barcode, format, shelf_id, runtime_minutes, content_rating, disc_region, audio_languages, subtitle_languages, custom_fields, thickness_cm, position)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+    (SELECT COALESCE(MAX(position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $11)
 ) RETURNING *;
 
--- name: GetShows :many
-SELECT * FROM shows;
+-- name: GetShowsForUser :many
+SELECT shows.* FROM shows
+INNER JOIN shelves
+ON shows.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+ORDER BY shows.title;
 
 -- name: GetShowsByShelf :many
-SELECT * FROM shows WHERE shelf_id = $1;
+SELECT * FROM shows WHERE shelf_id = @shelf_id
+AND custom_fields @> @custom_fields::jsonb
+ORDER BY position, title;
 
 -- name: GetShowByID :one
 SELECT * FROM shows WHERE id = $1;
 
--- name: GetShowByBarcode :one
-SELECT * FROM shows WHERE barcode = $1;
+-- name: GetShowsByBarcodeForUser :many
+SELECT shows.* FROM shows
+INNER JOIN shelves
+ON shows.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+INNER JOIN location_user
+ON cases.location_id = location_user.location_id
+WHERE shows.barcode = $1
+AND location_user.user_id = $2;
 
 -- name: GetShowsByLocation :many
-SELECT * FROM shows
+SELECT shows.* FROM shows
 INNER JOIN shelves
 ON shows.shelf_id = shelves.id
 INNER JOIN cases
 ON shelves.case_id = cases.id
 INNER JOIN locations
 ON cases.location_id = locations.id
-WHERE locations.id = $1;
+WHERE locations.id = @location_id
+AND shows.custom_fields @> @custom_fields::jsonb;
+
+-- name: GetShowsByLocationAndDecade :many
+SELECT shows.* FROM shows
+INNER JOIN shelves
+ON shows.shelf_id = shelves.id
+INNER JOIN cases
+ON shelves.case_id = cases.id
+WHERE cases.location_id = @location_id
+AND release_date >= make_date(@decade::int, 1, 1)
+AND release_date < make_date(@decade::int + 10, 1, 1)
+AND shows.custom_fields @> @custom_fields::jsonb
+ORDER BY release_date,
+    CASE release_date_precision WHEN 'year' THEN 0 WHEN 'month' THEN 1 ELSE 2 END,
+    title;
 
 -- name: GetShowLocation :one
 SELECT locations.id, locations.name
@@ -34,20 +68,51 @@ JOIN shelves ON cases.id = shelves.case_id
 JOIN shows ON shelves.id = shows.shelf_id
 WHERE shows.id = $1;
 
--- name: SearchShows :many
-SELECT shows.id, shows.created_at, shows.updated_at, title, season, genre, actors, writer, director, release_date, barcode, format, shelf_id,
-    CAST(
-        ts_rank(search, websearch_to_tsquery('english', $1)) + 
-        ts_rank(search, websearch_to_tsquery('simple', $1)) AS float8
-    ) AS rank
-FROM shows
-INNER JOIN shelves
-ON shows.shelf_id = shelves.id
-INNER JOIN cases
-ON shelves.case_id = cases.id
-INNER JOIN locations
-ON cases.location_id = locations.id
-WHERE search @@ websearch_to_tsquery('english', $1)
-OR search @@ websearch_to_tsquery('simple', $1)
-AND locations.id = $2
-ORDER BY rank DESC;
\ No newline at end of file
+-- name: GetShowsByBundle :many
+SELECT * FROM shows WHERE bundle_id = $1
+ORDER BY position, title;
+
+-- name: AddShowToBundle :execrows
+-- AddShowToBundle doesn't take an item out of another bundle.
+UPDATE shows
+SET updated_at = NOW(), bundle_id = $2
+WHERE id = $1 AND (bundle_id IS NULL OR bundle_id = $2);
+
+-- name: RemoveShowFromBundle :execrows
+UPDATE shows
+SET updated_at = NOW(), bundle_id = NULL
+WHERE id = $1 AND bundle_id = $2;
+
+-- name: UpdateShow :one
+UPDATE shows
+SET updated_at = NOW(), title = $2, season = $3, genre = $4, actors = $5, writer = $6, director = $7,
+    release_date = $8, release_date_precision = $9, barcode = $10, format = $11, shelf_id = $12,
+    runtime_minutes = $13, content_rating = $14, disc_region = $15, audio_languages = $16,
+    subtitle_languages = $17, custom_fields = $18, thickness_cm = $19,
+    position = CASE WHEN shelf_id = $12 THEN position
+        ELSE (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $12) END
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteShow :exec
+DELETE FROM shows WHERE id = $1;
+
+-- name: SetShowPosition :exec
+UPDATE shows
+SET updated_at = NOW(), position = $2
+WHERE id = $1;
+
+-- name: MoveShow :exec
+UPDATE shows
+SET updated_at = NOW(), shelf_id = $2,
+    position = (SELECT COALESCE(MAX(location_items.position) + 1, 0) FROM location_items WHERE location_items.shelf_id = $2)
+WHERE id = $1 AND shelf_id <> $2;
+
+-- name: SetShowMissingSince :exec
+UPDATE shows
+SET updated_at = NOW(), missing_since = $2
+WHERE id = $1;
+
+-- name: LockShow :one
+-- LockShow locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM shows WHERE id = $1 FOR UPDATE;
\ No newline at end of file
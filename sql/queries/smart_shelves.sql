@@ -0,0 +1,34 @@
+-- name: CreateSmartShelf :one
+INSERT INTO smart_shelves (id, created_at, updated_at, location_id, name, query, filters, pinned)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetSmartShelfByID :one
+SELECT * FROM smart_shelves WHERE id = $1;
+
+-- name: GetSmartShelvesByLocation :many
+SELECT * FROM smart_shelves WHERE location_id = $1
+ORDER BY name;
+
+-- name: GetPinnedSmartShelvesForUser :many
+SELECT smart_shelves.* FROM smart_shelves
+INNER JOIN location_user
+ON smart_shelves.location_id = location_user.location_id
+WHERE location_user.user_id = $1
+AND smart_shelves.pinned
+ORDER BY smart_shelves.name;
+
+-- name: UpdateSmartShelf :one
+UPDATE smart_shelves
+SET updated_at = NOW(), name = $2, query = $3, filters = $4, pinned = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSmartShelf :exec
+DELETE FROM smart_shelves WHERE id = $1;
+
+-- name: LockSmartShelf :one
+-- LockSmartShelf locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM smart_shelves WHERE id = $1 FOR UPDATE;
\ No newline at end of file
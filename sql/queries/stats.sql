@@ -0,0 +1,55 @@
+-- name: GetLocationItemCountsByType :many
+SELECT item_type, COUNT(*) AS count FROM location_items
+WHERE location_id = $1
+GROUP BY item_type
+ORDER BY item_type;
+
+-- name: GetLocationItemCountsByFormat :many
+SELECT item_type, format, COUNT(*) AS count FROM location_items
+WHERE location_id = $1 AND format <> ''
+GROUP BY item_type, format
+ORDER BY item_type, count DESC, format;
+
+-- name: GetLocationItemCountsByGenre :many
+SELECT item_type, genre, COUNT(*) AS count FROM location_items
+WHERE location_id = $1 AND genre <> ''
+GROUP BY item_type, genre
+ORDER BY item_type, count DESC, genre;
+
+-- name: GetLocationItemCountsByDecade :many
+SELECT (EXTRACT(YEAR FROM release_date)::int / 10 * 10)::int AS decade, COUNT(*) AS count FROM location_items
+WHERE location_id = $1 AND release_date IS NOT NULL
+GROUP BY decade
+ORDER BY decade;
+
+-- name: GetLocationItemCountsByCase :many
+SELECT cases.id, cases.name, COUNT(location_items.id) AS count FROM cases
+LEFT JOIN location_items ON location_items.case_id = cases.id
+WHERE cases.location_id = $1
+GROUP BY cases.id, cases.name
+ORDER BY cases.name;
+
+-- name: GetLocationItemCountsByShelf :many
+SELECT shelves.id, shelves.name, shelves.case_id, COUNT(location_items.id) AS count FROM shelves
+INNER JOIN cases ON shelves.case_id = cases.id
+LEFT JOIN location_items ON location_items.shelf_id = shelves.id
+WHERE cases.location_id = $1
+GROUP BY shelves.id, shelves.name, shelves.case_id
+ORDER BY shelves.name;
+
+-- name: GetLocationItemsAddedByMonth :many
+SELECT date_trunc('month', created_at)::timestamp AS month, COUNT(*) AS count FROM location_items
+WHERE location_id = $1
+GROUP BY month
+ORDER BY month;
+
+-- name: GetLocationTopCreators :many
+SELECT item_type, creator, item_count::bigint AS count FROM (
+    SELECT item_type, creator, COUNT(*) AS item_count,
+        ROW_NUMBER() OVER (PARTITION BY item_type ORDER BY COUNT(*) DESC, creator) AS rank
+    FROM location_items
+    WHERE location_id = @location_id AND creator <> ''
+    GROUP BY item_type, creator
+) AS ranked
+WHERE rank <= @top_limit::int
+ORDER BY item_type, count DESC, creator;
\ No newline at end of file
@@ -19,5 +19,9 @@ UPDATE users SET email = $2, hashed_password = $3, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: LockUser :one
+-- LockUser locks the row until the transaction ends and returns when it was last updated.
+SELECT updated_at FROM users WHERE id = $1 FOR UPDATE;
+
 -- name: ResetUsers :exec
 DELETE FROM users;
\ No newline at end of file
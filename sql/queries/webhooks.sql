@@ -0,0 +1,74 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (id, created_at, updated_at, location_id, url, secret, event_types, active)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: GetWebhooksByLocation :many
+SELECT * FROM webhooks WHERE location_id = $1
+ORDER BY created_at;
+
+-- name: GetActiveWebhooksByLocation :many
+SELECT * FROM webhooks WHERE location_id = $1 AND active;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks
+SET updated_at = NOW(), url = $2, secret = $3, event_types = $4, active = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: LockWebhook :one
+-- LockWebhook locks the row until the transaction ends and returns it.
+SELECT * FROM webhooks WHERE id = $1 FOR UPDATE;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE id = $1;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (id, created_at, updated_at, webhook_id, event_type, payload, next_attempt_at)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, NOW()
+)
+RETURNING *;
+
+-- name: CreateClaimedWebhookDelivery :one
+-- CreateClaimedWebhookDelivery creates a delivery that's about to be sent, claimed like
+-- ClaimDueWebhookDeliveries does, so that the background worker doesn't send it too.
+INSERT INTO webhook_deliveries (id, created_at, updated_at, webhook_id, event_type, payload, next_attempt_at)
+VALUES (
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, NOW() + INTERVAL '5 minutes'
+)
+RETURNING *;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Pending deliveries that are due are put back by a few minutes while they're sent, so that
+-- they aren't sent twice if more than one server is running, and are tried again if the server
+-- stops while sending them.
+UPDATE webhook_deliveries
+SET next_attempt_at = NOW() + INTERVAL '5 minutes'
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordWebhookDeliveryAttempt :one
+-- retry_after_seconds is how long to wait before trying again, if the delivery is still pending.
+UPDATE webhook_deliveries
+SET updated_at = NOW(), last_attempt_at = NOW(), attempts = attempts + 1,
+    status = @status, next_attempt_at = NOW() + @retry_after_seconds::int * INTERVAL '1 second',
+    response_status = @response_status, last_error = @last_error
+WHERE id = @id
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- +goose Up
+ALTER TABLE movies
+ALTER COLUMN release_date DROP NOT NULL,
+ADD COLUMN release_date_precision TEXT NOT NULL DEFAULT 'day'
+    CHECK (release_date_precision IN ('unknown', 'year', 'month', 'day'));
+
+ALTER TABLE shows
+ALTER COLUMN release_date DROP NOT NULL,
+ADD COLUMN release_date_precision TEXT NOT NULL DEFAULT 'day'
+    CHECK (release_date_precision IN ('unknown', 'year', 'month', 'day'));
+
+ALTER TABLE music
+ALTER COLUMN release_date DROP NOT NULL,
+ADD COLUMN release_date_precision TEXT NOT NULL DEFAULT 'day'
+    CHECK (release_date_precision IN ('unknown', 'year', 'month', 'day'));
+
+ALTER TABLE books
+ALTER COLUMN publication_date DROP NOT NULL,
+ADD COLUMN publication_date_precision TEXT NOT NULL DEFAULT 'day'
+    CHECK (publication_date_precision IN ('unknown', 'year', 'month', 'day'));
+
+-- +goose Down
+ALTER TABLE movies
+DROP COLUMN release_date_precision;
+
+ALTER TABLE shows
+DROP COLUMN release_date_precision;
+
+ALTER TABLE music
+DROP COLUMN release_date_precision;
+
+ALTER TABLE books
+DROP COLUMN publication_date_precision;
+-- This migration does not restore the NOT NULL constraint on the date columns, as items with unknown dates would otherwise be lost.
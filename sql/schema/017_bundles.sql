@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE bundles (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        name TEXT NOT NULL,
+                        barcode TEXT NOT NULL,
+                        shelf_id UUID NOT NULL REFERENCES shelves(id) ON DELETE CASCADE);
+
+CREATE INDEX idx_bundles_barcode ON bundles(barcode);
+
+ALTER TABLE movies
+ADD COLUMN bundle_id UUID REFERENCES bundles(id) ON DELETE SET NULL;
+
+ALTER TABLE shows
+ADD COLUMN bundle_id UUID REFERENCES bundles(id) ON DELETE SET NULL;
+
+ALTER TABLE books
+ADD COLUMN bundle_id UUID REFERENCES bundles(id) ON DELETE SET NULL;
+
+ALTER TABLE music
+ADD COLUMN bundle_id UUID REFERENCES bundles(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE movies
+DROP COLUMN bundle_id;
+
+ALTER TABLE shows
+DROP COLUMN bundle_id;
+
+ALTER TABLE books
+DROP COLUMN bundle_id;
+
+ALTER TABLE music
+DROP COLUMN bundle_id;
+
+DROP INDEX idx_bundles_barcode;
+DROP TABLE bundles;
@@ -0,0 +1,180 @@
+-- +goose Up
+ALTER TABLE movies
+ADD COLUMN runtime_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN content_rating TEXT NOT NULL DEFAULT '',
+ADD COLUMN disc_region TEXT NOT NULL DEFAULT '',
+ADD COLUMN audio_languages TEXT NOT NULL DEFAULT '',
+ADD COLUMN subtitle_languages TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE shows
+ADD COLUMN runtime_minutes INT NOT NULL DEFAULT 0,
+ADD COLUMN content_rating TEXT NOT NULL DEFAULT '',
+ADD COLUMN disc_region TEXT NOT NULL DEFAULT '',
+ADD COLUMN audio_languages TEXT NOT NULL DEFAULT '',
+ADD COLUMN subtitle_languages TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE books
+ADD COLUMN isbn TEXT NOT NULL DEFAULT '',
+ADD COLUMN publisher TEXT NOT NULL DEFAULT '',
+ADD COLUMN page_count INT NOT NULL DEFAULT 0,
+ADD COLUMN edition TEXT NOT NULL DEFAULT '',
+ADD COLUMN language TEXT NOT NULL DEFAULT '',
+ADD COLUMN series TEXT NOT NULL DEFAULT '',
+ADD COLUMN series_number TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE music
+ADD COLUMN label TEXT NOT NULL DEFAULT '',
+ADD COLUMN catalog_number TEXT NOT NULL DEFAULT '',
+ADD COLUMN disc_count INT NOT NULL DEFAULT 1,
+ADD COLUMN tracklist JSONB NOT NULL DEFAULT '[]';
+
+-- The expression of a generated column can't be altered, so each search column is rebuilt.
+DROP INDEX idx_movies_search;
+ALTER TABLE movies
+DROP COLUMN search;
+ALTER TABLE movies
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', actors), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', writer), 'D') || ' ' ||
+    setweight(to_tsvector('simple', director), 'D') || ' ' ||
+    setweight(to_tsvector('simple', content_rating), 'D') || ' ' ||
+    setweight(to_tsvector('simple', audio_languages), 'D') || ' ' ||
+    setweight(to_tsvector('simple', subtitle_languages), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_movies_search ON movies USING GIN(search);
+
+DROP INDEX idx_shows_search;
+ALTER TABLE shows
+DROP COLUMN search;
+ALTER TABLE shows
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', actors), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', writer), 'D') || ' ' ||
+    setweight(to_tsvector('simple', director), 'D') || ' ' ||
+    setweight(to_tsvector('simple', content_rating), 'D') || ' ' ||
+    setweight(to_tsvector('simple', audio_languages), 'D') || ' ' ||
+    setweight(to_tsvector('simple', subtitle_languages), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_shows_search ON shows USING GIN(search);
+
+DROP INDEX idx_books_search;
+ALTER TABLE books
+DROP COLUMN search;
+ALTER TABLE books
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', isbn), 'A') || ' ' ||
+    setweight(to_tsvector('simple', author), 'B') || ' ' ||
+    setweight(to_tsvector('english', series), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', publisher), 'D') || ' ' ||
+    setweight(to_tsvector('simple', edition), 'D') || ' ' ||
+    setweight(to_tsvector('simple', language), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_books_search ON books USING GIN(search);
+
+DROP INDEX idx_music_search;
+ALTER TABLE music
+DROP COLUMN search;
+ALTER TABLE music
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', artist), 'B') || ' ' ||
+    setweight(jsonb_to_tsvector('english', tracklist, '["string"]'), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('english', format), 'D') || ' ' ||
+    setweight(to_tsvector('simple', label), 'D') || ' ' ||
+    setweight(to_tsvector('simple', catalog_number), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_music_search ON music USING GIN(search);
+
+-- +goose Down
+DROP INDEX idx_movies_search;
+ALTER TABLE movies
+DROP COLUMN search;
+ALTER TABLE movies
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', actors), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', writer), 'D') || ' ' ||
+    setweight(to_tsvector('simple', director), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_movies_search ON movies USING GIN(search);
+
+DROP INDEX idx_shows_search;
+ALTER TABLE shows
+DROP COLUMN search;
+ALTER TABLE shows
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', actors), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', writer), 'D') || ' ' ||
+    setweight(to_tsvector('simple', director), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_shows_search ON shows USING GIN(search);
+
+DROP INDEX idx_books_search;
+ALTER TABLE books
+DROP COLUMN search;
+ALTER TABLE books
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', author), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') :: tsvector
+) STORED;
+CREATE INDEX idx_books_search ON books USING GIN(search);
+
+DROP INDEX idx_music_search;
+ALTER TABLE music
+DROP COLUMN search;
+ALTER TABLE music
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', artist), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('english', format), 'D') :: tsvector
+) STORED;
+CREATE INDEX idx_music_search ON music USING GIN(search);
+
+ALTER TABLE movies
+DROP COLUMN runtime_minutes,
+DROP COLUMN content_rating,
+DROP COLUMN disc_region,
+DROP COLUMN audio_languages,
+DROP COLUMN subtitle_languages;
+
+ALTER TABLE shows
+DROP COLUMN runtime_minutes,
+DROP COLUMN content_rating,
+DROP COLUMN disc_region,
+DROP COLUMN audio_languages,
+DROP COLUMN subtitle_languages;
+
+ALTER TABLE books
+DROP COLUMN isbn,
+DROP COLUMN publisher,
+DROP COLUMN page_count,
+DROP COLUMN edition,
+DROP COLUMN language,
+DROP COLUMN series,
+DROP COLUMN series_number;
+
+ALTER TABLE music
+DROP COLUMN label,
+DROP COLUMN catalog_number,
+DROP COLUMN disc_count,
+DROP COLUMN tracklist;
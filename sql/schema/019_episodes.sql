@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE series (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        title TEXT NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE);
+
+CREATE TABLE episodes (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        series_id UUID NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+                        season_number INT NOT NULL,
+                        episode_number INT NOT NULL,
+                        title TEXT NOT NULL,
+                        show_id UUID REFERENCES shows(id) ON DELETE SET NULL,
+                        disc_number INT NOT NULL DEFAULT 1,
+                        UNIQUE(series_id, season_number, episode_number));
+
+CREATE INDEX idx_episodes_show_id ON episodes(show_id);
+
+CREATE TABLE episode_watches (user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        episode_id UUID NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+                        watched_at TIMESTAMP NOT NULL,
+                        UNIQUE(user_id, episode_id));
+
+-- +goose Down
+DROP TABLE episode_watches;
+DROP INDEX idx_episodes_show_id;
+DROP TABLE episodes;
+DROP TABLE series;
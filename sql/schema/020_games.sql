@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE games (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        title TEXT NOT NULL,
+                        game_type TEXT NOT NULL DEFAULT 'video' CHECK (game_type IN ('video', 'board')),
+                        platform TEXT NOT NULL,
+                        publisher TEXT NOT NULL,
+                        developer TEXT NOT NULL,
+                        genre TEXT NOT NULL,
+                        min_players INT NOT NULL DEFAULT 1,
+                        max_players INT NOT NULL DEFAULT 1,
+                        play_time_minutes INT NOT NULL DEFAULT 0,
+                        edition TEXT NOT NULL,
+                        release_date DATE,
+                        release_date_precision TEXT NOT NULL DEFAULT 'day' CHECK (release_date_precision IN ('unknown', 'year', 'month', 'day')),
+                        barcode TEXT NOT NULL,
+                        shelf_id UUID NOT NULL REFERENCES shelves(id) ON DELETE CASCADE);
+
+ALTER TABLE games
+ADD search tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') || ' ' ||
+    setweight(to_tsvector('simple', platform), 'B') || ' ' ||
+    setweight(to_tsvector('simple', developer), 'B') || ' ' ||
+    setweight(to_tsvector('english', genre), 'C') || ' ' ||
+    setweight(to_tsvector('simple', publisher), 'D') || ' ' ||
+    setweight(to_tsvector('simple', edition), 'D') :: tsvector
+) STORED;
+
+CREATE INDEX idx_games_search ON games USING GIN(search);
+
+-- +goose Down
+DROP INDEX idx_games_search;
+DROP TABLE games;
@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE custom_fields (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        item_type TEXT NOT NULL,
+                        name TEXT NOT NULL,
+                        field_type TEXT NOT NULL CHECK (field_type IN ('text', 'number', 'bool', 'date', 'enum')),
+                        options TEXT[] NOT NULL DEFAULT '{}',
+                        UNIQUE(location_id, item_type, name));
+
+ALTER TABLE movies
+ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE shows
+ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE books
+ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE music
+ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE games
+ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_movies_custom_fields ON movies USING GIN(custom_fields);
+CREATE INDEX idx_shows_custom_fields ON shows USING GIN(custom_fields);
+CREATE INDEX idx_books_custom_fields ON books USING GIN(custom_fields);
+CREATE INDEX idx_music_custom_fields ON music USING GIN(custom_fields);
+CREATE INDEX idx_games_custom_fields ON games USING GIN(custom_fields);
+
+-- +goose Down
+DROP INDEX idx_games_custom_fields;
+DROP INDEX idx_music_custom_fields;
+DROP INDEX idx_books_custom_fields;
+DROP INDEX idx_shows_custom_fields;
+DROP INDEX idx_movies_custom_fields;
+
+ALTER TABLE games
+DROP COLUMN custom_fields;
+ALTER TABLE music
+DROP COLUMN custom_fields;
+ALTER TABLE books
+DROP COLUMN custom_fields;
+ALTER TABLE shows
+DROP COLUMN custom_fields;
+ALTER TABLE movies
+DROP COLUMN custom_fields;
+
+DROP TABLE custom_fields;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- location_items lists every item of every media type with the case and location that
+-- hold it, so that queries across the whole collection don't need a UNION each.
+-- creator is the director, author, artist or developer of the item.
+CREATE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+-- +goose Down
+DROP VIEW location_items;
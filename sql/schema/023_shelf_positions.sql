@@ -0,0 +1,127 @@
+-- +goose Up
+-- capacity is the number of items a shelf holds, and width_cm its usable width. Either may be
+-- left empty. An item's thickness_cm is 0 when it hasn't been measured.
+ALTER TABLE shelves
+ADD COLUMN capacity INT,
+ADD COLUMN width_cm DOUBLE PRECISION;
+
+-- position orders the items on a shelf, across every media type.
+ALTER TABLE movies
+ADD COLUMN position INT NOT NULL DEFAULT 0,
+ADD COLUMN thickness_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE shows
+ADD COLUMN position INT NOT NULL DEFAULT 0,
+ADD COLUMN thickness_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE books
+ADD COLUMN position INT NOT NULL DEFAULT 0,
+ADD COLUMN thickness_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE music
+ADD COLUMN position INT NOT NULL DEFAULT 0,
+ADD COLUMN thickness_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE games
+ADD COLUMN position INT NOT NULL DEFAULT 0,
+ADD COLUMN thickness_cm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at,
+    movies.position, movies.thickness_cm
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at,
+    shows.position, shows.thickness_cm
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at,
+    books.position, books.thickness_cm
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at,
+    music.position, music.thickness_cm
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at,
+    games.position, games.thickness_cm
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+-- +goose Down
+DROP VIEW location_items;
+
+ALTER TABLE movies
+DROP COLUMN position,
+DROP COLUMN thickness_cm;
+ALTER TABLE shows
+DROP COLUMN position,
+DROP COLUMN thickness_cm;
+ALTER TABLE books
+DROP COLUMN position,
+DROP COLUMN thickness_cm;
+ALTER TABLE music
+DROP COLUMN position,
+DROP COLUMN thickness_cm;
+ALTER TABLE games
+DROP COLUMN position,
+DROP COLUMN thickness_cm;
+
+ALTER TABLE shelves
+DROP COLUMN capacity,
+DROP COLUMN width_cm;
+
+CREATE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
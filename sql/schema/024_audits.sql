@@ -0,0 +1,137 @@
+-- +goose Up
+-- An audit checks the items recorded at a location, case or shelf against the barcodes
+-- scanned on the shelves. Only one of case_id and shelf_id is set, or neither for a whole location.
+CREATE TABLE audits (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        case_id UUID REFERENCES cases(id) ON DELETE CASCADE,
+                        shelf_id UUID REFERENCES shelves(id) ON DELETE CASCADE,
+                        started_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        completed_at TIMESTAMP,
+                        CHECK (case_id IS NULL OR shelf_id IS NULL));
+
+-- shelf_id is the shelf the barcode was scanned on.
+CREATE TABLE audit_scans (id UUID PRIMARY KEY,
+                        audit_id UUID NOT NULL REFERENCES audits(id) ON DELETE CASCADE,
+                        barcode TEXT NOT NULL,
+                        shelf_id UUID NOT NULL REFERENCES shelves(id) ON DELETE CASCADE,
+                        scanned_at TIMESTAMP NOT NULL);
+
+CREATE INDEX idx_audit_scans_audit_id ON audit_scans(audit_id);
+
+-- missing_since is set when an audit marks an item as missing, and cleared when it's found again.
+ALTER TABLE movies
+ADD COLUMN missing_since TIMESTAMP;
+
+ALTER TABLE shows
+ADD COLUMN missing_since TIMESTAMP;
+
+ALTER TABLE books
+ADD COLUMN missing_since TIMESTAMP;
+
+ALTER TABLE music
+ADD COLUMN missing_since TIMESTAMP;
+
+ALTER TABLE games
+ADD COLUMN missing_since TIMESTAMP;
+
+CREATE OR REPLACE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at,
+    movies.position, movies.thickness_cm, movies.missing_since
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at,
+    shows.position, shows.thickness_cm, shows.missing_since
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at,
+    books.position, books.thickness_cm, books.missing_since
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at,
+    music.position, music.thickness_cm, music.missing_since
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at,
+    games.position, games.thickness_cm, games.missing_since
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+-- +goose Down
+DROP VIEW location_items;
+
+ALTER TABLE movies
+DROP COLUMN missing_since;
+ALTER TABLE shows
+DROP COLUMN missing_since;
+ALTER TABLE books
+DROP COLUMN missing_since;
+ALTER TABLE music
+DROP COLUMN missing_since;
+ALTER TABLE games
+DROP COLUMN missing_since;
+
+CREATE OR REPLACE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at,
+    movies.position, movies.thickness_cm
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at,
+    shows.position, shows.thickness_cm
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at,
+    books.position, books.thickness_cm
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at,
+    music.position, music.thickness_cm
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at,
+    games.position, games.thickness_cm
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+DROP INDEX idx_audit_scans_audit_id;
+DROP TABLE audit_scans;
+DROP TABLE audits;
@@ -0,0 +1,50 @@
+-- +goose Up
+-- Cases and shelves get a barcode that can be printed on a label and scanned. Existing and new
+-- cases and shelves are given a random code, which can be replaced with one of the user's own.
+ALTER TABLE cases
+ADD COLUMN barcode TEXT NOT NULL DEFAULT ('DSC-' || upper(substr(md5(gen_random_uuid()::text), 1, 10)));
+
+ALTER TABLE shelves
+ADD COLUMN barcode TEXT NOT NULL DEFAULT ('DSS-' || upper(substr(md5(gen_random_uuid()::text), 1, 10)));
+
+CREATE UNIQUE INDEX idx_cases_barcode ON cases(barcode);
+CREATE UNIQUE INDEX idx_shelves_barcode ON shelves(barcode);
+
+-- A scan session remembers the shelf that was last scanned, so that the items scanned after it
+-- can be shelved there.
+CREATE TABLE scan_sessions (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        started_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        shelf_id UUID REFERENCES shelves(id) ON DELETE SET NULL,
+                        closed_at TIMESTAMP);
+
+-- A draft is a scanned barcode that didn't match an item at the location, with any details
+-- that could be looked up for it. It's removed once an item is created from it.
+CREATE TABLE scan_drafts (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        session_id UUID NOT NULL REFERENCES scan_sessions(id) ON DELETE CASCADE,
+                        shelf_id UUID NOT NULL REFERENCES shelves(id) ON DELETE CASCADE,
+                        barcode TEXT NOT NULL,
+                        item_type TEXT NOT NULL DEFAULT '',
+                        title TEXT NOT NULL DEFAULT '',
+                        creator TEXT NOT NULL DEFAULT '',
+                        genre TEXT NOT NULL DEFAULT '',
+                        release_date TEXT NOT NULL DEFAULT '',
+                        source TEXT NOT NULL DEFAULT '');
+
+CREATE INDEX idx_scan_drafts_session_id ON scan_drafts(session_id);
+
+-- +goose Down
+DROP TABLE scan_drafts;
+DROP TABLE scan_sessions;
+
+DROP INDEX idx_shelves_barcode;
+DROP INDEX idx_cases_barcode;
+
+ALTER TABLE shelves
+DROP COLUMN barcode;
+
+ALTER TABLE cases
+DROP COLUMN barcode;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- pg_trgm compares the trigrams of a search with those of item titles and creators, so that
+-- searches with typos still find items. It's a trusted extension, so the database owner can
+-- create it. Searches are always limited to one location's items first, so the similarity is
+-- only worked out for those rows and the columns aren't given trigram indexes.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- +goose Down
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,51 @@
+-- +goose Up
+-- search_items has what's needed to search every media type at once. details are the other
+-- text fields that are searched, so that matches in them can be highlighted.
+CREATE VIEW search_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.director AS creator,
+    movies.genre, movies.format, movies.release_date, movies.release_date_precision,
+    concat_ws(' ', movies.actors, movies.writer)::text AS details, movies.search,
+    movies.shelf_id, shelves.name AS shelf_name, shelves.case_id, cases.name AS case_name,
+    cases.location_id
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.director,
+    shows.genre, shows.format, shows.release_date, shows.release_date_precision,
+    concat_ws(' ', shows.actors, shows.writer), shows.search,
+    shows.shelf_id, shelves.name, shelves.case_id, cases.name,
+    cases.location_id
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.author,
+    books.genre, '', books.publication_date, books.publication_date_precision,
+    concat_ws(' ', books.series, books.isbn, books.publisher), books.search,
+    books.shelf_id, shelves.name, shelves.case_id, cases.name,
+    cases.location_id
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.artist,
+    music.genre, music.format, music.release_date, music.release_date_precision,
+    concat_ws(' ', music.label, music.catalog_number), music.search,
+    music.shelf_id, shelves.name, shelves.case_id, cases.name,
+    cases.location_id
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.developer,
+    games.genre, games.platform, games.release_date, games.release_date_precision,
+    concat_ws(' ', games.publisher, games.edition), games.search,
+    games.shelf_id, shelves.name, shelves.case_id, cases.name,
+    cases.location_id
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+-- +goose Down
+DROP VIEW search_items;
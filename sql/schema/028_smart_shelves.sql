@@ -0,0 +1,15 @@
+-- +goose Up
+-- A smart shelf is a saved search of a location. filters are the search's facet filters, by
+-- facet name, and pinned smart shelves are shown in the web app's menu.
+CREATE TABLE smart_shelves (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        name TEXT NOT NULL,
+                        query TEXT NOT NULL DEFAULT '',
+                        filters JSONB NOT NULL DEFAULT '{}',
+                        pinned BOOLEAN NOT NULL DEFAULT false,
+                        UNIQUE (location_id, name));
+
+-- +goose Down
+DROP TABLE smart_shelves;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- A webhook posts a location's events to a URL. A webhook with no event_types gets every event.
+CREATE TABLE webhooks (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        url TEXT NOT NULL,
+                        secret TEXT NOT NULL,
+                        event_types TEXT[] NOT NULL DEFAULT '{}',
+                        active BOOLEAN NOT NULL DEFAULT true);
+
+CREATE INDEX idx_webhooks_location_id ON webhooks(location_id);
+
+-- Each event is queued as a delivery to each webhook that's subscribed to it. Pending deliveries
+-- are sent once next_attempt_at has passed, and tried again with a backoff until they succeed
+-- or fail too many times.
+CREATE TABLE webhook_deliveries (id UUID PRIMARY KEY,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL,
+                        webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                        event_type TEXT NOT NULL,
+                        payload JSONB NOT NULL,
+                        status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+                        attempts INT NOT NULL DEFAULT 0,
+                        next_attempt_at TIMESTAMP NOT NULL,
+                        last_attempt_at TIMESTAMP,
+                        response_status INT,
+                        last_error TEXT NOT NULL DEFAULT '');
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
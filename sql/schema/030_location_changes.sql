@@ -0,0 +1,136 @@
+-- +goose Up
+-- location_changes records every change to a location's cases, shelves and items, so clients
+-- that work offline can fetch what changed since they last synced. Deleted entities are
+-- recorded as 'delete' tombstones, and an item moved to another location is deleted from the
+-- old location's changes.
+--
+-- seq is the sync cursor. Changes are recorded without it, and are only given one once the
+-- transaction that made them, and every transaction that started before it, has finished.
+-- That hands out cursors in the order changes become visible, so a client never skips a change
+-- that was committed after it synced. id orders the changes to each entity.
+CREATE SEQUENCE location_change_seq;
+
+CREATE TABLE location_changes (id BIGSERIAL PRIMARY KEY,
+                        seq BIGINT UNIQUE,
+                        txid BIGINT NOT NULL DEFAULT txid_current(),
+                        location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+                        entity_type TEXT NOT NULL,
+                        entity_id UUID NOT NULL,
+                        operation TEXT NOT NULL CHECK (operation IN ('upsert', 'delete')),
+                        changed_at TIMESTAMP NOT NULL DEFAULT NOW());
+
+CREATE INDEX idx_location_changes_location_id ON location_changes(location_id, seq);
+CREATE INDEX idx_location_changes_entity ON location_changes(entity_type, entity_id, id);
+CREATE INDEX idx_location_changes_unsequenced ON location_changes(id) WHERE seq IS NULL;
+
+-- +goose StatementBegin
+CREATE FUNCTION record_location_change(change_location_id UUID, change_entity_type TEXT, change_entity_id UUID, change_operation TEXT)
+RETURNS void AS $$
+BEGIN
+    -- Entities removed along with their location have nowhere to be recorded.
+    IF change_location_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO location_changes (location_id, entity_type, entity_id, operation)
+    VALUES (change_location_id, change_entity_type, change_entity_id, change_operation);
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE FUNCTION record_case_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM record_location_change(OLD.location_id, 'case', OLD.id, 'delete');
+    ELSE
+        PERFORM record_location_change(NEW.location_id, 'case', NEW.id, 'upsert');
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE FUNCTION record_shelf_change() RETURNS trigger AS $$
+DECLARE
+    shelf_location_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        SELECT location_id INTO shelf_location_id FROM cases WHERE id = OLD.case_id;
+        PERFORM record_location_change(shelf_location_id, 'shelf', OLD.id, 'delete');
+    ELSE
+        SELECT location_id INTO shelf_location_id FROM cases WHERE id = NEW.case_id;
+        PERFORM record_location_change(shelf_location_id, 'shelf', NEW.id, 'upsert');
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- The item tables share one trigger function. Its argument is the item type.
+-- +goose StatementBegin
+CREATE FUNCTION record_item_change() RETURNS trigger AS $$
+DECLARE
+    old_location_id UUID;
+    new_location_id UUID;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        SELECT cases.location_id INTO old_location_id
+        FROM shelves JOIN cases ON cases.id = shelves.case_id
+        WHERE shelves.id = OLD.shelf_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        SELECT cases.location_id INTO new_location_id
+        FROM shelves JOIN cases ON cases.id = shelves.case_id
+        WHERE shelves.id = NEW.shelf_id;
+    END IF;
+
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND old_location_id IS DISTINCT FROM new_location_id) THEN
+        PERFORM record_location_change(old_location_id, TG_ARGV[0], OLD.id, 'delete');
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM record_location_change(new_location_id, TG_ARGV[0], NEW.id, 'upsert');
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER cases_location_changes AFTER INSERT OR UPDATE OR DELETE ON cases
+FOR EACH ROW EXECUTE FUNCTION record_case_change();
+CREATE TRIGGER shelves_location_changes AFTER INSERT OR UPDATE OR DELETE ON shelves
+FOR EACH ROW EXECUTE FUNCTION record_shelf_change();
+CREATE TRIGGER movies_location_changes AFTER INSERT OR UPDATE OR DELETE ON movies
+FOR EACH ROW EXECUTE FUNCTION record_item_change('movie');
+CREATE TRIGGER shows_location_changes AFTER INSERT OR UPDATE OR DELETE ON shows
+FOR EACH ROW EXECUTE FUNCTION record_item_change('show');
+CREATE TRIGGER books_location_changes AFTER INSERT OR UPDATE OR DELETE ON books
+FOR EACH ROW EXECUTE FUNCTION record_item_change('book');
+CREATE TRIGGER music_location_changes AFTER INSERT OR UPDATE OR DELETE ON music
+FOR EACH ROW EXECUTE FUNCTION record_item_change('music');
+CREATE TRIGGER games_location_changes AFTER INSERT OR UPDATE OR DELETE ON games
+FOR EACH ROW EXECUTE FUNCTION record_item_change('game');
+
+-- Everything that already exists is recorded once, so the first sync fetches all of it.
+INSERT INTO location_changes (location_id, entity_type, entity_id, operation)
+SELECT location_id, 'case', id, 'upsert' FROM cases;
+INSERT INTO location_changes (location_id, entity_type, entity_id, operation)
+SELECT cases.location_id, 'shelf', shelves.id, 'upsert' FROM shelves JOIN cases ON cases.id = shelves.case_id;
+INSERT INTO location_changes (location_id, entity_type, entity_id, operation)
+SELECT location_id, item_type, id, 'upsert' FROM location_items;
+
+-- +goose Down
+DROP TRIGGER games_location_changes ON games;
+DROP TRIGGER music_location_changes ON music;
+DROP TRIGGER books_location_changes ON books;
+DROP TRIGGER shows_location_changes ON shows;
+DROP TRIGGER movies_location_changes ON movies;
+DROP TRIGGER shelves_location_changes ON shelves;
+DROP TRIGGER cases_location_changes ON cases;
+DROP FUNCTION record_item_change();
+DROP FUNCTION record_shelf_change();
+DROP FUNCTION record_case_change();
+DROP FUNCTION record_location_change(UUID, TEXT, UUID, TEXT);
+DROP TABLE location_changes;
+DROP SEQUENCE location_change_seq;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- An idempotency key is sent with a create request, so that a retry of the request gets the
+-- original response instead of creating the entity again. Keys belong to the user who sent them.
+-- status_code is null while the first request is still being handled.
+CREATE TABLE idempotency_keys (user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        key TEXT NOT NULL,
+                        created_at TIMESTAMP NOT NULL,
+                        expires_at TIMESTAMP NOT NULL,
+                        request_hash TEXT NOT NULL,
+                        status_code INT,
+                        content_type TEXT NOT NULL DEFAULT '',
+                        response_body BYTEA,
+                        PRIMARY KEY (user_id, key));
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- +goose Down
+DROP TABLE idempotency_keys;
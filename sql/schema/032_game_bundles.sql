@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE games
+ADD COLUMN bundle_id UUID REFERENCES bundles(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE games
+DROP COLUMN bundle_id;
@@ -0,0 +1,50 @@
+-- +goose Up
+-- Items that were on a shelf before positions were added all have position 0, so shelves where
+-- positions are shared are numbered again from 0. Items are kept in the order of their position,
+-- and items that share one are ordered by title. Every table is updated in one statement, so
+-- that each is numbered from the same snapshot of the shelves.
+WITH numbered AS (
+    SELECT item_type, id,
+        ROW_NUMBER() OVER (PARTITION BY shelf_id ORDER BY position, title, id) - 1 AS position
+    FROM location_items
+    WHERE shelf_id IN (
+        SELECT shelf_id FROM location_items
+        GROUP BY shelf_id
+        HAVING COUNT(DISTINCT position) < COUNT(*)
+    )
+),
+movies_numbered AS (
+    UPDATE movies
+    SET position = numbered.position
+    FROM numbered
+    WHERE numbered.item_type = 'movie' AND movies.id = numbered.id
+    RETURNING movies.id
+),
+shows_numbered AS (
+    UPDATE shows
+    SET position = numbered.position
+    FROM numbered
+    WHERE numbered.item_type = 'show' AND shows.id = numbered.id
+    RETURNING shows.id
+),
+books_numbered AS (
+    UPDATE books
+    SET position = numbered.position
+    FROM numbered
+    WHERE numbered.item_type = 'book' AND books.id = numbered.id
+    RETURNING books.id
+),
+music_numbered AS (
+    UPDATE music
+    SET position = numbered.position
+    FROM numbered
+    WHERE numbered.item_type = 'music' AND music.id = numbered.id
+    RETURNING music.id
+)
+UPDATE games
+SET position = numbered.position
+FROM numbered
+WHERE numbered.item_type = 'game' AND games.id = numbered.id;
+
+-- +goose Down
+-- The positions that were shared can't be restored, and don't need to be.
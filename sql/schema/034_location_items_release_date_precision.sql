@@ -0,0 +1,87 @@
+-- +goose Up
+-- location_items has the precision of each item's release or publication date, so that a date
+-- from the view can be shown the way it was entered.
+CREATE OR REPLACE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at,
+    movies.position, movies.thickness_cm, movies.missing_since, movies.release_date_precision
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at,
+    shows.position, shows.thickness_cm, shows.missing_since, shows.release_date_precision
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at,
+    books.position, books.thickness_cm, books.missing_since, books.publication_date_precision
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at,
+    music.position, music.thickness_cm, music.missing_since, music.release_date_precision
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at,
+    games.position, games.thickness_cm, games.missing_since, games.release_date_precision
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;
+
+-- +goose Down
+DROP VIEW location_items;
+
+CREATE VIEW location_items AS
+SELECT 'movie'::text AS item_type, movies.id, movies.title, movies.genre, movies.format,
+    movies.director AS creator, movies.release_date, movies.barcode, movies.shelf_id,
+    shelves.case_id, cases.location_id, movies.created_at, movies.updated_at,
+    movies.position, movies.thickness_cm, movies.missing_since
+FROM movies
+INNER JOIN shelves ON movies.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'show', shows.id, shows.title, shows.genre, shows.format,
+    shows.director, shows.release_date, shows.barcode, shows.shelf_id,
+    shelves.case_id, cases.location_id, shows.created_at, shows.updated_at,
+    shows.position, shows.thickness_cm, shows.missing_since
+FROM shows
+INNER JOIN shelves ON shows.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'book', books.id, books.title, books.genre, '',
+    books.author, books.publication_date, books.barcode, books.shelf_id,
+    shelves.case_id, cases.location_id, books.created_at, books.updated_at,
+    books.position, books.thickness_cm, books.missing_since
+FROM books
+INNER JOIN shelves ON books.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'music', music.id, music.title, music.genre, music.format,
+    music.artist, music.release_date, music.barcode, music.shelf_id,
+    shelves.case_id, cases.location_id, music.created_at, music.updated_at,
+    music.position, music.thickness_cm, music.missing_since
+FROM music
+INNER JOIN shelves ON music.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id
+UNION ALL
+SELECT 'game', games.id, games.title, games.genre, games.platform,
+    games.developer, games.release_date, games.barcode, games.shelf_id,
+    shelves.case_id, cases.location_id, games.created_at, games.updated_at,
+    games.position, games.thickness_cm, games.missing_since
+FROM games
+INNER JOIN shelves ON games.shelf_id = shelves.id
+INNER JOIN cases ON shelves.case_id = cases.id;